@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	subtlecrypto "crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// serveToken is the token "gotask serve" requires of clients, and that
+// "gotask attach" sends, from GOTASK_TOKEN. Without one the server only
+// listens on loopback addresses.
+func serveToken() string {
+	return os.Getenv("GOTASK_TOKEN")
+}
+
+// checkExposure refuses to serve on an address other machines can reach
+// unless clients need a token
+func checkExposure(addr, token string) error {
+	if token != "" || isLoopback(addr) {
+		return nil
+	}
+	return fmt.Errorf("%s can be reached from other machines; set GOTASK_TOKEN to require a token, or listen on localhost", addr)
+}
+
+// isLoopback reports whether addr, host:port, only listens on this machine.
+// ":8080" listens everywhere.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	return err == nil && isLocalHost(host)
+}
+
+// isLocalHost reports whether a host name, without a port, is this machine
+func isLocalHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.IsLoopback()
+}
+
+// requireLocal turns away requests sent by web pages on other sites, which
+// a browser sends with their Origin. Without a token the API only listens
+// on this machine, so it also turns away requests for any other Host,
+// which is what a page that rebinds its own name to 127.0.0.1 sends.
+func requireLocal(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" {
+			u, err := url.Parse(origin)
+			if err != nil || !isLocalHost(u.Hostname()) {
+				writeError(w, &httpError{http.StatusForbidden, fmt.Errorf("requests from %s are not allowed", origin)})
+				return
+			}
+		}
+		if token == "" {
+			host, _, err := net.SplitHostPort(r.Host)
+			if err != nil {
+				host = r.Host
+			}
+			if !isLocalHost(host) {
+				writeError(w, &httpError{http.StatusForbidden, fmt.Errorf("requests for %s are not allowed; set GOTASK_TOKEN to serve other hosts", r.Host)})
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// validToken reports whether an Authorization header carries the token
+func validToken(header, token string) bool {
+	got, ok := strings.CutPrefix(header, "Bearer ")
+	return ok && subtlecrypto.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
+
+// requireToken turns away API requests without the token, if there is one
+func requireToken(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !validToken(r.Header.Get("Authorization"), token) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, &httpError{http.StatusUnauthorized, fmt.Errorf("missing or wrong token")})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// grpcTokenOptions make a gRPC server turn away calls without the token,
+// if there is one
+func grpcTokenOptions(token string) []grpc.ServerOption {
+	if token == "" {
+		return nil
+	}
+	check := func(ctx context.Context) error {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, header := range md.Get("authorization") {
+			if validToken(header, token) {
+				return nil
+			}
+		}
+		return status.Error(codes.Unauthenticated, "missing or wrong token")
+	}
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := check(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := check(ss.Context()); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}),
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+	"gotask/internal/stats"
+	"gotask/internal/storage"
+)
+
+// writeBurndownCSV writes the burndown with a header row
+func writeBurndownCSV(w io.Writer, points []stats.BurnPoint) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"date", "scope", "done", "remaining"})
+	for _, p := range points {
+		out.Write([]string{p.Day.Format(board.DueLayout), strconv.Itoa(p.Scope), strconv.Itoa(p.Done), strconv.Itoa(p.Remaining())})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// runBurndown implements "gotask burndown", which prints the burndown as
+// CSV for plotting elsewhere
+func runBurndown(args []string) error {
+	flags := flag.NewFlagSet("burndown", flag.ContinueOnError)
+	since := flags.String("since", "30d", "how far back to go, e.g. 14d or 6w")
+	path := flags.String("file", config.BoardPath(), "board file to read")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	days, err := stats.ParseDays(*since)
+	if err != nil {
+		return fmt.Errorf("-since: %w", err)
+	}
+	board, err := storage.Load(*path)
+	if err != nil {
+		return err
+	}
+	return writeBurndownCSV(os.Stdout, stats.Burndown(&board, days, time.Now()))
+}
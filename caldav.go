@@ -0,0 +1,434 @@
+package main
+
+import (
+	"encoding/xml"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gotask/internal/config"
+	"gotask/internal/httpjson"
+	"gotask/internal/storage"
+)
+
+// caldavClient talks to one calendar collection
+type caldavClient struct {
+	collection *url.URL
+	username   string
+	password   string
+}
+
+// caldavTodo is a VTODO resource on the server
+type caldavTodo struct {
+	href string // absolute URL of the resource
+	etag string
+	cal  *icalendar
+}
+
+// syncCalDAV implements "gotask sync caldav", a two-way sync between the
+// board and a CalDAV task list such as Nextcloud Tasks or Fastmail:
+//
+//   - tasks that aren't on the server yet are uploaded
+//   - to-dos that aren't on the board yet are added to it
+//   - a task changed on one side is updated on the other; when both
+//     changed, the newer change wins
+//   - tasks whose to-do was deleted on the server are archived
+//
+// Columns map to the to-do's status: the first column is NEEDS-ACTION, the
+// last COMPLETED, and those between IN-PROCESS.
+func syncCalDAV(args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	flags := flag.NewFlagSet("sync caldav", flag.ContinueOnError)
+	rawURL := flags.String("url", cfg.CalDAV.URL, "task list URL")
+	username := flags.String("user", cfg.CalDAV.Username, "user name")
+	path := flags.String("file", config.BoardPath(), "board file")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *rawURL == "" {
+		return errors.New("set -url or caldav.url in the config")
+	}
+	collection, err := url.Parse(strings.TrimSuffix(*rawURL, "/") + "/")
+	if err != nil {
+		return err
+	}
+	client := &caldavClient{
+		collection: collection,
+		username:   *username,
+		password:   firstNonEmpty(os.Getenv("CALDAV_PASSWORD"), cfg.CalDAV.Password),
+	}
+
+	var stats caldavStats
+	var failed error
+	err = storage.NewBoardFile(*path).Change(func(b *KanbanBoard) error {
+		todos, err := client.todos()
+		if err != nil {
+			return err
+		}
+		stats, failed = client.sync(b, todos, time.Now())
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Uploaded %d, downloaded %d, updated %d here and %d there, archived %d\n",
+		stats.uploaded, stats.downloaded, stats.pulled, stats.pushed, stats.archived)
+	return failed
+}
+
+// caldavStats counts what a sync did
+type caldavStats struct {
+	uploaded, downloaded, pulled, pushed, archived int
+}
+
+// sync brings the board and the server's to-dos in step. Failures to
+// upload single tasks are collected rather than stopping the sync, so that
+// what did sync is saved.
+func (c *caldavClient) sync(b *KanbanBoard, todos []caldavTodo, now time.Time) (caldavStats, error) {
+	var stats caldavStats
+	var errs []error
+	byUID := make(map[string]caldavTodo, len(todos))
+	for _, todo := range todos {
+		byUID[todo.cal.text("UID")] = todo
+	}
+
+	// Tasks may move to other columns as they sync, so go by their IDs
+	var ids []int
+	for _, col := range b.Columns {
+		for _, task := range col.Tasks {
+			ids = append(ids, task.ID)
+		}
+	}
+	for _, id := range ids {
+		col, idx, ok := b.FindTask(id)
+		if !ok {
+			continue
+		}
+		task := b.Columns[col].Tasks[idx]
+		src := task.Source
+		switch {
+		case src == nil:
+			todo := caldavTodo{cal: newVTodo(fmt.Sprintf("gotask-%d-%d", task.CreatedAt.Unix(), task.ID))}
+			if err := c.push(b, task.ID, todo, now); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			stats.uploaded++
+
+		case src.Kind != "caldav" || !c.owns(src.URL):
+			continue
+
+		default:
+			todo, ok := byUID[src.ID]
+			delete(byUID, src.ID)
+			if !ok {
+				b.ArchiveTask(task.ID, now)
+				stats.archived++
+				continue
+			}
+			localChanged := src.Synced == nil || task.LastUpdated().After(*src.Synced)
+			remoteChanged := todo.etag == "" || todo.etag != src.Version
+			switch {
+			case remoteChanged && (!localChanged || !todo.modified().Before(task.LastUpdated())):
+				pullTodo(b, task.ID, todo)
+				stats.pulled++
+			case localChanged:
+				if err := c.push(b, task.ID, todo, now); err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				stats.pushed++
+			}
+		}
+	}
+
+	// What is left are to-dos that aren't on the board
+	for uid, todo := range byUID {
+		if _, _, _, ok := findSource(b, "caldav", uid); ok {
+			continue // archived here
+		}
+		task := Task{ID: b.MaxID() + 1}
+		todo.fill(&task)
+		if task.Title == "" {
+			task.Title = "Untitled"
+		}
+		b.AddTask(statusColumn(b, todo.status(), 0), task)
+		linkTodo(b, task.ID, todo)
+		stats.downloaded++
+	}
+	return stats, errors.Join(errs...)
+}
+
+// owns reports whether a resource URL lies in the collection
+func (c *caldavClient) owns(href string) bool {
+	return strings.HasPrefix(href, c.collection.String())
+}
+
+// todoStatus is the VTODO status for a column
+func todoStatus(b *KanbanBoard, col int) string {
+	switch {
+	case b.IsDone(col):
+		return "COMPLETED"
+	case col > 0:
+		return "IN-PROCESS"
+	}
+	return "NEEDS-ACTION"
+}
+
+// statusColumn is the column for a VTODO status, staying in col when the
+// status already fits it
+func statusColumn(b *KanbanBoard, status string, col int) int {
+	if status == "CANCELLED" {
+		status = "COMPLETED"
+	}
+	if status == "" {
+		status = "NEEDS-ACTION"
+	}
+	if todoStatus(b, col) == status {
+		return col
+	}
+	switch status {
+	case "COMPLETED":
+		return len(b.Columns) - 1
+	case "IN-PROCESS":
+		return min(1, len(b.Columns)-1)
+	}
+	return 0
+}
+
+// modified is when the to-do last changed, or the zero time if it doesn't
+// say
+func (t caldavTodo) modified() time.Time {
+	params, value, _ := t.cal.get("LAST-MODIFIED")
+	modified, _ := parseICalTime(params, value)
+	return modified
+}
+
+// status is the to-do's STATUS
+func (t caldavTodo) status() string {
+	_, status, _ := t.cal.get("STATUS")
+	return strings.ToUpper(status)
+}
+
+// fill copies the to-do's fields onto a task
+func (t caldavTodo) fill(task *Task) {
+	task.Title = firstNonEmpty(t.cal.text("SUMMARY"), task.Title)
+	task.Description = t.cal.text("DESCRIPTION")
+	task.Due = nil
+	if params, value, ok := t.cal.get("DUE"); ok {
+		if due, ok := parseICalTime(params, value); ok {
+			task.Due = &due
+		}
+	}
+	task.Priority = PriorityNone
+	if _, value, ok := t.cal.get("PRIORITY"); ok {
+		switch p, _ := strconv.Atoi(value); {
+		case p >= 1 && p <= 4:
+			task.Priority = PriorityHigh
+		case p == 5:
+			task.Priority = PriorityMedium
+		case p >= 6:
+			task.Priority = PriorityLow
+		}
+	}
+	_, categories, _ := t.cal.get("CATEGORIES")
+	task.Tags = splitICalList(categories)
+}
+
+// pullTodo copies a to-do onto the task with the given ID, moving it to the
+// column that matches the to-do's status
+func pullTodo(b *KanbanBoard, id int, todo caldavTodo) {
+	col, idx, ok := b.FindTask(id)
+	if !ok {
+		return
+	}
+	next := b.Columns[col].Tasks[idx]
+	todo.fill(&next)
+	b.Columns[col].Tasks[idx].Update(next)
+	if to := statusColumn(b, todo.status(), col); to != col {
+		b.MoveTask(col, idx, to, -1)
+	}
+	linkTodo(b, id, todo)
+}
+
+// linkTodo records that the task with the given ID is in step with todo.
+// It comes after every change to the task, so that the sync itself doesn't
+// count as a local change next time.
+func linkTodo(b *KanbanBoard, id int, todo caldavTodo) {
+	col, idx, ok := b.FindTask(id)
+	if !ok {
+		return
+	}
+	now := time.Now()
+	b.Columns[col].Tasks[idx].Source = &TaskSource{
+		Kind: "caldav", ID: todo.cal.text("UID"), URL: todo.href, Version: todo.etag, Synced: &now,
+	}
+}
+
+// push uploads the task with the given ID, writing its fields into todo so
+// that properties gotask doesn't know about are kept
+func (c *caldavClient) push(b *KanbanBoard, id int, todo caldavTodo, now time.Time) error {
+	col, idx, ok := b.FindTask(id)
+	if !ok {
+		return nil
+	}
+	task := &b.Columns[col].Tasks[idx]
+	cal := todo.cal
+	stamp := now.UTC().Format(icalDateTime) + "Z"
+	cal.set("DTSTAMP", "", stamp)
+	cal.set("LAST-MODIFIED", "", task.LastUpdated().UTC().Format(icalDateTime)+"Z")
+	if _, _, ok := cal.get("CREATED"); !ok {
+		cal.set("CREATED", "", task.CreatedAt.UTC().Format(icalDateTime)+"Z")
+	}
+	cal.set("SUMMARY", "", escapeICalText(task.Title))
+	cal.set("DESCRIPTION", "", escapeICalText(task.Description))
+	if task.Due != nil {
+		params, value := formatICalTime(*task.Due)
+		cal.set("DUE", params, value)
+	} else {
+		cal.set("DUE", "", "")
+	}
+	priority := map[Priority]string{PriorityHigh: "1", PriorityMedium: "5", PriorityLow: "9"}[task.Priority]
+	cal.set("PRIORITY", "", priority)
+	categories := make([]string, len(task.Tags))
+	for i, tag := range task.Tags {
+		categories[i] = escapeICalText(tag)
+	}
+	cal.set("CATEGORIES", "", strings.Join(categories, ","))
+
+	status := todoStatus(b, col)
+	cal.set("STATUS", "", status)
+	if status == "COMPLETED" {
+		cal.set("PERCENT-COMPLETE", "", "100")
+		if task.CompletedAt != nil {
+			cal.set("COMPLETED", "", task.CompletedAt.UTC().Format(icalDateTime)+"Z")
+		}
+	} else {
+		cal.set("PERCENT-COMPLETE", "", "")
+		cal.set("COMPLETED", "", "")
+	}
+
+	href := todo.href
+	if href == "" {
+		ref, err := url.Parse(url.PathEscape(cal.text("UID")) + ".ics")
+		if err != nil {
+			return err
+		}
+		href = c.collection.ResolveReference(ref).String()
+	}
+	etag, err := c.put(href, todo.etag, cal.String())
+	if err != nil {
+		return fmt.Errorf("#%d %s: %w", task.ID, task.Title, err)
+	}
+	linkTodo(b, id, caldavTodo{href: href, etag: etag, cal: cal})
+	return nil
+}
+
+// do sends a request to the server
+func (c *caldavClient) do(method, href string, body io.Reader, header map[string]string) (*http.Response, error) {
+	req, err := http.NewRequest(method, href, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := httpjson.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 300))
+		return nil, fmt.Errorf("%s %s: %s: %s", method, req.URL.Redacted(), resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return resp, nil
+}
+
+// put uploads a to-do and returns its new ETag, or "" if the server
+// doesn't say. An etag guards against overwriting changes made since it
+// was fetched; without one, the resource must not exist yet.
+func (c *caldavClient) put(href, etag, data string) (string, error) {
+	header := map[string]string{"Content-Type": "text/calendar; charset=utf-8"}
+	if etag != "" {
+		header["If-Match"] = etag
+	} else {
+		header["If-None-Match"] = "*"
+	}
+	resp, err := c.do(http.MethodPut, href, strings.NewReader(data), header)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return resp.Header.Get("ETag"), nil
+}
+
+const caldavQuery = `<?xml version="1.0" encoding="utf-8"?>
+<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/><c:calendar-data/></d:prop>
+  <c:filter>
+    <c:comp-filter name="VCALENDAR"><c:comp-filter name="VTODO"/></c:comp-filter>
+  </c:filter>
+</c:calendar-query>`
+
+// todos fetches every to-do in the collection
+func (c *caldavClient) todos() ([]caldavTodo, error) {
+	resp, err := c.do("REPORT", c.collection.String(), strings.NewReader(caldavQuery), map[string]string{
+		"Content-Type": "application/xml; charset=utf-8",
+		"Depth":        "1",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var status struct {
+		Responses []struct {
+			Href     string `xml:"DAV: href"`
+			Propstat []struct {
+				Status string `xml:"DAV: status"`
+				ETag   string `xml:"DAV: prop>getetag"`
+				Data   string `xml:"urn:ietf:params:xml:ns:caldav prop>calendar-data"`
+			} `xml:"DAV: propstat"`
+		} `xml:"DAV: response"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", c.collection.Redacted(), err)
+	}
+
+	var todos []caldavTodo
+	for _, r := range status.Responses {
+		ref, err := url.Parse(r.Href)
+		if err != nil {
+			continue
+		}
+		for _, ps := range r.Propstat {
+			if ps.Data == "" || !strings.Contains(ps.Status, " 200 ") {
+				continue
+			}
+			cal := parseICal(ps.Data)
+			if _, _, ok := cal.todo(); !ok {
+				continue
+			}
+			todos = append(todos, caldavTodo{
+				href: c.collection.ResolveReference(ref).String(),
+				etag: ps.ETag,
+				cal:  cal,
+			})
+		}
+	}
+	return todos, nil
+}
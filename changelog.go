@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+	"gotask/internal/stats"
+	"gotask/internal/storage"
+)
+
+// changelogSections are the headings of a changelog, in order, with the
+// tags that put a task under each. Tasks with none of these tags go under
+// Changed.
+var changelogSections = []struct {
+	title string
+	tags  []string
+}{
+	{"Added", []string{"feature", "feat", "added", "add", "new", "enhancement"}},
+	{"Changed", []string{"changed", "change", "improvement", "refactor"}},
+	{"Fixed", []string{"bug", "fix", "fixed", "bugfix"}},
+	{"Removed", []string{"removed", "remove", "deprecated"}},
+	{"Security", []string{"security"}},
+}
+
+// changelogSection picks the section a finished task belongs under
+func changelogSection(task Task) string {
+	for _, tag := range task.Tags {
+		for _, section := range changelogSections {
+			for _, t := range section.tags {
+				if strings.EqualFold(tag, t) {
+					return section.title
+				}
+			}
+		}
+	}
+	return "Changed"
+}
+
+// writeChangelog writes the finished tasks as a Markdown changelog entry
+// in the Keep a Changelog style
+func writeChangelog(w io.Writer, tasks []stats.Completed, version string, now time.Time) {
+	fmt.Fprintf(w, "## %s - %s\n", version, now.Format(board.DueLayout))
+	sections := make(map[string][]stats.Completed)
+	for _, task := range tasks {
+		title := changelogSection(task.Task)
+		sections[title] = append(sections[title], task)
+	}
+	if len(tasks) == 0 {
+		fmt.Fprintln(w, "\nNothing finished yet.")
+	}
+	for _, section := range changelogSections {
+		if len(sections[section.title]) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n### %s\n\n", section.title)
+		for _, task := range sections[section.title] {
+			fmt.Fprintf(w, "- %s (#%d)\n", task.Title, task.ID)
+		}
+	}
+}
+
+// changelogSince works out when the changelog starts from a number of
+// days, a date, or a git tag or other revision, which starts it at that
+// commit
+func changelogSince(s string, now time.Time) (time.Time, error) {
+	if days, err := stats.ParseDays(s); err == nil {
+		return board.DayOf(now).AddDate(0, 0, 1-days), nil
+	}
+	if day, err := time.ParseInLocation(board.DueLayout, s, time.Local); err == nil {
+		return day, nil
+	}
+	out, err := exec.Command("git", "log", "-1", "--format=%cI", s, "--").Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a number of days, a date, or a git revision", s)
+	}
+	return time.Parse(time.RFC3339, strings.TrimSpace(string(out)))
+}
+
+// runChangelog implements "gotask export changelog", which writes the
+// tasks finished since a release as a changelog entry grouped by tag
+func runChangelog(args []string) error {
+	flags := flag.NewFlagSet("export changelog", flag.ContinueOnError)
+	sinceFlag := flags.String("since", "", "git tag, date, or number of days to start from, e.g. v1.2, 2024-05-01, or 30d")
+	version := flags.String("version", "Unreleased", "heading for the entry")
+	path := flags.String("file", config.BoardPath(), "board file to read")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *sinceFlag == "" {
+		return errors.New("-since is required, e.g. -since v1.2")
+	}
+	now := time.Now()
+	since, err := changelogSince(*sinceFlag, now)
+	if err != nil {
+		return fmt.Errorf("-since: %w", err)
+	}
+	board, err := storage.Load(*path)
+	if err != nil {
+		return err
+	}
+	writeChangelog(os.Stdout, stats.CompletedSince(&board, since), *version, now)
+	return nil
+}
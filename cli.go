@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"gotask/internal/config"
+	"gotask/internal/plugin"
+)
+
+// version is set at build time with -ldflags "-X main.version=..."
+var version = "dev"
+
+// subcommand is a way of running gotask other than the interactive board
+type subcommand struct {
+	summary string
+	run     func(args []string) error
+}
+
+// subcommands are run as "gotask <name> [args]"
+var subcommands = map[string]subcommand{
+	"attach":        {"open a board shared by gotask serve elsewhere", runAttach},
+	"burndown":      {"print the burndown as CSV", runBurndown},
+	"config":        {"write a starter config file, or print where it is", runConfig},
+	"daemon":        {"send notifications in the background", runDaemon},
+	"digest":        {"print or email a summary of what needs attention", runDigest},
+	"export":        {"export tasks with their time in each column, or a changelog", runExport},
+	"forecast":      {"estimate when the open tasks will be finished", runForecast},
+	"git-hook":      {"close tasks named in the last commit message", runGitHook},
+	"heatmap":       {"print a calendar heatmap of completions", runHeatmap},
+	"import":        {"import tasks from another tool", runImport},
+	"install-timer": {"check for notifications on a schedule with systemd or launchd", runInstallTimer},
+	"log":           {"print the board's change log, or the board replayed from it", runLog},
+	"merge":         {"merge conflicted copies of the board into it", runMerge},
+	"mcp":           {"serve the board to AI assistants over MCP", runMCP},
+	"prompt":        {"print a short summary for shell prompts", runPrompt},
+	"render":        {"print the board as a single frame, for golden files and screenshots", runRender},
+	"report":        {"list the tasks completed lately", runReport},
+	"serve":         {"serve the board over HTTP", runServe},
+	"standup":       {"print what was done, what is next, and what is blocked", runStandup},
+	"stats":         {"print board statistics and cycle times", runStats},
+	"status":        {"print a summary for status lines", runStatus},
+	"sync":          {"sync imported tasks with their source", runSync},
+}
+
+// exitStatus is the error of a subcommand that has already said what went
+// wrong, and only sets the status gotask exits with
+type exitStatus int
+
+func (s exitStatus) Error() string {
+	return fmt.Sprintf("exit status %d", int(s))
+}
+
+// runSubcommand runs the subcommand named by the first argument
+func runSubcommand(args []string) error {
+	name := args[0]
+	if name == "help" || name == "-h" || name == "--help" {
+		fmt.Print(usage())
+		return nil
+	}
+	// Bad settings are reported when the board is opened
+	cfg, _ := config.Load()
+	config.ApplyColumns(cfg)
+	if cfg.NoColor {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	cmd, ok := subcommands[name]
+	if !ok {
+		if path, ok := plugin.Installed()[name]; ok {
+			return runPlugin(path, args[1:])
+		}
+		return fmt.Errorf("unknown command %q\n\n%s", name, usage())
+	}
+	return cmd.run(args[1:])
+}
+
+// usage lists the subcommands
+func usage() string {
+	names := make([]string, 0, len(subcommands))
+	for name := range subcommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("Usage: gotask [--debug] [command]\n\nWith no command, gotask opens the board.\n\n")
+	fmt.Fprintf(&b, "Flags:\n  --debug       write a debug log to %s\n  --debug-file  write the debug log here instead\n\nCommands:\n", defaultDebugPath())
+	for _, name := range names {
+		fmt.Fprintf(&b, "  %-10s %s\n", name, subcommands[name].summary)
+	}
+	b.WriteString(pluginUsage())
+	b.WriteString(config.EnvUsage())
+	return b.String()
+}
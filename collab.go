@@ -0,0 +1,140 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gotask/internal/storage"
+	"gotask/internal/ui"
+)
+
+// Several people can work on one board at once. "gotask serve" shares it,
+// and "gotask attach" opens it from another machine. Each instance hears
+// about the others' changes: attached ones through the server's /events
+// stream, and the one next to the file by watching it. Saves that cross
+// are merged task by task, as conflicted copies are.
+
+// maxBoardSize limits the board a client may upload with PUT /board
+const maxBoardSize = 32 << 20
+
+// boardVersion identifies a version of the board, for ETags and /events
+func boardVersion(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// putBoard replaces the whole board. If-Match must name the version being
+// replaced, so that changes made in the meantime aren't lost.
+func (s *boardServer) putBoard(w http.ResponseWriter, r *http.Request) {
+	want := strings.Trim(r.Header.Get("If-Match"), `"`)
+	if want == "" {
+		writeError(w, &httpError{http.StatusPreconditionRequired, errors.New("If-Match is required")})
+		return
+	}
+	if err := requireJSON(r); err != nil {
+		writeError(w, err)
+		return
+	}
+	var next KanbanBoard
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBoardSize)).Decode(&next); err != nil {
+		writeError(w, badRequest("invalid board: %v", err))
+		return
+	}
+	if len(next.Columns) == 0 {
+		writeError(w, badRequest("the board has no columns"))
+		return
+	}
+
+	var version string
+	err := s.Change(func(b *KanbanBoard) error {
+		data, err := storage.Encode(*b)
+		if err != nil {
+			return err
+		}
+		if boardVersion(data) != want {
+			return &httpError{http.StatusPreconditionFailed, storage.ErrBoardChanged}
+		}
+		if data, err = storage.Encode(next); err != nil {
+			return err
+		}
+		*b, version = next, boardVersion(data)
+		return nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("ETag", `"`+version+`"`)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// events streams the board's version as server-sent events, once on
+// connecting and again whenever it changes
+func (s *boardServer) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, errors.New("streaming is not supported"))
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	var stamp storage.Stamp
+	last := ""
+	ticker := time.NewTicker(storage.WatchInterval)
+	defer ticker.Stop()
+	for {
+		if now := storage.Stat(s.Path); now != stamp || last == "" {
+			board, err := s.Read()
+			if err != nil {
+				return
+			}
+			data, err := storage.Encode(board)
+			if err != nil {
+				return
+			}
+			if version := boardVersion(data); version != last {
+				fmt.Fprintf(w, "event: board\ndata: %s\n\n", version)
+				flusher.Flush()
+				last = version
+			}
+			stamp = now
+		}
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runAttach implements "gotask attach", which opens a board shared with
+// "gotask serve" on another machine, e.g. "gotask attach
+// http://192.168.1.20:8080". Changes show up on both sides as they are made.
+// A server on the network needs a token, which is sent from GOTASK_TOKEN.
+func runAttach(args []string) error {
+	flags := flag.NewFlagSet("attach", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: gotask attach <server URL>")
+	}
+	addr := flags.Arg(0)
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+	u, err := url.Parse(addr)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("%q is not a server URL", flags.Arg(0))
+	}
+	return ui.Attach(strings.TrimSuffix(u.String(), "/"), serveToken())
+}
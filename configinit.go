@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gotask/internal/config"
+)
+
+// starterConfig is the config "gotask config init" writes: the common
+// settings at their defaults, with the rest commented out
+const starterConfig = `# gotask settings. Delete a setting to go back to its default.
+
+# Board file used when no -file is given
+board = "~/.kanban.json"
+
+# Columns a new board starts with; the last is where finished work goes
+columns = ["To Do", "In Progress", "Done"]
+
+# When changes are written: "on" after every change, "off" only on :w and
+# when quitting, or a duration such as "30s" to write at most that often
+autosave = "on"
+
+# default, light, gruvbox, nord, monochrome, or high-contrast
+theme = "default"
+# auto detects the terminal background; dark or light choose it
+background = "auto"
+
+[keys]
+# vim (hjkl) or arrows
+profile = "vim"
+
+# Replace the keys for an action, by the action's name
+[keys.bindings]
+# add = ["a", "n"]
+
+# Which destructive actions ask first: always, never, or description
+# (only tasks with a description)
+[confirm]
+delete = "always"
+archive = "always"
+bulk = "always"
+
+[display]
+# strike, dim, or plain
+done = "strike"
+title_lines = 3
+# marks, icons, or none
+priority = "marks"
+# none, created, or updated
+timestamp = "none"
+compact = false
+symbols = false
+
+[limits]
+# wip = { "In Progress" = 3 }
+# max_age = { "In Progress" = "5d" }
+`
+
+// runConfig implements "gotask config init", which writes a starter
+// config file, and "gotask config path", which prints where it is read
+func runConfig(args []string) error {
+	usage := errors.New("usage: gotask config init [-force] [-print] | gotask config path")
+	if len(args) == 0 {
+		return usage
+	}
+	switch args[0] {
+	case "path":
+		fmt.Println(config.Path())
+		return nil
+	case "init":
+	default:
+		return usage
+	}
+
+	flags := flag.NewFlagSet("config init", flag.ContinueOnError)
+	force := flags.Bool("force", false, "replace an existing config.toml")
+	print := flags.Bool("print", false, "print the config instead of writing it")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *print {
+		fmt.Print(starterConfig)
+		return nil
+	}
+
+	path := filepath.Join(config.Dir(), "config.toml")
+	if _, err := os.Stat(path); err == nil && !*force {
+		return fmt.Errorf("%s already exists; pass -force to replace it", path)
+	}
+	if err := os.MkdirAll(config.Dir(), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(starterConfig), 0644); err != nil {
+		return err
+	}
+	fmt.Println("Wrote", path)
+	legacy := filepath.Join(config.Dir(), "config.json")
+	if _, err := os.Stat(legacy); err == nil {
+		fmt.Printf("%s is ignored now; move its settings into config.toml\n", legacy)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gotask/internal/config"
+	"gotask/internal/stats"
+	"gotask/internal/storage"
+)
+
+// runStats implements "gotask stats", which prints what the statistics
+// screen shows: the tasks in each column, what was finished, how long
+// tasks spend in each column, and all of that by tag. With -json it writes
+// every statistic the screen shows as JSON instead.
+func runStats(args []string) error {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	since := flags.String("since", "30d", "how far back to measure times, e.g. 14d or 6w")
+	asJSON := flags.Bool("json", false, "write every statistic as JSON, for dashboards and scripts")
+	path := flags.String("file", config.BoardPath(), "board file to read")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	days, err := stats.ParseDays(*since)
+	if err != nil {
+		return fmt.Errorf("-since: %w", err)
+	}
+	board, err := storage.Load(*path)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return writeStatsJSON(os.Stdout, &board, days, time.Now())
+	}
+	return writeStats(os.Stdout, &board, days, time.Now())
+}
+
+// writeStats writes the board statistics as plain text, measuring times
+// over the last days days
+func writeStats(w io.Writer, board *KanbanBoard, days int, now time.Time) error {
+	s := stats.Of(board, now)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, col := range s.Columns {
+		fmt.Fprintf(tw, "%s\t%d\n", col.Title, col.Count)
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "\nCompleted this week: %d\n", s.CompletedThisWeek())
+	fmt.Fprintf(w, "Open tasks: %d", s.Open)
+	if s.Open > 0 {
+		fmt.Fprintf(w, ", average age %s\nOldest: #%d %s (%s, %s old)",
+			stats.AgeDays(s.AverageAge), s.Oldest.ID, s.Oldest.Title, s.OldestIn, stats.AgeDays(now.Sub(s.Oldest.CreatedAt)))
+	}
+	v := stats.Velocity(board, stats.VelocityWeeks, now)
+	weekly := make([]string, len(v))
+	for i, week := range v {
+		weekly[i] = strconv.FormatFloat(stats.RoundTenth(week.Points), 'f', -1, 64)
+	}
+	fmt.Fprintf(w, "\nVelocity, points a week since %s: %s (average %s)\n",
+		v[0].Start.Format("Jan 2"), strings.Join(weekly, " "), strconv.FormatFloat(stats.RoundTenth(stats.AverageVelocity(v)), 'f', -1, 64))
+
+	fmt.Fprintf(w, "\nTime in column, last %d days:\n", days)
+	if err := stats.WriteCycleTimes(w, stats.NewCycleTimes(board, now.AddDate(0, 0, -days))); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "\nBy tag, last %d days:\n", days)
+	return stats.WriteTags(w, stats.ByTag(board, now.AddDate(0, 0, -days)))
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"gotask/internal/config"
+	"gotask/internal/notify"
+	"gotask/internal/storage"
+)
+
+// daemonInterval is how often the daemon looks for tasks that fell due
+const daemonInterval = time.Minute
+
+// runDaemon implements "gotask daemon", which runs without the board,
+// sending notifications for due tasks, the daily email digest, and the
+// scheduled reports, and optionally serving the HTTP API. It also notes
+// the column counts each day for the cumulative flow diagram. It stops
+// cleanly on SIGINT or SIGTERM, so it can run as a systemd user service:
+//
+//	[Service]
+//	ExecStart=%h/go/bin/gotask daemon -serve localhost:8080
+//
+// With -once it checks once and exits, remembering what it sent between
+// runs, for timers set up by "gotask install-timer".
+func runDaemon(args []string) error {
+	flags := flag.NewFlagSet("daemon", flag.ContinueOnError)
+	serve := flags.String("serve", "", "also serve the HTTP API and /metrics on this address")
+	path := flags.String("file", config.BoardPath(), "board file to watch")
+	once := flags.Bool("once", false, "check once and exit, for running from a timer")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *once && *serve != "" {
+		return errors.New("-once and -serve can't be used together")
+	}
+	if *serve != "" {
+		if err := checkExposure(*serve, serveToken()); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	notifier, err := notify.NewSettings(cfg.Notify)
+	if err != nil {
+		log.Print(err)
+	}
+	digestAt := time.Duration(-1)
+	if cfg.Email.Digest != "" {
+		if digestAt, err = notify.ParseTimeOfDay(cfg.Email.Digest); err != nil {
+			return fmt.Errorf("email.digest: %w", err)
+		}
+		if cfg.Email.To == "" {
+			return errors.New("email.digest needs email.to")
+		}
+	}
+	reports, err := newScheduledReports(cfg.Reports, cfg.Email)
+	if err != nil {
+		return err
+	}
+	if len(notifier.Sinks) == 0 && digestAt < 0 && len(reports) == 0 && *serve == "" {
+		return fmt.Errorf("nothing to do: configure notify, email.digest, or reports in %s, or pass -serve", config.Path())
+	}
+
+	if *once {
+		return checkOnce(*path, notifier, cfg.Email, digestAt, reports, time.Now())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	var srv *http.Server
+	if *serve != "" {
+		srv = &http.Server{Addr: *serve, Handler: newBoardServer(*path, serveToken()).handler()}
+		go func() {
+			log.Printf("serving %s on http://%s", *path, *serve)
+			if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				errs <- err
+			}
+		}()
+	}
+
+	log.Printf("watching %s", *path)
+	sent := make(map[string]bool)
+	ticker := time.NewTicker(daemonInterval)
+	defer ticker.Stop()
+	for now := time.Now(); ; {
+		checkBoard(*path, notifier, now, sent)
+		checkDigest(*path, cfg.Email, digestAt, now, sent)
+		checkReports(*path, reports, now, sent)
+		checkFlow(*path, now)
+		select {
+		case now = <-ticker.C:
+		case err := <-errs:
+			return err
+		case <-ctx.Done():
+			log.Print("stopping")
+			if srv != nil {
+				shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				return srv.Shutdown(shutdown)
+			}
+			return nil
+		}
+	}
+}
+
+// checkBoard reads the board and sends the alerts that are due, logging
+// rather than stopping on errors
+func checkBoard(path string, notifier notify.Settings, now time.Time, sent map[string]bool) {
+	if len(notifier.Sinks) == 0 {
+		return
+	}
+	board, err := storage.Load(path)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+	alerts := notifier.PendingAlerts(&board, now, sent)
+	for _, a := range alerts {
+		log.Printf("%s: %s", a.Title, a.Body)
+	}
+	if err := notifier.Deliver(alerts); err != nil {
+		log.Print(err)
+	}
+}
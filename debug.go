@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"gotask/internal/debug"
+)
+
+// defaultDebugPath is where --debug writes unless --debug-file says
+// otherwise, e.g. ~/.cache/gotask/debug.log
+func defaultDebugPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "gotask", "debug.log")
+}
+
+// startDebugLog appends structured logs to the file at path for the rest
+// of the run, including what the standard logger prints. It returns a
+// function that closes the file.
+func startDebugLog(path string, args []string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening debug log: %w", err)
+	}
+	debug.Log = slog.New(slog.NewTextHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	slog.SetDefault(debug.Log)
+	debug.Log.Info("start", "version", version, "args", args, "pid", os.Getpid())
+	return func() {
+		debug.Log.Info("exit")
+		f.Close()
+	}, nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+	"gotask/internal/notify"
+	"gotask/internal/storage"
+)
+
+// runDigest implements "gotask digest", which prints a summary of overdue,
+// due-today, and in-progress tasks, or emails it with -email. It is meant
+// for cron; "gotask daemon" can also send it daily (see config.Email).
+func runDigest(args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	flags := flag.NewFlagSet("digest", flag.ContinueOnError)
+	to := flags.String("email", cfg.Email.To, "send the digest to this address instead of printing it")
+	path := flags.String("file", config.BoardPath(), "board file to read")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	board, err := storage.Load(*path)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	d := notify.NewDigest(&board, now)
+	if *to == "" {
+		fmt.Printf("%s\n\n%s", d.Subject(), d.Text(now))
+		return nil
+	}
+	return sendMail(cfg.Email, *to, d.Subject(), d.Text(now))
+}
+
+// checkDigest emails the daily digest once its time of day has come, for
+// the daemon, logging rather than stopping on errors
+func checkDigest(path string, cfg config.Email, at time.Duration, now time.Time, sent map[string]bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	key := "digest " + today.Format(board.DueLayout)
+	if at < 0 || now.Before(today.Add(at)) || sent[key] {
+		return
+	}
+	sent[key] = true
+	board, err := storage.Load(path)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+	d := notify.NewDigest(&board, now)
+	if err := sendMail(cfg, cfg.To, d.Subject(), d.Text(now)); err != nil {
+		log.Print(err)
+		return
+	}
+	log.Printf("sent digest to %s", cfg.To)
+}
+
+// sendMail sends a plain text message through the configured server
+func sendMail(cfg config.Email, to, subject, body string) error {
+	if cfg.Host == "" {
+		return errors.New("set email.host in the config to send mail")
+	}
+	port := cfg.Port
+	if port == 0 {
+		port = 587
+	}
+	from := firstNonEmpty(cfg.From, cfg.Username)
+	if from == "" {
+		return errors.New("set email.from in the config to send mail")
+	}
+
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\n"+
+		"MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\nContent-Transfer-Encoding: 8bit\r\n\r\n%s",
+		from, to, subject, time.Now().Format(time.RFC1123Z),
+		strings.ReplaceAll(body, "\n", "\r\n"))
+
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(port))
+	var conn net.Conn
+	var err error
+	if port == 465 {
+		conn, err = tls.Dial("tcp", addr, &tls.Config{ServerName: cfg.Host})
+	} else {
+		conn, err = net.DialTimeout("tcp", addr, 30*time.Second)
+	}
+	if err != nil {
+		return err
+	}
+	c, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok && port != 465 {
+		if err := c.StartTLS(&tls.Config{ServerName: cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if password := firstNonEmpty(os.Getenv("GOTASK_SMTP_PASSWORD"), cfg.Password); password != "" {
+		if err := c.Auth(smtp.PlainAuth("", cfg.Username, password, cfg.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte(msg)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"gotask/internal/config"
+	"gotask/internal/storage"
+)
+
+// runLog implements "gotask log", which prints the board's change log as
+// an audit trail, or the board replayed from it
+func runLog(args []string) error {
+	flags := flag.NewFlagSet("log", flag.ContinueOnError)
+	limit := flags.Int("n", 50, "how many of the latest events to print, 0 for all")
+	replay := flags.Bool("replay", false, "print the board replayed from the log instead")
+	path := flags.String("file", config.BoardPath(), "board file whose log to read")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	l, err := storage.OpenEventLog(storage.EventLogPath(*path))
+	if err != nil {
+		return err
+	}
+	if *replay {
+		board, err := l.Board()
+		if err != nil {
+			return err
+		}
+		data, err := storage.Encode(board)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Printf("%s\n", data)
+		return err
+	}
+	// Only the latest events are held in memory; the rest are in the file
+	entries := l.Entries
+	if *limit == 0 || *limit > len(entries) {
+		if entries, err = storage.LogEntries(l.Path); err != nil {
+			return err
+		}
+	}
+	if *limit > 0 && len(entries) > *limit {
+		entries = entries[len(entries)-*limit:]
+	}
+	for _, e := range entries {
+		what := e.Summary
+		if e.Target != 0 {
+			what = fmt.Sprintf("%s (event %d)", what, e.Target)
+		}
+		fmt.Printf("%5d  %s  %-8s  %s\n", e.Seq, e.At.Local().Format("2006-01-02 15:04:05"), e.Kind, what)
+	}
+	return nil
+}
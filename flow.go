@@ -0,0 +1,26 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"gotask/internal/storage"
+)
+
+// checkFlow notes the day's column counts for the daemon, so that days the
+// board isn't touched still show in the cumulative flow diagram. The file
+// is only written when the counts aren't noted yet.
+func checkFlow(path string, now time.Time) {
+	board, err := storage.Load(path)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+	if !board.RecordFlow(now) {
+		return
+	}
+	// Saving notes the counts
+	if err := storage.NewBoardFile(path).Change(func(*KanbanBoard) error { return nil }); err != nil {
+		log.Print(err)
+	}
+}
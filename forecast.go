@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gotask/internal/config"
+	"gotask/internal/stats"
+	"gotask/internal/storage"
+)
+
+// writeForecast prints the forecast with a line for each open task
+func writeForecast(w io.Writer, f stats.Forecast, ok bool, now time.Time) error {
+	if len(f.Tasks) == 0 {
+		_, err := fmt.Fprintln(w, "No open tasks")
+		return err
+	}
+	if !ok {
+		_, err := fmt.Fprintf(w, "No tasks finished in the last %d days to forecast from\n", f.Days)
+		return err
+	}
+	fmt.Fprintln(w, strings.Join(f.Summary(now), "\n"))
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "task\tcolumn\t50%\t85%\t95%")
+	for _, t := range f.Tasks {
+		fmt.Fprintf(tw, "#%d %s\t%s\t%s\t%s\t%s\n", t.Task.ID, t.Task.Title, t.Column,
+			stats.ForecastDate(t.P50, now), stats.ForecastDate(t.P85, now), stats.ForecastDate(t.P95, now))
+	}
+	return tw.Flush()
+}
+
+// runForecast implements "gotask forecast", which estimates when the open
+// tasks will be finished from recent throughput
+func runForecast(args []string) error {
+	flags := flag.NewFlagSet("forecast", flag.ContinueOnError)
+	sinceFlag := flags.String("since", "30d", "how much throughput to forecast from, e.g. 30d or 6w")
+	path := flags.String("file", config.BoardPath(), "board file to read")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	days, err := stats.ParseDays(*sinceFlag)
+	if err != nil {
+		return fmt.Errorf("-since: %w", err)
+	}
+	board, err := storage.Load(*path)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	f, ok := stats.NewForecast(&board, days, now)
+	return writeForecast(os.Stdout, f, ok, now)
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gotask/internal/config"
+	"gotask/internal/storage"
+)
+
+// commitRefs match the ways a commit message can close a task:
+// "gotask:#42", and "closes GT-42" with close, fix, or resolve in any tense
+var commitRefs = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\bgotask:\s*#(\d+)\b`),
+	regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?)\s*:?\s+GT-(\d+)\b`),
+}
+
+// closedTasks lists the task IDs a commit message closes, in order and
+// without repeats
+func closedTasks(message string) []int {
+	type ref struct{ at, id int }
+	var refs []ref
+	for _, re := range commitRefs {
+		for _, m := range re.FindAllStringSubmatchIndex(message, -1) {
+			id, err := strconv.Atoi(message[m[2]:m[3]])
+			if err == nil {
+				refs = append(refs, ref{m[0], id})
+			}
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].at < refs[j].at })
+	var ids []int
+	seen := make(map[int]bool)
+	for _, r := range refs {
+		if !seen[r.id] {
+			seen[r.id] = true
+			ids = append(ids, r.id)
+		}
+	}
+	return ids
+}
+
+// postCommitHook is the line "gotask git-hook install" adds to the
+// post-commit hook
+const postCommitHook = "gotask git-hook"
+
+// runGitHook implements "gotask git-hook", which moves the tasks the last
+// commit closes to the last column, and "gotask git-hook install", which
+// runs it after every commit in the current repository
+func runGitHook(args []string) error {
+	if len(args) > 0 && args[0] == "install" {
+		return installGitHook()
+	}
+	flags := flag.NewFlagSet("git-hook", flag.ContinueOnError)
+	message := flags.String("message", "", "commit message to scan instead of the last commit's")
+	path := flags.String("file", config.BoardPath(), "board file to update")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *message == "" {
+		out, err := exec.Command("git", "log", "-1", "--format=%B").Output()
+		if err != nil {
+			return fmt.Errorf("reading the last commit: %w", err)
+		}
+		*message = string(out)
+	}
+	ids := closedTasks(*message)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var moved []string
+	var missing []int
+	err := storage.NewBoardFile(*path).Change(func(b *KanbanBoard) error {
+		done := len(b.Columns) - 1
+		for _, id := range ids {
+			col, idx, ok := b.FindTask(id)
+			switch {
+			case !ok:
+				missing = append(missing, id)
+			case !b.IsDone(col):
+				b.MoveTask(col, idx, done, -1)
+				moved = append(moved, fmt.Sprintf("#%d", id))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(moved) > 0 {
+		fmt.Printf("gotask: closed %s\n", strings.Join(moved, ", "))
+	}
+	for _, id := range missing {
+		fmt.Fprintf(os.Stderr, "gotask: no task #%d\n", id)
+	}
+	return nil
+}
+
+// installGitHook adds "gotask git-hook" to the repository's post-commit
+// hook, creating the hook if there is none
+func installGitHook() error {
+	out, err := exec.Command("git", "rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		return errors.New("not in a git repository")
+	}
+	dir := strings.TrimSpace(string(out))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	hook := filepath.Join(dir, "post-commit")
+
+	data, err := os.ReadFile(hook)
+	switch {
+	case os.IsNotExist(err):
+		data = []byte("#!/bin/sh\n")
+	case err != nil:
+		return err
+	case strings.Contains(string(data), postCommitHook):
+		fmt.Printf("%s already runs %s\n", hook, postCommitHook)
+		return nil
+	case len(data) > 0 && data[len(data)-1] != '\n':
+		data = append(data, '\n')
+	}
+	data = append(data, postCommitHook+"\n"...)
+	if err := os.WriteFile(hook, data, 0755); err != nil {
+		return err
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(hook, 0755); err != nil {
+		return err
+	}
+	fmt.Printf("Installed %s\n", hook)
+	return nil
+}
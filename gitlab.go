@@ -0,0 +1,231 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+	"gotask/internal/httpjson"
+	"gotask/internal/storage"
+)
+
+// gitlabIssue holds the fields of a GitLab issue that make up a task
+type gitlabIssue struct {
+	IID         int      `json:"iid"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	State       string   `json:"state"` // "opened" or "closed"
+	Labels      []string `json:"labels"`
+	DueDate     string   `json:"due_date"`
+	WebURL      string   `json:"web_url"`
+}
+
+// gitlabClient talks to the issues API of one project
+type gitlabClient struct {
+	base    string // API root, e.g. https://gitlab.com/api/v4
+	token   string
+	project string
+}
+
+// gitlabCommand holds the flags shared by the GitLab commands
+type gitlabCommand struct {
+	client *gitlabClient
+	path   string // board file
+	column string // column for imported issues
+}
+
+// parseGitLabFlags parses the flags of a GitLab command, falling back on
+// the config file
+func parseGitLabFlags(name string, args []string) (gitlabCommand, error) {
+	var cmd gitlabCommand
+	cfg, err := config.Load()
+	if err != nil {
+		return cmd, err
+	}
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	project := flags.String("project", cfg.GitLab.Project, "project path, e.g. group/project")
+	base := flags.String("url", firstNonEmpty(cfg.GitLab.URL, "https://gitlab.com"), "GitLab instance")
+	flags.StringVar(&cmd.path, "file", config.BoardPath(), "board file")
+	flags.StringVar(&cmd.column, "column", "", "column for imported issues (default the first)")
+	if err := flags.Parse(args); err != nil {
+		return cmd, err
+	}
+	if *project == "" {
+		return cmd, fmt.Errorf("set -project or gitlab.project in the config")
+	}
+	cmd.client = &gitlabClient{
+		base:    strings.TrimSuffix(*base, "/") + "/api/v4",
+		token:   firstNonEmpty(os.Getenv("GITLAB_TOKEN"), cfg.GitLab.Token),
+		project: *project,
+	}
+	return cmd, nil
+}
+
+func (c *gitlabClient) request(method, path string, query url.Values) (*http.Request, error) {
+	u := fmt.Sprintf("%s/projects/%s%s", c.base, url.PathEscape(c.project), path)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("PRIVATE-TOKEN", c.token)
+	}
+	return req, nil
+}
+
+// openIssues fetches every open issue in the project
+func (c *gitlabClient) openIssues() ([]gitlabIssue, error) {
+	var all []gitlabIssue
+	for page := "1"; page != ""; {
+		req, err := c.request(http.MethodGet, "/issues", url.Values{
+			"state": {"opened"}, "per_page": {"100"}, "page": {page},
+		})
+		if err != nil {
+			return nil, err
+		}
+		var issues []gitlabIssue
+		header, err := httpjson.Fetch(req, &issues)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, issues...)
+		page = header.Get("X-Next-Page")
+	}
+	return all, nil
+}
+
+// issue fetches a single issue
+func (c *gitlabClient) issue(iid int) (gitlabIssue, error) {
+	var issue gitlabIssue
+	req, err := c.request(http.MethodGet, "/issues/"+strconv.Itoa(iid), nil)
+	if err != nil {
+		return issue, err
+	}
+	_, err = httpjson.Fetch(req, &issue)
+	return issue, err
+}
+
+// setState closes or reopens an issue
+func (c *gitlabClient) setState(iid int, event string) error {
+	req, err := c.request(http.MethodPut, "/issues/"+strconv.Itoa(iid), url.Values{"state_event": {event}})
+	if err != nil {
+		return err
+	}
+	_, err = httpjson.Fetch(req, nil)
+	return err
+}
+
+// sourceID identifies an issue across projects, e.g. "group/project#12"
+func (c *gitlabClient) sourceID(iid int) string {
+	return fmt.Sprintf("%s#%d", c.project, iid)
+}
+
+// task turns an issue into a task
+func (c *gitlabClient) task(issue gitlabIssue) Task {
+	task := Task{
+		Title:       issue.Title,
+		Description: issue.Description,
+		Source:      &TaskSource{Kind: "gitlab", ID: c.sourceID(issue.IID), URL: issue.WebURL},
+	}
+	for _, label := range issue.Labels {
+		task.AddTag(label)
+	}
+	if due, err := time.ParseInLocation(board.DueLayout, issue.DueDate, time.Local); err == nil {
+		task.Due = &due
+	}
+	return task
+}
+
+// importGitLab implements "gotask import gitlab", which adds the project's
+// open issues to the board. Issues imported before are skipped.
+func importGitLab(args []string) error {
+	cmd, err := parseGitLabFlags("import gitlab", args)
+	if err != nil {
+		return err
+	}
+	client := cmd.client
+	issues, err := client.openIssues()
+	if err != nil {
+		return err
+	}
+
+	added := 0
+	err = storage.NewBoardFile(cmd.path).Change(func(b *KanbanBoard) error {
+		col, err := importColumn(b, cmd.column)
+		if err != nil {
+			return err
+		}
+		tasks := make([]Task, len(issues))
+		columns := make([]int, len(issues))
+		for i, issue := range issues {
+			tasks[i], columns[i] = client.task(issue), col
+		}
+		added = importTasks(b, tasks, columns)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Imported %d of %d open issues from %s\n", added, len(issues), client.project)
+	return nil
+}
+
+// syncGitLab implements "gotask sync gitlab": issues whose tasks reached
+// the done column are closed, and tasks whose issues were closed on GitLab
+// move to the done column
+func syncGitLab(args []string) error {
+	cmd, err := parseGitLabFlags("sync gitlab", args)
+	if err != nil {
+		return err
+	}
+	client := cmd.client
+	prefix := client.project + "#"
+
+	closed, finished := 0, 0
+	err = storage.NewBoardFile(cmd.path).Change(func(b *KanbanBoard) error {
+		done := len(b.Columns) - 1
+		for i := range b.Columns {
+			// Moving tasks changes the column, so walk it from the end
+			for j := len(b.Columns[i].Tasks) - 1; j >= 0; j-- {
+				task := b.Columns[i].Tasks[j]
+				if task.Source == nil || task.Source.Kind != "gitlab" || !strings.HasPrefix(task.Source.ID, prefix) {
+					continue
+				}
+				iid, err := strconv.Atoi(strings.TrimPrefix(task.Source.ID, prefix))
+				if err != nil {
+					continue
+				}
+				issue, err := client.issue(iid)
+				if err != nil {
+					return err
+				}
+				switch {
+				case b.IsDone(i) && issue.State == "opened":
+					if err := client.setState(iid, "close"); err != nil {
+						return err
+					}
+					closed++
+				case !b.IsDone(i) && issue.State == "closed":
+					b.MoveTask(i, j, done, -1)
+					finished++
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Closed %d issues and finished %d tasks\n", closed, finished)
+	return nil
+}
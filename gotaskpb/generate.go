@@ -0,0 +1,5 @@
+// Package gotaskpb holds the protobuf messages and gRPC service of the
+// gotask board API, generated from gotask.proto.
+package gotaskpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative gotask.proto
@@ -0,0 +1,370 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: gotask.proto
+
+// The gotask board API, served by "gotask serve -grpc". It offers what the
+// REST API does, with typed messages, plus a stream of changes to the board.
+
+package gotaskpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Board_GetBoard_FullMethodName   = "/gotask.v1.Board/GetBoard"
+	Board_ListTasks_FullMethodName  = "/gotask.v1.Board/ListTasks"
+	Board_GetTask_FullMethodName    = "/gotask.v1.Board/GetTask"
+	Board_AddTask_FullMethodName    = "/gotask.v1.Board/AddTask"
+	Board_UpdateTask_FullMethodName = "/gotask.v1.Board/UpdateTask"
+	Board_MoveTask_FullMethodName   = "/gotask.v1.Board/MoveTask"
+	Board_WatchBoard_FullMethodName = "/gotask.v1.Board/WatchBoard"
+)
+
+// BoardClient is the client API for Board service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BoardClient interface {
+	// GetBoard returns every column and its tasks
+	GetBoard(ctx context.Context, in *GetBoardRequest, opts ...grpc.CallOption) (*BoardSnapshot, error)
+	// ListTasks returns every task, or those in one column or matching a search
+	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
+	GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	// AddTask adds a task, to the first column unless another is given
+	AddTask(ctx context.Context, in *AddTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	// UpdateTask changes the fields that are set in the request
+	UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	// MoveTask moves a task to another column or position
+	MoveTask(ctx context.Context, in *MoveTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	// WatchBoard streams changes to tasks as they happen, whether made through
+	// the API or anywhere else
+	WatchBoard(ctx context.Context, in *WatchBoardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BoardEvent], error)
+}
+
+type boardClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBoardClient(cc grpc.ClientConnInterface) BoardClient {
+	return &boardClient{cc}
+}
+
+func (c *boardClient) GetBoard(ctx context.Context, in *GetBoardRequest, opts ...grpc.CallOption) (*BoardSnapshot, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BoardSnapshot)
+	err := c.cc.Invoke(ctx, Board_GetBoard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *boardClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTasksResponse)
+	err := c.cc.Invoke(ctx, Board_ListTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *boardClient) GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, Board_GetTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *boardClient) AddTask(ctx context.Context, in *AddTaskRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, Board_AddTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *boardClient) UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, Board_UpdateTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *boardClient) MoveTask(ctx context.Context, in *MoveTaskRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, Board_MoveTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *boardClient) WatchBoard(ctx context.Context, in *WatchBoardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BoardEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Board_ServiceDesc.Streams[0], Board_WatchBoard_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchBoardRequest, BoardEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Board_WatchBoardClient = grpc.ServerStreamingClient[BoardEvent]
+
+// BoardServer is the server API for Board service.
+// All implementations must embed UnimplementedBoardServer
+// for forward compatibility.
+type BoardServer interface {
+	// GetBoard returns every column and its tasks
+	GetBoard(context.Context, *GetBoardRequest) (*BoardSnapshot, error)
+	// ListTasks returns every task, or those in one column or matching a search
+	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
+	GetTask(context.Context, *GetTaskRequest) (*Task, error)
+	// AddTask adds a task, to the first column unless another is given
+	AddTask(context.Context, *AddTaskRequest) (*Task, error)
+	// UpdateTask changes the fields that are set in the request
+	UpdateTask(context.Context, *UpdateTaskRequest) (*Task, error)
+	// MoveTask moves a task to another column or position
+	MoveTask(context.Context, *MoveTaskRequest) (*Task, error)
+	// WatchBoard streams changes to tasks as they happen, whether made through
+	// the API or anywhere else
+	WatchBoard(*WatchBoardRequest, grpc.ServerStreamingServer[BoardEvent]) error
+	mustEmbedUnimplementedBoardServer()
+}
+
+// UnimplementedBoardServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBoardServer struct{}
+
+func (UnimplementedBoardServer) GetBoard(context.Context, *GetBoardRequest) (*BoardSnapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBoard not implemented")
+}
+func (UnimplementedBoardServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (UnimplementedBoardServer) GetTask(context.Context, *GetTaskRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTask not implemented")
+}
+func (UnimplementedBoardServer) AddTask(context.Context, *AddTaskRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTask not implemented")
+}
+func (UnimplementedBoardServer) UpdateTask(context.Context, *UpdateTaskRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTask not implemented")
+}
+func (UnimplementedBoardServer) MoveTask(context.Context, *MoveTaskRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveTask not implemented")
+}
+func (UnimplementedBoardServer) WatchBoard(*WatchBoardRequest, grpc.ServerStreamingServer[BoardEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchBoard not implemented")
+}
+func (UnimplementedBoardServer) mustEmbedUnimplementedBoardServer() {}
+func (UnimplementedBoardServer) testEmbeddedByValue()               {}
+
+// UnsafeBoardServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BoardServer will
+// result in compilation errors.
+type UnsafeBoardServer interface {
+	mustEmbedUnimplementedBoardServer()
+}
+
+func RegisterBoardServer(s grpc.ServiceRegistrar, srv BoardServer) {
+	// If the following call pancis, it indicates UnimplementedBoardServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Board_ServiceDesc, srv)
+}
+
+func _Board_GetBoard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBoardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoardServer).GetBoard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Board_GetBoard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoardServer).GetBoard(ctx, req.(*GetBoardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Board_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoardServer).ListTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Board_ListTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoardServer).ListTasks(ctx, req.(*ListTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Board_GetTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoardServer).GetTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Board_GetTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoardServer).GetTask(ctx, req.(*GetTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Board_AddTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoardServer).AddTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Board_AddTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoardServer).AddTask(ctx, req.(*AddTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Board_UpdateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoardServer).UpdateTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Board_UpdateTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoardServer).UpdateTask(ctx, req.(*UpdateTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Board_MoveTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoardServer).MoveTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Board_MoveTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoardServer).MoveTask(ctx, req.(*MoveTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Board_WatchBoard_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBoardRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BoardServer).WatchBoard(m, &grpc.GenericServerStream[WatchBoardRequest, BoardEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Board_WatchBoardServer = grpc.ServerStreamingServer[BoardEvent]
+
+// Board_ServiceDesc is the grpc.ServiceDesc for Board service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Board_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gotask.v1.Board",
+	HandlerType: (*BoardServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBoard",
+			Handler:    _Board_GetBoard_Handler,
+		},
+		{
+			MethodName: "ListTasks",
+			Handler:    _Board_ListTasks_Handler,
+		},
+		{
+			MethodName: "GetTask",
+			Handler:    _Board_GetTask_Handler,
+		},
+		{
+			MethodName: "AddTask",
+			Handler:    _Board_AddTask_Handler,
+		},
+		{
+			MethodName: "UpdateTask",
+			Handler:    _Board_UpdateTask_Handler,
+		},
+		{
+			MethodName: "MoveTask",
+			Handler:    _Board_MoveTask_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchBoard",
+			Handler:       _Board_WatchBoard_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "gotask.proto",
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"gotask/gotaskpb"
+	"gotask/internal/board"
+	"gotask/internal/storage"
+)
+
+// grpcServer implements the Board service of gotaskpb on top of the board
+// file, sharing its operations with the REST API
+type grpcServer struct {
+	gotaskpb.UnimplementedBoardServer
+	*storage.BoardFile
+}
+
+// serveGRPC serves the gRPC API on addr until it fails
+func serveGRPC(addr, path, token string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return newGRPCServer(path, token).Serve(ln)
+}
+
+// newGRPCServer returns a gRPC server for the board at path, requiring
+// the token of callers if there is one
+func newGRPCServer(path, token string) *grpc.Server {
+	srv := grpc.NewServer(grpcTokenOptions(token)...)
+	gotaskpb.RegisterBoardServer(srv, &grpcServer{BoardFile: storage.NewBoardFile(path)})
+	return srv
+}
+
+func (s *grpcServer) GetBoard(ctx context.Context, _ *gotaskpb.GetBoardRequest) (*gotaskpb.BoardSnapshot, error) {
+	b, err := s.Read()
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	snapshot := &gotaskpb.BoardSnapshot{}
+	for _, col := range b.Columns {
+		c := &gotaskpb.Column{Title: col.Title}
+		for _, task := range col.Tasks {
+			c.Tasks = append(c.Tasks, pbTask(board.ColumnTask{Task: task, Column: col.Title}))
+		}
+		snapshot.Columns = append(snapshot.Columns, c)
+	}
+	return snapshot, nil
+}
+
+func (s *grpcServer) ListTasks(ctx context.Context, req *gotaskpb.ListTasksRequest) (*gotaskpb.ListTasksResponse, error) {
+	board, err := s.Read()
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	tasks, err := apiTasks(&board, req.Column, req.Query)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &gotaskpb.ListTasksResponse{}
+	for _, task := range tasks {
+		resp.Tasks = append(resp.Tasks, pbTask(task))
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) GetTask(ctx context.Context, req *gotaskpb.GetTaskRequest) (*gotaskpb.Task, error) {
+	board, err := s.Read()
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	task, err := lookupTask(&board, strconv.FormatInt(req.Id, 10))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return pbTask(task), nil
+}
+
+func (s *grpcServer) AddTask(ctx context.Context, req *gotaskpb.AddTaskRequest) (*gotaskpb.Task, error) {
+	priority := Priority(req.Priority)
+	in := taskInput{
+		Title:       &req.Title,
+		Description: &req.Description,
+		Icon:        &req.Icon,
+		Priority:    &priority,
+		Tags:        &req.Tags,
+		Column:      req.Column,
+	}
+	if req.Due != "" {
+		in.Due = &req.Due
+	}
+	var created board.ColumnTask
+	err := s.Change(func(b *KanbanBoard) (err error) {
+		created, err = in.create(b)
+		return err
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return pbTask(created), nil
+}
+
+func (s *grpcServer) UpdateTask(ctx context.Context, req *gotaskpb.UpdateTaskRequest) (*gotaskpb.Task, error) {
+	in := taskInput{
+		Title:       req.Title,
+		Description: req.Description,
+		Icon:        req.Icon,
+		Due:         req.Due,
+	}
+	if req.Priority != nil {
+		priority := Priority(*req.Priority)
+		in.Priority = &priority
+	}
+	if req.Tags != nil {
+		in.Tags = &req.Tags.Tags
+	}
+	var updated board.ColumnTask
+	err := s.Change(func(b *KanbanBoard) (err error) {
+		updated, err = in.update(b, strconv.FormatInt(req.Id, 10))
+		return err
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return pbTask(updated), nil
+}
+
+func (s *grpcServer) MoveTask(ctx context.Context, req *gotaskpb.MoveTaskRequest) (*gotaskpb.Task, error) {
+	in := moveInput{Column: req.Column}
+	if req.Position != nil {
+		pos := int(*req.Position)
+		in.Position = &pos
+	}
+	var moved board.ColumnTask
+	err := s.Change(func(b *KanbanBoard) (err error) {
+		moved, err = in.move(b, strconv.FormatInt(req.Id, 10))
+		return err
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return pbTask(moved), nil
+}
+
+// WatchBoard polls the board file and streams what changed in it, so that
+// changes made in the TUI or by other commands are seen too
+func (s *grpcServer) WatchBoard(_ *gotaskpb.WatchBoardRequest, stream gotaskpb.Board_WatchBoardServer) error {
+	var stamp storage.Stamp
+	var last KanbanBoard
+	first := true
+	ticker := time.NewTicker(storage.WatchInterval)
+	defer ticker.Stop()
+	for {
+		if now := storage.Stat(s.Path); first || now != stamp {
+			board, err := s.Read()
+			if err != nil {
+				return grpcError(err)
+			}
+			if !first {
+				for _, event := range boardEvents(&last, &board, time.Now()) {
+					if err := stream.Send(event); err != nil {
+						return err
+					}
+				}
+			}
+			stamp, last, first = now, board, false
+		}
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// boardEvents converts the changes from one version of the board to the
+// next for WatchBoard. Archived tasks are reported as removed.
+func boardEvents(before, after *KanbanBoard, now time.Time) []*gotaskpb.BoardEvent {
+	kinds := map[string]gotaskpb.BoardEvent_Kind{
+		board.ChangeAdded:    gotaskpb.BoardEvent_KIND_ADDED,
+		board.ChangeUpdated:  gotaskpb.BoardEvent_KIND_UPDATED,
+		board.ChangeMoved:    gotaskpb.BoardEvent_KIND_MOVED,
+		board.ChangeRemoved:  gotaskpb.BoardEvent_KIND_REMOVED,
+		board.ChangeArchived: gotaskpb.BoardEvent_KIND_REMOVED,
+	}
+	at := timestamppb.New(now)
+	var events []*gotaskpb.BoardEvent
+	for _, c := range board.Diff(before, after) {
+		events = append(events, &gotaskpb.BoardEvent{Kind: kinds[c.Kind], Task: pbTask(c.ColumnTask), FromColumn: c.From, At: at})
+	}
+	return events
+}
+
+// pbTask converts a task for the gRPC API
+func pbTask(t board.ColumnTask) *gotaskpb.Task {
+	task := &gotaskpb.Task{
+		Id:          int64(t.ID),
+		Title:       t.Title,
+		Description: t.Description,
+		Icon:        t.Icon,
+		Priority:    gotaskpb.Priority(t.Priority),
+		Tags:        t.Tags,
+		CreatedAt:   timestamppb.New(t.CreatedAt),
+		Points:      t.Points,
+		Column:      t.Column,
+	}
+	if t.Due != nil {
+		task.Due = timestamppb.New(*t.Due)
+	}
+	if t.CompletedAt != nil {
+		task.CompletedAt = timestamppb.New(*t.CompletedAt)
+	}
+	return task
+}
+
+// grpcError turns an API error into a gRPC status
+func grpcError(err error) error {
+	var herr *httpError
+	if !errors.As(err, &herr) {
+		return status.Error(codes.Internal, err.Error())
+	}
+	code := codes.Unknown
+	switch herr.status {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusNotFound:
+		code = codes.NotFound
+	}
+	return status.Error(code, herr.Error())
+}
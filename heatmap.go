@@ -0,0 +1,29 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"gotask/internal/config"
+	"gotask/internal/stats"
+	"gotask/internal/storage"
+	"gotask/internal/ui"
+)
+
+// runHeatmap implements "gotask heatmap", which prints the completions
+// heatmap
+func runHeatmap(args []string) error {
+	flags := flag.NewFlagSet("heatmap", flag.ContinueOnError)
+	weeks := flags.Int("weeks", 26, fmt.Sprintf("how many weeks to show, up to %d", ui.MaxHeatWeeks))
+	path := flags.String("file", config.BoardPath(), "board file to read")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	board, err := storage.Load(*path)
+	if err != nil {
+		return err
+	}
+	fmt.Println(ui.RenderHeatmap(stats.CompletionsByDay(&board), *weeks, time.Now()))
+	return nil
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// icalendar is an iCalendar object kept as its unfolded content lines, so
+// that properties gotask doesn't know about survive a round trip
+type icalendar struct {
+	lines []string
+}
+
+// parseICal unfolds an iCalendar object into content lines
+func parseICal(data string) *icalendar {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	var lines []string
+	for _, line := range strings.Split(data, "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return &icalendar{lines}
+}
+
+// newVTodo returns a calendar holding an empty to-do with the given UID
+func newVTodo(uid string) *icalendar {
+	return &icalendar{[]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//gotask//gotask//EN",
+		"BEGIN:VTODO",
+		"UID:" + escapeICalText(uid),
+		"END:VTODO",
+		"END:VCALENDAR",
+	}}
+}
+
+// todo finds the first VTODO. It returns the index of its END line and
+// the indexes of its own properties, leaving out those of components
+// nested in it, such as VALARM.
+func (c *icalendar) todo() (end int, props []int, ok bool) {
+	inside, depth := false, 0
+	for i, line := range c.lines {
+		upper := strings.ToUpper(line)
+		switch {
+		case !inside:
+			inside = upper == "BEGIN:VTODO"
+		case strings.HasPrefix(upper, "BEGIN:"):
+			depth++
+		case strings.HasPrefix(upper, "END:"):
+			if depth == 0 {
+				return i, props, true
+			}
+			depth--
+		case depth == 0:
+			props = append(props, i)
+		}
+	}
+	return -1, nil, false
+}
+
+// splitProp splits a content line into its name, parameters, and value
+func splitProp(line string) (name, params, value string) {
+	quoted := false
+	for i, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ':' && !quoted:
+			head := line[:i]
+			name, params, _ = strings.Cut(head, ";")
+			return strings.ToUpper(name), params, line[i+1:]
+		}
+	}
+	return strings.ToUpper(line), "", ""
+}
+
+// get returns a property of the to-do
+func (c *icalendar) get(name string) (params, value string, ok bool) {
+	_, props, _ := c.todo()
+	for _, i := range props {
+		if n, p, v := splitProp(c.lines[i]); n == name {
+			return p, v, true
+		}
+	}
+	return "", "", false
+}
+
+// text returns a text property of the to-do, unescaped
+func (c *icalendar) text(name string) string {
+	_, value, _ := c.get(name)
+	return unescapeICalText(value)
+}
+
+// set replaces a property of the to-do, or removes it if value is empty.
+// Params, such as "VALUE=DATE", are written as given.
+func (c *icalendar) set(name, params, value string) {
+	end, props, ok := c.todo()
+	if !ok {
+		return
+	}
+	line := name
+	if params != "" {
+		line += ";" + params
+	}
+	line += ":" + value
+
+	for _, i := range props {
+		if n, _, _ := splitProp(c.lines[i]); n == name {
+			if value == "" {
+				c.lines = append(c.lines[:i], c.lines[i+1:]...)
+			} else {
+				c.lines[i] = line
+			}
+			return
+		}
+	}
+	if value != "" {
+		c.lines = append(c.lines[:end], append([]string{line}, c.lines[end:]...)...)
+	}
+}
+
+// String folds the lines at 75 octets and joins them with CRLF, as
+// RFC 5545 asks. Continuation lines count their leading space.
+func (c *icalendar) String() string {
+	var b strings.Builder
+	for _, line := range c.lines {
+		for limit := 75; len(line) > limit; limit = 74 {
+			cut := limit
+			for cut > 0 && !isRuneStart(line[cut]) {
+				cut--
+			}
+			b.WriteString(line[:cut] + "\r\n ")
+			line = line[cut:]
+		}
+		b.WriteString(line + "\r\n")
+	}
+	return b.String()
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+var (
+	icalEscaper   = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	icalUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+)
+
+func escapeICalText(s string) string {
+	return icalEscaper.Replace(s)
+}
+
+func unescapeICalText(s string) string {
+	return icalUnescaper.Replace(s)
+}
+
+// splitICalList splits a comma separated value such as CATEGORIES,
+// leaving escaped commas alone
+func splitICalList(s string) []string {
+	var items []string
+	var item strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s):
+			item.WriteString(s[i : i+2])
+			i++
+		case s[i] == ',':
+			items = append(items, unescapeICalText(item.String()))
+			item.Reset()
+		default:
+			item.WriteByte(s[i])
+		}
+	}
+	if item.Len() > 0 {
+		items = append(items, unescapeICalText(item.String()))
+	}
+	return items
+}
+
+// iCalendar date and date-time layouts
+const (
+	icalDate     = "20060102"
+	icalDateTime = "20060102T150405"
+)
+
+// formatICalTime renders a time for a property, as a date when it falls
+// on midnight and as a UTC date-time otherwise. It returns the params to
+// write along with the value.
+func formatICalTime(t time.Time) (params, value string) {
+	local := t.Local()
+	if local.Hour() == 0 && local.Minute() == 0 && local.Second() == 0 {
+		return "VALUE=DATE", local.Format(icalDate)
+	}
+	return "", t.UTC().Format(icalDateTime) + "Z"
+}
+
+// parseICalTime reads a date or date-time value. Times in a named zone are
+// read in that zone if it is known, and as local time otherwise.
+func parseICalTime(params, value string) (time.Time, bool) {
+	if t, err := time.ParseInLocation(icalDate, value, time.Local); err == nil {
+		return t, true
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(icalDateTime, strings.TrimSuffix(value, "Z"))
+		return t.Local(), err == nil
+	}
+	loc := time.Local
+	for _, param := range strings.Split(params, ";") {
+		key, tzid, _ := strings.Cut(param, "=")
+		if !strings.EqualFold(key, "TZID") {
+			continue
+		}
+		if l, err := time.LoadLocation(strings.Trim(tzid, `"`)); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation(icalDateTime, value, loc)
+	return t, err == nil
+}
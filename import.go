@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// importers bring tasks in from other tools, run as "gotask import <name>"
+var importers = map[string]func(args []string) error{
+	"gitlab": importGitLab,
+	"linear": importLinear,
+	"notion": importNotion,
+}
+
+// syncers keep imported tasks and their originals in step, run as
+// "gotask sync <name>"
+var syncers = map[string]func(args []string) error{
+	"caldav": syncCalDAV,
+	"gitlab": syncGitLab,
+}
+
+func runImport(args []string) error {
+	return runNamed("import", importers, args)
+}
+
+func runSync(args []string) error {
+	return runNamed("sync", syncers, args)
+}
+
+// runNamed runs the command named by the first argument
+func runNamed(verb string, commands map[string]func([]string) error, args []string) error {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(args) == 0 {
+		return fmt.Errorf("usage: gotask %s <%s>", verb, strings.Join(names, "|"))
+	}
+	run, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("can't %s %q, choose one of %s", verb, args[0], strings.Join(names, ", "))
+	}
+	return run(args[1:])
+}
+
+// findSource locates the task imported from the given item, on the board
+// or in the archive
+func findSource(b *KanbanBoard, kind, id string) (col, idx int, archived, ok bool) {
+	for i, c := range b.Columns {
+		for j, task := range c.Tasks {
+			if task.Source != nil && task.Source.Kind == kind && task.Source.ID == id {
+				return i, j, false, true
+			}
+		}
+	}
+	for j, task := range b.Archive {
+		if task.Source != nil && task.Source.Kind == kind && task.Source.ID == id {
+			return -1, j, true, true
+		}
+	}
+	return -1, -1, false, false
+}
+
+// importTasks adds the tasks that aren't on the board yet, each to the
+// column given for it, and returns how many were added
+func importTasks(b *KanbanBoard, tasks []Task, columns []int) int {
+	added := 0
+	for i, task := range tasks {
+		if _, _, _, ok := findSource(b, task.Source.Kind, task.Source.ID); ok {
+			continue
+		}
+		task.ID = b.MaxID() + 1
+		b.AddTask(columns[i], task)
+		added++
+	}
+	return added
+}
+
+// importColumn resolves the -column flag of an import, the first column
+// by default
+func importColumn(b *KanbanBoard, name string) (int, error) {
+	if name == "" {
+		return 0, nil
+	}
+	col, ok := b.ColumnIndex(name)
+	if !ok {
+		return 0, fmt.Errorf("no column matches %q", name)
+	}
+	return col, nil
+}
+
+// firstNonEmpty returns the first of its arguments that isn't ""
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
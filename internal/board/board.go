@@ -0,0 +1,240 @@
+// Package board is the kanban model behind gotask: a board of columns
+// holding tasks, and the operations that change it. Every change goes
+// through a method here so that each task keeps its history and the
+// spells it spent in each column, which the reports are built from.
+//
+// The package does no I/O; see gotask/internal/storage for reading and
+// writing boards.
+package board
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Column represents a column in our kanban board
+type Column struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	Tasks []Task `json:"tasks"`
+}
+
+// Board is a whole kanban board: its columns in order, and the tasks
+// taken off it
+type Board struct {
+	Columns []Column       `json:"columns"`
+	Archive []ArchivedTask `json:"archive,omitempty"`
+	// Deleted records when tasks were deleted, so that merging in a copy
+	// of the board made elsewhere doesn't bring them back
+	Deleted map[int]time.Time `json:"deleted,omitempty"`
+	// Flow records the column counts day by day, oldest first
+	Flow []FlowDay `json:"flow,omitempty"`
+}
+
+// ArchivedTask is a task that was taken off the board but kept for reference
+type ArchivedTask struct {
+	Task
+	Column     string    `json:"column"`
+	ArchivedAt time.Time `json:"archived_at"`
+}
+
+// DefaultColumns are the titles of a new board's columns, in order. The
+// last is where finished work goes.
+var DefaultColumns = []string{"To Do", "In Progress", "Done"}
+
+// New returns an empty board with the DefaultColumns
+func New() Board {
+	b := Board{Columns: make([]Column, len(DefaultColumns))}
+	for i, title := range DefaultColumns {
+		b.Columns[i] = Column{ID: i + 1, Title: title, Tasks: []Task{}}
+	}
+	return b
+}
+
+// FindTask locates a task by ID, returning its column and index
+func (b *Board) FindTask(id int) (int, int, bool) {
+	for i, col := range b.Columns {
+		for j, task := range col.Tasks {
+			if task.ID == id {
+				return i, j, true
+			}
+		}
+	}
+	return -1, -1, false
+}
+
+// AddTask appends a new task to a column, stamping its creation, and
+// returns it
+func (b *Board) AddTask(column int, task Task) Task {
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = time.Now()
+	}
+	col := &b.Columns[column]
+	task.Record(EventCreated, "", col.Title)
+	task.SetCompleted(b.IsDone(column))
+	col.Tasks = append(col.Tasks, task)
+	return task
+}
+
+// InsertTask places a task at index idx of a column, or at the end if idx
+// is out of range, and returns where it landed
+func (b *Board) InsertTask(col, idx int, task Task) int {
+	dest := &b.Columns[col]
+	if idx < 0 || idx >= len(dest.Tasks) {
+		dest.Tasks = append(dest.Tasks, task)
+		return len(dest.Tasks) - 1
+	}
+	dest.Tasks = slices.Insert(dest.Tasks, idx, task)
+	return idx
+}
+
+// MoveTask moves the task at (fromCol, fromIdx) so that it ends up at index
+// toIdx of toCol. An out-of-range toIdx appends to the column. It returns the
+// task's final index in the destination column.
+func (b *Board) MoveTask(fromCol, fromIdx, toCol, toIdx int) int {
+	src := &b.Columns[fromCol]
+	task := src.Tasks[fromIdx]
+	src.Tasks = append(src.Tasks[:fromIdx], src.Tasks[fromIdx+1:]...)
+	if fromCol != toCol {
+		task.Record(EventMoved, src.Title, b.Columns[toCol].Title)
+		task.SetCompleted(b.IsDone(toCol))
+	}
+
+	// Removing the task shifts later positions in the same column up by one
+	if fromCol == toCol && toIdx > fromIdx {
+		toIdx--
+	}
+
+	dest := &b.Columns[toCol]
+	if toIdx < 0 || toIdx >= len(dest.Tasks) {
+		dest.Tasks = append(dest.Tasks, task)
+		return len(dest.Tasks) - 1
+	}
+	dest.Tasks = append(dest.Tasks[:toIdx], append([]Task{task}, dest.Tasks[toIdx:]...)...)
+	return toIdx
+}
+
+// IsDone reports whether col is the last column, where finished work goes
+func (b *Board) IsDone(col int) bool {
+	return col == len(b.Columns)-1
+}
+
+// Progress counts the tasks in the done column against all tasks
+func (b *Board) Progress() (done, total int) {
+	for i, col := range b.Columns {
+		total += len(col.Tasks)
+		if b.IsDone(i) {
+			done += len(col.Tasks)
+		}
+	}
+	return done, total
+}
+
+// DeleteTask removes a task from the board for good
+func (b *Board) DeleteTask(id int) {
+	if b.RemoveTask(id) {
+		if b.Deleted == nil {
+			b.Deleted = make(map[int]time.Time)
+		}
+		b.Deleted[id] = time.Now()
+	}
+}
+
+// RemoveTask takes a task out of its column, reporting whether it was there
+func (b *Board) RemoveTask(id int) bool {
+	col, idx, ok := b.FindTask(id)
+	if ok {
+		tasks := b.Columns[col].Tasks
+		b.Columns[col].Tasks = append(tasks[:idx], tasks[idx+1:]...)
+	}
+	return ok
+}
+
+// ArchiveTask takes a task off the board and keeps it in the archive
+func (b *Board) ArchiveTask(id int, now time.Time) {
+	col, idx, ok := b.FindTask(id)
+	if !ok {
+		return
+	}
+	task := b.Columns[col].Tasks[idx]
+	task.leaveColumn(b.Columns[col].Title, now)
+	task.History = append(task.History, TaskEvent{At: now, Action: EventArchived, From: b.Columns[col].Title})
+	b.Archive = append(b.Archive, ArchivedTask{
+		Task:       task,
+		Column:     b.Columns[col].Title,
+		ArchivedAt: now,
+	})
+	b.RemoveTask(id)
+}
+
+// RestoreTask puts an archived task back at the bottom of the column it
+// was archived from, or the first column if that one is gone, and returns
+// the column's title
+func (b *Board) RestoreTask(i int) string {
+	archived := b.Archive[i]
+	b.Archive = append(b.Archive[:i], b.Archive[i+1:]...)
+	col := 0
+	for j, c := range b.Columns {
+		if c.Title == archived.Column {
+			col = j
+			break
+		}
+	}
+	task := archived.Task
+	task.Record(EventRestored, "", b.Columns[col].Title)
+	task.SetCompleted(b.IsDone(col))
+	b.Columns[col].Tasks = append(b.Columns[col].Tasks, task)
+	return b.Columns[col].Title
+}
+
+// MaxID returns the highest task ID on the board, in its archive, or
+// among its deleted tasks, so that a new task never takes a deleted one's
+// ID and a merge doesn't mistake one for the other
+func (b *Board) MaxID() int {
+	id := 0
+	for _, col := range b.Columns {
+		for _, task := range col.Tasks {
+			id = max(id, task.ID)
+		}
+	}
+	for _, task := range b.Archive {
+		id = max(id, task.ID)
+	}
+	for deleted := range b.Deleted {
+		id = max(id, deleted)
+	}
+	return id
+}
+
+// ColumnIndex resolves a column from its 1-based number or its title.
+// Titles match case-insensitively, ignoring spaces, and may be abbreviated
+// as long as the abbreviation is unambiguous.
+func (b *Board) ColumnIndex(name string) (int, bool) {
+	if n, err := strconv.Atoi(name); err == nil {
+		if n >= 1 && n <= len(b.Columns) {
+			return n - 1, true
+		}
+		return -1, false
+	}
+
+	normalize := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	}
+	want := normalize(name)
+	found := -1
+	for i, col := range b.Columns {
+		title := normalize(col.Title)
+		if title == want {
+			return i, true
+		}
+		if strings.HasPrefix(title, want) || strings.Contains(title, want) {
+			if found >= 0 {
+				return -1, false
+			}
+			found = i
+		}
+	}
+	return found, found >= 0
+}
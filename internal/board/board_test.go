@@ -0,0 +1,129 @@
+package board
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+var created = time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+// testBoard has tasks #1-#3 in To Do and #4 in Done
+func testBoard() Board {
+	b := New()
+	for id := 1; id <= 3; id++ {
+		b.AddTask(0, Task{ID: id, CreatedAt: created})
+	}
+	b.AddTask(2, Task{ID: 4, CreatedAt: created})
+	return b
+}
+
+// ids lists the IDs of the tasks in a column, in order
+func ids(col Column) []int {
+	var ids []int
+	for _, task := range col.Tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func TestMoveTask(t *testing.T) {
+	tests := []struct {
+		name                           string
+		fromCol, fromIdx, toCol, toIdx int
+		wantIdx                        int
+		want                           [3][]int
+	}{
+		{"down", 0, 0, 0, 2, 1, [3][]int{{2, 1, 3}, nil, {4}}},
+		{"up", 0, 2, 0, 0, 0, [3][]int{{3, 1, 2}, nil, {4}}},
+		{"to the end", 0, 0, 0, 99, 2, [3][]int{{2, 3, 1}, nil, {4}}},
+		{"across", 0, 1, 1, 0, 0, [3][]int{{1, 3}, {2}, {4}}},
+		{"above another", 0, 0, 2, 0, 0, [3][]int{{2, 3}, nil, {1, 4}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := testBoard()
+			if idx := b.MoveTask(tt.fromCol, tt.fromIdx, tt.toCol, tt.toIdx); idx != tt.wantIdx {
+				t.Errorf("MoveTask returned %d, want %d", idx, tt.wantIdx)
+			}
+			for i, col := range b.Columns {
+				if got := ids(col); !slices.Equal(got, tt.want[i]) {
+					t.Errorf("%s holds %v, want %v", col.Title, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMoveTaskRecordsColumnChange(t *testing.T) {
+	b := testBoard()
+	b.MoveTask(0, 0, 2, -1)
+	task := b.Columns[2].Tasks[1]
+	last := task.History[len(task.History)-1]
+	if last.Action != EventMoved || last.From != "To Do" || last.To != "Done" {
+		t.Errorf("last history entry is %+v, want a move from To Do to Done", last)
+	}
+	if task.CompletedAt == nil {
+		t.Error("a task moved to Done isn't completed")
+	}
+
+	b.MoveTask(2, 1, 0, -1)
+	if task := b.Columns[0].Tasks[2]; task.CompletedAt != nil {
+		t.Error("a task moved out of Done is still completed")
+	}
+}
+
+func TestDeleteTask(t *testing.T) {
+	b := testBoard()
+	b.DeleteTask(2)
+	if _, _, ok := b.FindTask(2); ok {
+		t.Error("#2 is still on the board")
+	}
+	if _, ok := b.Deleted[2]; !ok {
+		t.Error("#2 isn't recorded as deleted")
+	}
+	if got := ids(b.Columns[0]); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("To Do holds %v, want [1 3]", got)
+	}
+
+	b.DeleteTask(99)
+	if _, ok := b.Deleted[99]; ok {
+		t.Error("deleting a missing task recorded it as deleted")
+	}
+}
+
+func TestMaxID(t *testing.T) {
+	b := testBoard()
+	if got := b.MaxID(); got != 4 {
+		t.Errorf("MaxID = %d, want 4", got)
+	}
+	b.ArchiveTask(4, created)
+	if got := b.MaxID(); got != 4 {
+		t.Errorf("with #4 archived MaxID = %d, want 4", got)
+	}
+	b.RestoreTask(0)
+	b.DeleteTask(4)
+	if got := b.MaxID(); got != 4 {
+		t.Errorf("with #4 deleted MaxID = %d, want 4", got)
+	}
+	empty := New()
+	if got := empty.MaxID(); got != 0 {
+		t.Errorf("MaxID of a new board = %d, want 0", got)
+	}
+}
+
+func TestProgress(t *testing.T) {
+	b := testBoard()
+	if done, total := b.Progress(); done != 1 || total != 4 {
+		t.Errorf("Progress = %d/%d, want 1/4", done, total)
+	}
+	b.MoveTask(0, 0, 2, -1)
+	b.DeleteTask(2)
+	if done, total := b.Progress(); done != 2 || total != 3 {
+		t.Errorf("Progress = %d/%d, want 2/3", done, total)
+	}
+	empty := New()
+	if done, total := empty.Progress(); done != 0 || total != 0 {
+		t.Errorf("Progress of a new board = %d/%d, want 0/0", done, total)
+	}
+}
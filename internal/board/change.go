@@ -0,0 +1,74 @@
+package board
+
+import (
+	"reflect"
+)
+
+// Kinds of Change
+const (
+	ChangeAdded    = "added"
+	ChangeUpdated  = "updated"
+	ChangeMoved    = "moved"
+	ChangeRemoved  = "removed"
+	ChangeArchived = "archived"
+)
+
+// Change is something that happened to a task between two versions of
+// the board. From is the column a moved task left.
+type Change struct {
+	Kind string
+	ColumnTask
+	From string
+}
+
+// Diff lists the changes from one version of the board to the next:
+// tasks added, changed, moved to another column, and removed or archived
+func Diff(before, after *Board) []Change {
+	old := make(map[int]ColumnTask)
+	for _, col := range before.Columns {
+		for _, task := range col.Tasks {
+			old[task.ID] = ColumnTask{task, col.Title}
+		}
+	}
+	var changes []Change
+	for _, col := range after.Columns {
+		for _, task := range col.Tasks {
+			current := ColumnTask{task, col.Title}
+			prev, ok := old[task.ID]
+			delete(old, task.ID)
+			switch {
+			case !ok:
+				changes = append(changes, Change{Kind: ChangeAdded, ColumnTask: current})
+			case prev.Column != current.Column:
+				changes = append(changes, Change{Kind: ChangeMoved, ColumnTask: current, From: prev.Column})
+			case !reflect.DeepEqual(prev.Task, current.Task):
+				changes = append(changes, Change{Kind: ChangeUpdated, ColumnTask: current})
+			}
+		}
+	}
+	// What is left was deleted or archived; go by the old board's order
+	archived := make(map[int]bool)
+	for _, task := range after.Archive {
+		archived[task.ID] = true
+	}
+	for _, col := range before.Columns {
+		for _, task := range col.Tasks {
+			prev, ok := old[task.ID]
+			if !ok {
+				continue
+			}
+			kind := ChangeRemoved
+			if archived[task.ID] {
+				kind = ChangeArchived
+			}
+			changes = append(changes, Change{Kind: kind, ColumnTask: prev})
+		}
+	}
+	return changes
+}
+
+// ColumnTask is a task as the API returns it, along with its column
+type ColumnTask struct {
+	Task
+	Column string `json:"column"`
+}
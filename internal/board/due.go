@@ -0,0 +1,75 @@
+package board
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DueSoonDays is how close a due date has to be before its badge stands out
+const DueSoonDays = 7
+
+// DaysUntil counts calendar days from now until t, negative once t has passed
+func DaysUntil(t, now time.Time) int {
+	day := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	t = t.In(now.Location())
+	return int(day(t).Sub(day(now)).Hours() / 24)
+}
+
+const DueLayout = "2006-01-02"
+
+// ParseDue understands dates (2024-05-01), "today", "tomorrow", and offsets
+// such as "+3d" or "+2w". An empty string clears the due date.
+func ParseDue(s string, now time.Time) (*time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return nil, nil
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch s {
+	case "today":
+		return &today, nil
+	case "tomorrow":
+		t := today.AddDate(0, 0, 1)
+		return &t, nil
+	}
+
+	if strings.HasPrefix(s, "+") && len(s) > 2 {
+		n, err := strconv.Atoi(s[1 : len(s)-1])
+		if err == nil {
+			switch s[len(s)-1] {
+			case 'd':
+				t := today.AddDate(0, 0, n)
+				return &t, nil
+			case 'w':
+				t := today.AddDate(0, 0, 7*n)
+				return &t, nil
+			case 'm':
+				t := today.AddDate(0, n, 0)
+				return &t, nil
+			}
+		}
+	}
+
+	for _, layout := range []string{DueLayout, "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("can't read due date %q, use YYYY-MM-DD, today, tomorrow, or +3d", s)
+}
+
+// FormatDue renders a due date the way ParseDue reads it back
+func FormatDue(due *time.Time) string {
+	if due == nil {
+		return ""
+	}
+	if due.Hour() == 0 && due.Minute() == 0 {
+		return due.Format(DueLayout)
+	}
+	return due.Format("2006-01-02 15:04")
+}
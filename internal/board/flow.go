@@ -0,0 +1,90 @@
+package board
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// dateLayout is how days are written in the flow, e.g. 2024-03-01
+const dateLayout = "2006-01-02"
+
+// DayOf returns midnight at the start of t's day
+func DayOf(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// FlowDay is how many tasks each column held at the end of a day, for the
+// cumulative flow diagram. Counts are in column order.
+type FlowDay struct {
+	Date   string `json:"date"` // YYYY-MM-DD
+	Counts []int  `json:"counts"`
+}
+
+// maxFlowDays is how many days of column counts the board keeps
+const maxFlowDays = 400
+
+// RecordFlow notes today's column counts, replacing any noted earlier
+// today, and reports whether they changed
+func (b *Board) RecordFlow(now time.Time) bool {
+	counts := make([]int, len(b.Columns))
+	for i, col := range b.Columns {
+		counts[i] = len(col.Tasks)
+	}
+	today := DayOf(now).Format(dateLayout)
+	n := len(b.Flow)
+	switch {
+	case n > 0 && b.Flow[n-1].Date == today && slices.Equal(b.Flow[n-1].Counts, counts):
+		return false
+	case n > 0 && b.Flow[n-1].Date == today:
+		b.Flow[n-1].Counts = counts
+	default:
+		b.Flow = append(b.Flow, FlowDay{Date: today, Counts: counts})
+	}
+	if len(b.Flow) > maxFlowDays {
+		b.Flow = slices.Clone(b.Flow[len(b.Flow)-maxFlowDays:])
+	}
+	return true
+}
+
+// MergeFlow adds the days another copy of the board noted that this one
+// lacks. Where both noted a day, this copy's counts stand.
+func (b *Board) MergeFlow(other []FlowDay) {
+	days := make(map[string]bool, len(b.Flow))
+	for _, day := range b.Flow {
+		days[day.Date] = true
+	}
+	for _, day := range other {
+		if !days[day.Date] {
+			b.Flow = append(b.Flow, day)
+		}
+	}
+	slices.SortFunc(b.Flow, func(x, y FlowDay) int { return strings.Compare(x.Date, y.Date) })
+	if len(b.Flow) > maxFlowDays {
+		b.Flow = b.Flow[len(b.Flow)-maxFlowDays:]
+	}
+}
+
+// FlowDays returns the column counts at the end of each of the last days
+// days up to now. A day without counts of its own has the counts of the
+// day before it; days before the first counts were noted are nil.
+func (b *Board) FlowDays(days int, now time.Time) [][]int {
+	out := make([][]int, days)
+	first := DayOf(now).AddDate(0, 0, 1-days).Format(dateLayout)
+	var last []int
+	next := 0
+	for next < len(b.Flow) && b.Flow[next].Date < first {
+		last = b.Flow[next].Counts
+		next++
+	}
+	for i := range out {
+		date := DayOf(now).AddDate(0, 0, i+1-days).Format(dateLayout)
+		for next < len(b.Flow) && b.Flow[next].Date <= date {
+			last = b.Flow[next].Counts
+			next++
+		}
+		out[i] = last
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package board
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ShortDuration formats a number of days as "3d", "2w", or "5mo"
+func ShortDuration(days int) string {
+	switch {
+	case days < 14:
+		return fmt.Sprintf("%dd", days)
+	case days < 60:
+		return fmt.Sprintf("%dw", days/7)
+	default:
+		return fmt.Sprintf("%dmo", days/30)
+	}
+}
+
+// IconPrefix is the task's icon and a space, or nothing
+func IconPrefix(task Task) string {
+	if task.Icon == "" {
+		return ""
+	}
+	return task.Icon + " "
+}
+
+// FormatPoints renders an estimate such as "3pt"
+func FormatPoints(points float64) string {
+	return strconv.FormatFloat(points, 'f', -1, 64) + "pt"
+}
+
+// FormatSpan formats a duration briefly, e.g. "40m", "5.5h", or "3.2d"
+func FormatSpan(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%.1fh", d.Hours())
+	default:
+		return fmt.Sprintf("%.1fd", d.Hours()/24)
+	}
+}
+
+// DescribeEvent turns a history entry into a readable sentence
+func DescribeEvent(e TaskEvent) string {
+	switch e.Action {
+	case EventCreated:
+		return "created in " + e.To
+	case EventMoved:
+		return fmt.Sprintf("moved from %s to %s", e.From, e.To)
+	case EventEdited:
+		return fmt.Sprintf("renamed from %q", e.From)
+	case EventUpdated:
+		return "changed " + e.To
+	case EventTagged:
+		if e.To == "" {
+			return "removed all tags"
+		}
+		return "tags set to " + e.To
+	case EventArchived:
+		return "archived from " + e.From
+	case EventRestored:
+		return "restored to " + e.To
+	case EventPrioritized:
+		return fmt.Sprintf("priority changed from %s to %s", e.From, e.To)
+	case EventPomodoro:
+		return "finished a pomodoro"
+	case EventFocus:
+		if d, err := time.ParseDuration(e.To); err == nil {
+			return "focused for " + FormatSpan(d)
+		}
+		return "focused"
+	}
+	return e.Action
+}
+
+// Plural returns word, with an "s" unless n is 1
+func Plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
@@ -0,0 +1,53 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Priority represents how urgent a task is
+type Priority int
+
+// Priorities, least urgent first
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+)
+
+var priorityNames = []string{"none", "low", "medium", "high"}
+
+// String names the priority, e.g. "high"
+func (p Priority) String() string {
+	if p < 0 || int(p) >= len(priorityNames) {
+		return priorityNames[PriorityNone]
+	}
+	return priorityNames[p]
+}
+
+// ParsePriority accepts a priority name or its first letter
+func ParsePriority(s string) (Priority, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for i, name := range priorityNames {
+		if s == name || (len(s) == 1 && s[0] == name[0]) {
+			return Priority(i), true
+		}
+	}
+	return PriorityNone, false
+}
+
+// MarshalText stores priorities by name so the save file stays readable
+func (p Priority) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText reads a priority by name or first letter
+func (p *Priority) UnmarshalText(text []byte) error {
+	parsed, ok := ParsePriority(string(text))
+	if !ok {
+		return fmt.Errorf("unknown priority %q", text)
+	}
+	*p = parsed
+	return nil
+}
@@ -0,0 +1,66 @@
+package board
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// FuzzyMatch reports whether every rune of pattern appears in text in order,
+// ignoring case. It returns a score (higher is better) along with the rune
+// indexes in text that were matched.
+func FuzzyMatch(pattern, text string) (int, []int, bool) {
+	p := lowerRunes(pattern)
+	t := lowerRunes(text)
+	if len(p) == 0 {
+		return 0, nil, false
+	}
+
+	positions := make([]int, 0, len(p))
+	score := 0
+	pi := 0
+	for ti := 0; ti < len(t) && pi < len(p); ti++ {
+		if t[ti] != p[pi] {
+			continue
+		}
+		score++
+		// Reward runs of consecutive characters and matches at word starts
+		if len(positions) > 0 && positions[len(positions)-1] == ti-1 {
+			score += 3
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 2
+		}
+		positions = append(positions, ti)
+		pi++
+	}
+
+	if pi < len(p) {
+		return 0, nil, false
+	}
+	return score, positions, true
+}
+
+// lowerRunes lowercases s one rune at a time, so that rune indexes still
+// line up with the original string (strings.ToLower may change the length)
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+// MatchTask checks a task's title and description against the query
+func MatchTask(query string, task Task) bool {
+	if _, _, ok := FuzzyMatch(query, task.Title); ok {
+		return true
+	}
+	_, _, ok := FuzzyMatch(query, task.Description)
+	return ok
+}
+
+// HasTag reports whether the task is tagged tag, ignoring case
+func HasTag(task Task, tag string) bool {
+	return slices.ContainsFunc(task.Tags, func(t string) bool { return strings.EqualFold(t, tag) })
+}
@@ -0,0 +1,87 @@
+package board
+
+import "time"
+
+// ColumnSpell is a spell a task spent in a column, kept on the task so
+// exports carry its full flow through the board
+type ColumnSpell struct {
+	Column  string     `json:"column"`
+	Entered time.Time  `json:"entered"`
+	Left    *time.Time `json:"left,omitempty"` // nil while it's still there
+}
+
+// HistorySpells works out the spells a task spent in columns from its
+// history, for tasks saved before spells were kept
+func (t Task) HistorySpells() []ColumnSpell {
+	var spells []ColumnSpell
+	leave := func(from string, at time.Time) {
+		if len(spells) == 0 && from != "" {
+			spells = append(spells, ColumnSpell{Column: from, Entered: t.CreatedAt})
+		}
+		if n := len(spells); n > 0 && spells[n-1].Left == nil {
+			spells[n-1].Left = &at
+		}
+	}
+	for _, e := range t.History {
+		switch e.Action {
+		case EventCreated:
+			spells = append(spells, ColumnSpell{Column: e.To, Entered: e.At})
+		case EventMoved, EventRestored:
+			leave(e.From, e.At)
+			spells = append(spells, ColumnSpell{Column: e.To, Entered: e.At})
+		case EventArchived:
+			leave(e.From, e.At)
+		}
+	}
+	return spells
+}
+
+// ColumnSpells lists the spells the task spent in columns, the last one still
+// open unless it was archived
+func (t Task) ColumnSpells() []ColumnSpell {
+	if t.Spells != nil {
+		return t.Spells
+	}
+	return t.HistorySpells()
+}
+
+// leaveColumn ends the task's spell in its column
+func (t *Task) leaveColumn(from string, at time.Time) {
+	if t.Spells == nil {
+		t.Spells = t.HistorySpells()
+	}
+	if len(t.Spells) == 0 && from != "" {
+		t.Spells = append(t.Spells, ColumnSpell{Column: from, Entered: t.CreatedAt})
+	}
+	if n := len(t.Spells); n > 0 && t.Spells[n-1].Left == nil {
+		t.Spells[n-1].Left = &at
+	}
+}
+
+// enterColumn ends the task's spell in the column it was in, if any, and
+// starts one in the column it is now in
+func (t *Task) enterColumn(from, to string, at time.Time) {
+	t.leaveColumn(from, at)
+	t.Spells = append(t.Spells, ColumnSpell{Column: to, Entered: at})
+}
+
+// Stays lists the spells the task spent in columns that have ended, by
+// moving on or being archived
+func (t Task) Stays() []ColumnSpell {
+	var stays []ColumnSpell
+	for _, s := range t.ColumnSpells() {
+		if s.Left != nil && s.Left.After(s.Entered) {
+			stays = append(stays, s)
+		}
+	}
+	return stays
+}
+
+// EnteredColumn is when the task arrived in the column it is in
+func (t Task) EnteredColumn() time.Time {
+	spells := t.ColumnSpells()
+	if n := len(spells); n > 0 && spells[n-1].Left == nil {
+		return spells[n-1].Entered
+	}
+	return t.CreatedAt
+}
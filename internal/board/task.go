@@ -0,0 +1,176 @@
+package board
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Task represents a single task in our kanban board
+type Task struct {
+	ID          int           `json:"id"`
+	Title       string        `json:"title"`
+	Icon        string        `json:"icon,omitempty"`
+	Description string        `json:"description"`
+	CreatedAt   time.Time     `json:"created_at"`
+	Tags        []string      `json:"tags,omitempty"`
+	Priority    Priority      `json:"priority,omitempty"`
+	Due         *time.Time    `json:"due,omitempty"`
+	Subtasks    []Subtask     `json:"subtasks,omitempty"`
+	History     []TaskEvent   `json:"history,omitempty"`
+	Spells      []ColumnSpell `json:"spells,omitempty"`
+	CompletedAt *time.Time    `json:"completed_at,omitempty"`
+	Pomodoros   int           `json:"pomodoros,omitempty"`
+	Points      float64       `json:"points,omitempty"`
+	Source      *TaskSource   `json:"source,omitempty"`
+}
+
+// TaskSource records where an imported task came from, so importing again
+// skips it and syncing can find the original
+type TaskSource struct {
+	Kind string `json:"kind"` // e.g. "gitlab"
+	ID   string `json:"id"`   // the item's ID in that system
+	URL  string `json:"url,omitempty"`
+	// Version is the item's version as of the last sync, such as an ETag,
+	// and Synced when that was
+	Version string     `json:"version,omitempty"`
+	Synced  *time.Time `json:"synced,omitempty"`
+}
+
+// Subtask is a checklist item inside a task
+type Subtask struct {
+	Title string `json:"title"`
+	Done  bool   `json:"done"`
+}
+
+// TaskEvent records a change made to a task
+type TaskEvent struct {
+	At     time.Time `json:"at"`
+	Action string    `json:"action"`
+	From   string    `json:"from,omitempty"`
+	To     string    `json:"to,omitempty"`
+}
+
+// Actions recorded in a task's history
+const (
+	EventCreated     = "created"
+	EventEdited      = "edited"
+	EventUpdated     = "updated"
+	EventMoved       = "moved"
+	EventTagged      = "tagged"
+	EventArchived    = "archived"
+	EventPrioritized = "prioritized"
+	EventPomodoro    = "pomodoro"
+	EventFocus       = "focus"
+	EventRestored    = "restored"
+)
+
+// Record appends an event to the task's history, and starts a spell in
+// the column a task was created in, moved to, or restored to
+func (t *Task) Record(action, from, to string) {
+	now := time.Now()
+	if action == EventCreated || action == EventMoved || action == EventRestored {
+		t.enterColumn(from, to, now)
+	}
+	t.History = append(t.History, TaskEvent{At: now, Action: action, From: from, To: to})
+}
+
+// Update copies the editable fields from next onto the task and records
+// what changed in its history
+func (t *Task) Update(next Task) {
+	var changed []string
+	if next.Title != t.Title {
+		t.Record(EventEdited, t.Title, next.Title)
+	}
+	if next.Description != t.Description {
+		changed = append(changed, "description")
+	}
+	if !sameDue(next.Due, t.Due) {
+		changed = append(changed, "due date")
+	}
+	if next.Priority != t.Priority {
+		changed = append(changed, "priority")
+	}
+	if next.Icon != t.Icon {
+		changed = append(changed, "icon")
+	}
+	if strings.Join(next.Tags, ", ") != strings.Join(t.Tags, ", ") {
+		changed = append(changed, "tags")
+	}
+	if fmt.Sprint(next.Subtasks) != fmt.Sprint(t.Subtasks) {
+		changed = append(changed, "subtasks")
+	}
+	if len(changed) > 0 {
+		t.Record(EventUpdated, "", strings.Join(changed, ", "))
+	}
+
+	t.Title = next.Title
+	t.Description = next.Description
+	t.Due = next.Due
+	t.Priority = next.Priority
+	t.Icon = next.Icon
+	t.Tags = next.Tags
+	t.Subtasks = next.Subtasks
+}
+
+// sameDue reports whether two due dates are the same to the minute
+func sameDue(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Truncate(time.Minute).Equal(b.Truncate(time.Minute))
+}
+
+// SetCompleted stamps the completion time when a task reaches the done
+// column and clears it when the task is reopened
+func (t *Task) SetCompleted(done bool) {
+	switch {
+	case done && t.CompletedAt == nil:
+		now := time.Now()
+		t.CompletedAt = &now
+	case !done:
+		t.CompletedAt = nil
+	}
+}
+
+// AddTag adds a tag to the task unless it is already present
+func (t *Task) AddTag(tag string) {
+	for _, existing := range t.Tags {
+		if strings.EqualFold(existing, tag) {
+			return
+		}
+	}
+	t.Tags = append(t.Tags, tag)
+}
+
+// RemoveTag drops a tag from the task, ignoring case
+func (t *Task) RemoveTag(tag string) {
+	for i, existing := range t.Tags {
+		if strings.EqualFold(existing, tag) {
+			t.Tags = append(t.Tags[:i], t.Tags[i+1:]...)
+			return
+		}
+	}
+}
+
+// Clone returns a copy of the task that shares no slices with the original
+func (t Task) Clone() Task {
+	t.Tags = slices.Clone(t.Tags)
+	t.Subtasks = slices.Clone(t.Subtasks)
+	t.History = slices.Clone(t.History)
+	t.Spells = slices.Clone(t.Spells)
+	if t.Due != nil {
+		due := *t.Due
+		t.Due = &due
+	}
+	return t
+}
+
+// LastUpdated is when the task last changed, falling back to its creation
+func (t Task) LastUpdated() time.Time {
+	if n := len(t.History); n > 0 && t.History[n-1].At.After(t.CreatedAt) {
+		return t.History[n-1].At
+	}
+	return t.CreatedAt
+}
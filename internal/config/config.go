@@ -0,0 +1,181 @@
+// Package config reads gotask's settings from config.toml (or config.json)
+// in the user's config directory, with GOTASK_* environment variables
+// layered on top. Each section of the file has its own type here.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"gotask/internal/board"
+)
+
+// Config holds the user's settings, loaded from config.toml in the gotask
+// config directory (e.g. ~/.config/gotask/config.toml), or config.json if
+// there is no config.toml. Both use the same names.
+type Config struct {
+	// Board is the board file used when none is given (default
+	// ~/.kanban.json). A leading ~/ is the home directory.
+	Board string `json:"board,omitempty"`
+	// Columns are the titles of a new board's columns, in order (default
+	// "To Do", "In Progress", "Done")
+	Columns []string `json:"columns,omitempty"`
+	// Autosave is when changes are written: "on" (default) after every
+	// change, "off" only on :w and when quitting, or a duration such as
+	// "30s" to write at most that often
+	Autosave string `json:"autosave,omitempty"`
+	// NoColor draws the board without color, as NO_COLOR does
+	NoColor bool `json:"no_color,omitempty"`
+	// ReadOnly opens the board without ever writing to it, to look at a
+	// board that is being worked on elsewhere
+	ReadOnly bool `json:"read_only,omitempty"`
+	Keys     Keys `json:"keys"`
+	// Theme is the name of a built-in theme (see builtinThemes in internal/ui)
+	Theme string `json:"theme,omitempty"`
+	// Background is "auto" (default) to detect the terminal background, or
+	// "dark" or "light" to choose the palette of adaptive themes directly
+	Background string `json:"background,omitempty"`
+	// Colors overrides individual theme colors, e.g. {"highlight": "#FF8800"}
+	Colors map[string]string `json:"colors,omitempty"`
+	// Confirm controls which destructive actions ask for confirmation
+	Confirm Confirm `json:"confirm"`
+	// Display tweaks how tasks are drawn on the board
+	Display Display `json:"display"`
+	// Notify sends notifications when tasks fall due
+	Notify Notify `json:"notify"`
+	// GitLab configures "gotask import gitlab" and "gotask sync gitlab"
+	GitLab GitLab `json:"gitlab"`
+	// Linear configures "gotask import linear"
+	Linear Linear `json:"linear"`
+	// Notion configures "gotask import notion"
+	Notion Notion `json:"notion"`
+	// CalDAV configures "gotask sync caldav"
+	CalDAV CalDAV `json:"caldav"`
+	// Email holds the SMTP settings for "gotask digest"
+	Email Email `json:"email"`
+	// Hooks runs commands when tasks are added, moved, finished, or deleted
+	Hooks Hooks `json:"hooks"`
+	// Limits sets WIP and aging limits the status bar warns about
+	Limits Limits `json:"limits"`
+	// Reports are delivered on a schedule by "gotask daemon"
+	Reports []Report `json:"reports,omitempty"`
+}
+
+// Dir is the gotask config directory, e.g. ~/.config/gotask
+func Dir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		homedir, err := os.UserHomeDir()
+		if err != nil {
+			homedir = "."
+		}
+		dir = filepath.Join(homedir, ".config")
+	}
+	return filepath.Join(dir, "gotask")
+}
+
+// Path is the config file in use: the one GOTASK_CONFIG names,
+// otherwise config.toml, or config.json if only that exists. With
+// neither, it is where config.toml would go.
+func Path() string {
+	if path := os.Getenv("GOTASK_CONFIG"); path != "" {
+		return path
+	}
+	path := filepath.Join(Dir(), "config.toml")
+	legacy := filepath.Join(Dir(), "config.json")
+	if !fileExists(path) && fileExists(legacy) {
+		return legacy
+	}
+	return path
+}
+
+// fileExists reports whether there is a file at path
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Load reads the config file, overridden by the environment (see
+// envOverrides). A missing file yields the defaults.
+func Load() (Config, error) {
+	cfg, err := Read(Path())
+	return cfg, errors.Join(err, cfg.applyEnv())
+}
+
+// Read reads the config file at path, TOML or JSON by its extension
+func Read(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+
+	if filepath.Ext(path) == ".toml" {
+		// Read TOML through the JSON field names, so both formats stay in
+		// step without a second set of tags
+		var doc map[string]any
+		if err := toml.Unmarshal(data, &doc); err != nil {
+			return cfg, fmt.Errorf("%s: %w", path, err)
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return cfg, err
+		}
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// BoardPath is the board file to use when none is given: the one the
+// config names, or ~/.kanban.json
+func BoardPath() string {
+	cfg, _ := Load()
+	return cfg.BoardPath()
+}
+
+// BoardPath is the board file the settings name, or ~/.kanban.json
+func (cfg Config) BoardPath() string {
+	if cfg.Board == "" {
+		return defaultBoardPath()
+	}
+	if rest, ok := strings.CutPrefix(cfg.Board, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return cfg.Board
+}
+
+// defaultBoardPath is where the board is kept unless the config says
+// otherwise, ~/.kanban.json
+func defaultBoardPath() string {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		homedir = "."
+	}
+	return filepath.Join(homedir, ".kanban.json")
+}
+
+// ApplyColumns makes new boards start with the configured columns
+func ApplyColumns(cfg Config) error {
+	if len(cfg.Columns) == 0 {
+		return nil
+	}
+	for _, title := range cfg.Columns {
+		if strings.TrimSpace(title) == "" {
+			return fmt.Errorf("columns: titles can't be blank")
+		}
+	}
+	board.DefaultColumns = cfg.Columns
+	return nil
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// envOverrides are the environment variables that override the config,
+// for containers and SSH sessions where a config file is a chore. Flags
+// such as -file override them in turn, so the order of precedence is
+// flags, then the environment, then the config, then the defaults.
+// GOTASK_CONFIG, which names the config file itself, is read by
+// Path.
+var envOverrides = []struct {
+	name  string
+	apply func(cfg *Config, value string) error
+}{
+	{"GOTASK_BOARD", func(cfg *Config, value string) error {
+		cfg.Board = value
+		return nil
+	}},
+	{"GOTASK_THEME", func(cfg *Config, value string) error {
+		cfg.Theme = value
+		return nil
+	}},
+	{"GOTASK_NO_COLOR", func(cfg *Config, value string) (err error) {
+		cfg.NoColor, err = parseEnvBool(value)
+		return err
+	}},
+	{"GOTASK_READ_ONLY", func(cfg *Config, value string) (err error) {
+		cfg.ReadOnly, err = parseEnvBool(value)
+		return err
+	}},
+}
+
+// parseEnvBool reads an on/off environment variable such as 1 or true
+func parseEnvBool(value string) (bool, error) {
+	on, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("want true or false, not %q", value)
+	}
+	return on, nil
+}
+
+// applyEnv overrides the config with the GOTASK_* variables that are set.
+// A variable that can't be read is reported and leaves its setting be.
+func (cfg *Config) applyEnv() error {
+	var errs []error
+	for _, env := range envOverrides {
+		value := os.Getenv(env.name)
+		if value == "" {
+			continue
+		}
+		if err := env.apply(cfg, value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", env.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// EnvUsage describes the environment variables for "gotask help"
+func EnvUsage() string {
+	return `
+Environment:
+  GOTASK_BOARD      board file, like the board setting
+  GOTASK_CONFIG     config file to read instead of config.toml
+  GOTASK_THEME      theme, like the theme setting
+  GOTASK_NO_COLOR   true to draw without color
+  GOTASK_READ_ONLY  true to open the board without saving changes
+  GOTASK_TOKEN      token gotask serve requires and gotask attach sends
+
+Flags such as -file override these, and these override the config.
+`
+}
@@ -0,0 +1,209 @@
+package config
+
+// CalDAV holds the defaults for "gotask sync caldav"
+type CalDAV struct {
+	// URL of the task list (calendar collection), e.g.
+	// https://cloud.example.com/remote.php/dav/calendars/me/tasks/
+	URL      string `json:"url,omitempty"`
+	Username string `json:"username,omitempty"`
+	// Password, usually an app password. CALDAV_PASSWORD takes precedence.
+	Password string `json:"password,omitempty"`
+}
+
+// Keys selects a keybinding profile and overrides individual actions
+type Keys struct {
+	// Profile is the base set of bindings: "vim" (default) or "arrows"
+	Profile string `json:"profile,omitempty"`
+	// Bindings maps action names (see keyMap.actions) to the keys that
+	// trigger them, replacing the profile's keys for that action
+	Bindings map[string][]string `json:"bindings,omitempty"`
+}
+
+// Confirm sets the confirmation policy for each kind of destructive
+// action: "always" (default), "never", or "description"
+type Confirm struct {
+	Delete  string `json:"delete,omitempty"`
+	Archive string `json:"archive,omitempty"`
+	// Bulk applies to moving several marked tasks at once
+	Bulk string `json:"bulk,omitempty"`
+}
+
+// Email holds the SMTP settings for "gotask digest"
+type Email struct {
+	// Host and Port of the SMTP server. Port 465 speaks TLS from the start;
+	// other ports, 587 by default, upgrade with STARTTLS when offered.
+	Host string `json:"host,omitempty"`
+	Port int    `json:"port,omitempty"`
+	// Username and Password log in to the server. GOTASK_SMTP_PASSWORD
+	// takes precedence over Password.
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// From is the sender, the username by default
+	From string `json:"from,omitempty"`
+	// To is where digests go when -email isn't given
+	To string `json:"to,omitempty"`
+	// Digest has "gotask daemon" send the digest every day at this time,
+	// e.g. "08:00"
+	Digest string `json:"digest,omitempty"`
+}
+
+// Display holds options for how tasks are drawn on the board
+type Display struct {
+	// Done sets how finished tasks look: "strike" (default) strikes them
+	// through and dims them, "dim" only dims them, "plain" leaves them be
+	Done string `json:"done,omitempty"`
+	// TitleLines is how many lines a title may wrap onto before it is
+	// cut short with an ellipsis (default 3)
+	TitleLines int `json:"title_lines,omitempty"`
+	// Symbols shows state with symbols and border shapes as well as color.
+	// It is always on with NO_COLOR set or the monochrome and high-contrast
+	// themes.
+	Symbols bool `json:"symbols,omitempty"`
+	// Compact starts the board in compact mode, with one line per task and
+	// no card borders. It can be toggled at runtime.
+	Compact bool `json:"compact,omitempty"`
+	// Priority sets how priority shows on cards: "marks" (default) for
+	// !, !!, and !!!, "icons" for Nerd Font icons, or "none"
+	Priority string `json:"priority,omitempty"`
+	// HideTags starts the board with tag chips hidden. They can be toggled
+	// at runtime.
+	HideTags bool `json:"hide_tags,omitempty"`
+	// HideIcons leaves task icons off the board, for terminals that draw
+	// emoji badly
+	HideIcons bool `json:"hide_icons,omitempty"`
+	// TagColors picks the color of particular tags, e.g. {"bug": "#FF0000"}.
+	// Other tags get a theme color based on their name.
+	TagColors map[string]string `json:"tag_colors,omitempty"`
+	// Timestamp adds a relative time to cards: "created" for the task's
+	// age, "updated" for its last change, or "none" (default)
+	Timestamp string `json:"timestamp,omitempty"`
+	// DimUnfocused dims every column but the one with the cursor
+	DimUnfocused bool `json:"dim_unfocused,omitempty"`
+	// Border is the style of the board's borders: "rounded" (default),
+	// "normal", "thick", or "none"
+	Border string `json:"border,omitempty"`
+	// Padding sets the space inside columns and cards
+	Padding Padding `json:"padding,omitempty"`
+	// StaleDays is how many days an open task can go unchanged before it
+	// is dimmed and badged as stale (default 14, -1 to never)
+	StaleDays int `json:"stale_days,omitempty"`
+}
+
+// Padding holds padding for the parts of the board. Each is one to
+// four numbers, applied to the sides like CSS padding.
+type Padding struct {
+	Column []int `json:"column,omitempty"`
+	Card   []int `json:"card,omitempty"`
+}
+
+// GitLab holds the defaults for "gotask import gitlab" and
+// "gotask sync gitlab"
+type GitLab struct {
+	// URL of the GitLab instance, https://gitlab.com by default
+	URL string `json:"url,omitempty"`
+	// Token is a personal access token with the api scope. GITLAB_TOKEN
+	// takes precedence.
+	Token string `json:"token,omitempty"`
+	// Project is the path of the project, e.g. "group/project"
+	Project string `json:"project,omitempty"`
+}
+
+// Hooks lists commands to run when tasks change, whether in the TUI,
+// through the APIs, or by imports and syncs. Each command runs in the shell
+// with the event in GOTASK_EVENT, the task in GOTASK_TASK_ID,
+// GOTASK_TASK_TITLE, GOTASK_COLUMN, and GOTASK_FROM_COLUMN, and the whole
+// event as JSON on stdin.
+type Hooks struct {
+	// OnAdd runs when a task is created
+	OnAdd []string `json:"on_add,omitempty"`
+	// OnMove runs when a task moves to another column
+	OnMove []string `json:"on_move,omitempty"`
+	// OnDone runs when a task reaches the last column, after OnMove
+	OnDone []string `json:"on_done,omitempty"`
+	// OnDelete runs when a task is deleted. Archiving doesn't count.
+	OnDelete []string `json:"on_delete,omitempty"`
+}
+
+// Limits sets the policies the board is expected to keep to. The
+// status bar warns when it doesn't.
+type Limits struct {
+	// WIP caps how many tasks a column should hold, by column title, e.g.
+	// {"In Progress": 3}
+	WIP map[string]int `json:"wip,omitempty"`
+	// MaxAge is how long a task should stay in a column, by column title,
+	// in days or weeks, e.g. {"In Progress": "5d"}
+	MaxAge map[string]string `json:"max_age,omitempty"`
+}
+
+// Linear holds the defaults for "gotask import linear"
+type Linear struct {
+	// Token is a personal API key. LINEAR_API_KEY takes precedence.
+	Token string `json:"token,omitempty"`
+	// Team is the key of the team to import from, e.g. "ENG". All teams
+	// the key can see are imported when it is empty.
+	Team string `json:"team,omitempty"`
+	// States maps workflow state names to column titles, e.g.
+	// {"In Review": "In Progress"}. Other states go by their type:
+	// backlog and unstarted to the first column, started to the second,
+	// and completed to the last.
+	States map[string]string `json:"states,omitempty"`
+}
+
+// Notify controls notifications about tasks that fall due
+type Notify struct {
+	// Desktop pops up a system notification (notify-send on Linux, the
+	// notification center on macOS) when a task falls due
+	Desktop bool `json:"desktop,omitempty"`
+	// Remind also sends a reminder this long before a task is due, e.g.
+	// "30m", "2h", or "1d"
+	Remind string `json:"remind,omitempty"`
+	// Summary sends a daily summary of overdue tasks and tasks due that
+	// day at this time, e.g. "09:00"
+	Summary string `json:"summary,omitempty"`
+	// Targets are services alerts and summaries are posted to
+	Targets []NotifyTarget `json:"targets,omitempty"`
+}
+
+// Notion holds the defaults for "gotask import notion"
+type Notion struct {
+	// Token is the secret of an internal integration that the database is
+	// shared with. NOTION_TOKEN takes precedence.
+	Token string `json:"token,omitempty"`
+	// Database is the ID of the database to import
+	Database string `json:"database,omitempty"`
+	// Status names the property that picks a page's column, "Status" by
+	// default. Its values are matched against column titles.
+	Status string `json:"status,omitempty"`
+	// Columns maps status values to column titles where they differ, e.g.
+	// {"Not started": "To Do"}
+	Columns map[string]string `json:"columns,omitempty"`
+}
+
+// Report schedules a report for "gotask daemon" to deliver
+type Report struct {
+	// Report is "digest", "standup", or "stats"
+	Report string `json:"report"`
+	// Every is "day" (default), "weekday" for Monday to Friday, or the name
+	// of a day of the week, e.g. "monday"
+	Every string `json:"every,omitempty"`
+	// At is the time of day, e.g. "09:00"
+	At string `json:"at"`
+	// File writes the report to this path, replacing the last one
+	File string `json:"file,omitempty"`
+	// Email sends the report to this address through the email settings
+	Email string `json:"email,omitempty"`
+	// Webhook posts the report to ntfy, Slack, or Discord
+	Webhook *NotifyTarget `json:"webhook,omitempty"`
+}
+
+// NotifyTarget is a service that alerts are posted to
+type NotifyTarget struct {
+	// Type is "ntfy", "slack", or "discord"
+	Type string `json:"type"`
+	// URL is the ntfy topic (e.g. https://ntfy.sh/my-tasks) or the Slack or
+	// Discord webhook URL
+	URL string `json:"url"`
+	// Template formats the message with Go's text/template. It can use
+	// .Title, .Body, and .Tasks, the tasks the alert is about.
+	Template string `json:"template,omitempty"`
+}
@@ -0,0 +1,20 @@
+// Package debug holds the logger that --debug turns on.
+package debug
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Log is where --debug writes key presses, saves, errors, and slow
+// frames. Without --debug it drops everything, since the board owns the
+// terminal and there is nowhere else to print.
+var Log = slog.New(discardHandler{})
+
+// discardHandler is a slog handler that is never enabled
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
@@ -0,0 +1,144 @@
+// Package hooks runs the user's hook commands when a task changes. A hook
+// gets the change as JSON on stdin and runs in the background, so a slow
+// one never holds up the board.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+)
+
+// Timeout bounds how long a hook may run
+const Timeout = 30 * time.Second
+
+// Event is what a hook is told about
+type Event struct {
+	Event string           `json:"event"`
+	Task  board.ColumnTask `json:"task"`
+	From  string           `json:"from_column,omitempty"`
+}
+
+// Runner runs the configured commands. Runs are serialized so that hooks see
+// events in the order they happened.
+type Runner struct {
+	commands map[string][]string
+	mu       *sync.Mutex
+}
+
+func New(cfg config.Hooks) Runner {
+	return Runner{
+		commands: map[string][]string{
+			"add":    cfg.OnAdd,
+			"move":   cfg.OnMove,
+			"done":   cfg.OnDone,
+			"delete": cfg.OnDelete,
+		},
+		mu: &sync.Mutex{},
+	}
+}
+
+// Configured loads the hooks from the config, for commands that don't
+// otherwise read it
+func Configured() Runner {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Print(err)
+	}
+	return New(cfg.Hooks)
+}
+
+// Enabled reports whether any hook is configured
+func (h Runner) Enabled() bool {
+	for _, commands := range h.commands {
+		if len(commands) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Events turns the changes between two versions of the board into the
+// events hooks run for
+func (h Runner) Events(changes []board.Change, after *board.Board) []Event {
+	done := ""
+	if len(after.Columns) > 0 {
+		done = after.Columns[len(after.Columns)-1].Title
+	}
+	var events []Event
+	for _, c := range changes {
+		switch c.Kind {
+		case board.ChangeAdded:
+			events = append(events, Event{Event: "add", Task: c.ColumnTask})
+		case board.ChangeMoved:
+			events = append(events, Event{Event: "move", Task: c.ColumnTask, From: c.From})
+			if c.Column == done {
+				events = append(events, Event{Event: "done", Task: c.ColumnTask, From: c.From})
+			}
+		case board.ChangeRemoved:
+			events = append(events, Event{Event: "delete", Task: c.ColumnTask})
+		}
+	}
+	return events
+}
+
+// Run runs the hooks for each event in turn, carrying on past failures
+func (h Runner) Run(events []Event) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var errs []error
+	for _, ev := range events {
+		for _, command := range h.commands[ev.Event] {
+			if err := runHook(command, ev); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runHook runs one command for an event
+func runHook(command string, ev Event) error {
+	input, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Stdin = bytes.NewReader(append(input, '\n'))
+	cmd.Env = append(os.Environ(),
+		"GOTASK_EVENT="+ev.Event,
+		"GOTASK_TASK_ID="+strconv.Itoa(ev.Task.ID),
+		"GOTASK_TASK_TITLE="+ev.Task.Title,
+		"GOTASK_COLUMN="+ev.Task.Column,
+		"GOTASK_FROM_COLUMN="+ev.From,
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+		return fmt.Errorf("%s hook %q: %w", ev.Event, command, err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+// Package httpjson is the small JSON-over-HTTP client shared by the
+// integrations that talk to other services.
+package httpjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Client is used for requests to other services
+var Client = &http.Client{Timeout: 30 * time.Second}
+
+// Fetch sends a request and decodes the JSON response into v
+func Fetch(req *http.Request, v any) (http.Header, error) {
+	req.Header.Set("Accept", "application/json")
+	resp, err := Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 300))
+		return nil, fmt.Errorf("%s %s: %s: %s", req.Method, req.URL.Redacted(), resp.Status, strings.TrimSpace(string(detail)))
+	}
+	if v == nil {
+		return resp.Header, nil
+	}
+	return resp.Header, json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,98 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gotask/internal/board"
+)
+
+// Digest lists the tasks that need attention
+type Digest struct {
+	Overdue    []DigestTask
+	DueToday   []DigestTask
+	InProgress []DigestTask
+}
+
+type DigestTask struct {
+	board.Task
+	Column string
+}
+
+// NewDigest collects the open tasks that are overdue or due today, and the
+// tasks in progress
+func NewDigest(b *board.Board, now time.Time) Digest {
+	var d Digest
+	for i, col := range b.Columns {
+		if b.IsDone(i) {
+			continue
+		}
+		for _, task := range col.Tasks {
+			t := DigestTask{task, col.Title}
+			if task.Due != nil && task.CompletedAt == nil {
+				switch days := board.DaysUntil(*task.Due, now); {
+				case days < 0:
+					d.Overdue = append(d.Overdue, t)
+				case days == 0:
+					d.DueToday = append(d.DueToday, t)
+				}
+			}
+			if i > 0 {
+				d.InProgress = append(d.InProgress, t)
+			}
+		}
+	}
+	return d
+}
+
+// Subject sums the digest up in a line, e.g. "2 overdue, 1 due today, 3
+// in progress"
+func (d Digest) Subject() string {
+	var parts []string
+	add := func(n int, what string) {
+		if n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, what))
+		}
+	}
+	add(len(d.Overdue), "overdue")
+	add(len(d.DueToday), "due today")
+	add(len(d.InProgress), "in progress")
+	if len(parts) == 0 {
+		return "gotask: all clear"
+	}
+	return "gotask: " + strings.Join(parts, ", ")
+}
+
+// Text renders the digest as plain text, one section per kind of task
+func (d Digest) Text(now time.Time) string {
+	var b strings.Builder
+	section := func(title string, tasks []DigestTask, line func(t DigestTask) string) {
+		if len(tasks) == 0 {
+			return
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s (%d)\n%s\n", title, len(tasks), strings.Repeat("-", len(title)))
+		for _, t := range tasks {
+			fmt.Fprintf(&b, "  #%d %s%s%s\n", t.ID, board.IconPrefix(t.Task), t.Title, line(t))
+		}
+	}
+	section("Overdue", d.Overdue, func(t DigestTask) string {
+		return fmt.Sprintf("  (due %s, %s late) [%s]", board.FormatDue(t.Due), board.ShortDuration(-board.DaysUntil(*t.Due, now)), t.Column)
+	})
+	section("Due today", d.DueToday, func(t DigestTask) string {
+		return fmt.Sprintf("  [%s]", t.Column)
+	})
+	section("In progress", d.InProgress, func(t DigestTask) string {
+		if t.Due == nil {
+			return ""
+		}
+		return fmt.Sprintf("  (due %s)", board.FormatDue(t.Due))
+	})
+	if b.Len() == 0 {
+		return "Nothing is overdue, due today, or in progress.\n"
+	}
+	return b.String()
+}
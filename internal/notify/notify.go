@@ -0,0 +1,251 @@
+// Package notify sends alerts about tasks coming due: to the desktop, to
+// webhooks, and as a daily digest.
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+)
+
+// Settings is where alerts go and when reminders and the summary are due
+type Settings struct {
+	Sinks   []Sink
+	remind  time.Duration // 0 for no reminders
+	summary time.Duration // time of day of the summary, -1 for none
+}
+
+// Sink is somewhere alerts can be sent
+type Sink interface {
+	Send(a Alert) error
+}
+
+// MaxAlerts is how many alerts go out one by one before they are sent as
+// a single summary instead
+const MaxAlerts = 3
+
+// NewSettings validates the notification options
+func NewSettings(cfg config.Notify) (Settings, error) {
+	s := Settings{summary: -1}
+	var err error
+	if cfg.Desktop {
+		s.Sinks = append(s.Sinks, desktopSink{})
+	}
+	for i, target := range cfg.Targets {
+		t, terr := NewWebhookSink(target)
+		if terr != nil {
+			err = errors.Join(err, fmt.Errorf("notify.targets[%d]: %w", i, terr))
+			continue
+		}
+		s.Sinks = append(s.Sinks, t)
+	}
+	if cfg.Remind != "" {
+		remind, rerr := ParseLeadTime(cfg.Remind)
+		if rerr != nil {
+			err = errors.Join(err, fmt.Errorf("notify.remind: %w", rerr))
+		}
+		s.remind = remind
+	}
+	if cfg.Summary != "" {
+		at, perr := ParseTimeOfDay(cfg.Summary)
+		if perr != nil {
+			err = errors.Join(err, fmt.Errorf("notify.summary: %w", perr))
+		} else {
+			s.summary = at
+		}
+	}
+	return s, err
+}
+
+// ParseTimeOfDay reads a time such as "09:00" as the time since midnight
+func ParseTimeOfDay(s string) (time.Duration, error) {
+	at, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("can't read %q, use e.g. 09:00", s)
+	}
+	return time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute, nil
+}
+
+// ParseLeadTime reads a duration such as "90m", "2h", or "1d"
+func ParseLeadTime(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("can't read %q, use e.g. 30m, 2h, or 1d", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("can't read %q, use e.g. 30m, 2h, or 1d", s)
+	}
+	return d, nil
+}
+
+// Alert is a notification about a task or a summary of several. Its
+// exported fields are what message templates see.
+type Alert struct {
+	key   string // identifies the alert so it is only sent once
+	Title string
+	Body  string
+	Tasks []board.Task
+}
+
+// DueAlerts finds open tasks that have fallen due, or whose reminder has
+// come up, since the alerts in sent went out. Moving a due date re-arms
+// its alerts.
+func DueAlerts(b *board.Board, now time.Time, remind time.Duration, sent map[string]bool) []Alert {
+	var alerts []Alert
+	for i, col := range b.Columns {
+		if b.IsDone(i) {
+			continue
+		}
+		for _, task := range col.Tasks {
+			if task.Due == nil || task.CompletedAt != nil {
+				continue
+			}
+			due := *task.Due
+			body := fmt.Sprintf("#%d %s%s", task.ID, board.IconPrefix(task), task.Title)
+			var a Alert
+			switch {
+			case !now.Before(due):
+				a = Alert{
+					key:   fmt.Sprintf("%d due %s", task.ID, board.FormatDue(&due)),
+					Title: "Task due",
+					Body:  body,
+					Tasks: []board.Task{task},
+				}
+			case remind > 0 && !now.Before(due.Add(-remind)):
+				a = Alert{
+					key:   fmt.Sprintf("%d remind %s", task.ID, board.FormatDue(&due)),
+					Title: "Due " + board.FormatDue(&due),
+					Body:  body,
+					Tasks: []board.Task{task},
+				}
+			default:
+				continue
+			}
+			if !sent[a.key] {
+				sent[a.key] = true
+				alerts = append(alerts, a)
+			}
+		}
+	}
+	if len(alerts) > MaxAlerts {
+		var tasks []board.Task
+		for _, a := range alerts {
+			tasks = append(tasks, a.Tasks...)
+		}
+		alerts = []Alert{{Title: "Tasks due", Body: fmt.Sprintf("%d tasks need attention", len(alerts)), Tasks: tasks}}
+	}
+	return alerts
+}
+
+// SummaryAlert returns the daily summary once its time of day has come,
+// or nil if there is none to send
+func SummaryAlert(b *board.Board, now time.Time, at time.Duration, sent map[string]bool) *Alert {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	key := "summary " + today.Format(board.DueLayout)
+	if at < 0 || now.Before(today.Add(at)) || sent[key] {
+		return nil
+	}
+	sent[key] = true
+
+	var lines []string
+	var tasks []board.Task
+	overdue, dueToday := 0, 0
+	for i, col := range b.Columns {
+		if b.IsDone(i) {
+			continue
+		}
+		for _, task := range col.Tasks {
+			if task.Due == nil || task.CompletedAt != nil {
+				continue
+			}
+			switch days := board.DaysUntil(*task.Due, now); {
+			case days < 0:
+				overdue++
+			case days == 0:
+				dueToday++
+			default:
+				continue
+			}
+			tasks = append(tasks, task)
+			lines = append(lines, fmt.Sprintf("#%d %s%s (due %s)", task.ID, board.IconPrefix(task), task.Title, board.FormatDue(task.Due)))
+		}
+	}
+
+	body := "Nothing is due today"
+	if len(tasks) > 0 {
+		body = fmt.Sprintf("%d overdue, %d due today\n%s", overdue, dueToday, strings.Join(lines, "\n"))
+	}
+	return &Alert{key: key, Title: "gotask daily summary", Body: body, Tasks: tasks}
+}
+
+// PendingAlerts collects every alert that is ready to go out
+func (s Settings) PendingAlerts(b *board.Board, now time.Time, sent map[string]bool) []Alert {
+	alerts := DueAlerts(b, now, s.remind, sent)
+	if summary := SummaryAlert(b, now, s.summary, sent); summary != nil {
+		alerts = append(alerts, *summary)
+	}
+	return alerts
+}
+
+// Deliver sends the alerts to every sink, returning the errors joined
+func (s Settings) Deliver(alerts []Alert) error {
+	var err error
+	for _, a := range alerts {
+		for _, sink := range s.Sinks {
+			err = errors.Join(err, sink.Send(a))
+		}
+	}
+	return err
+}
+
+// DesktopOnly keeps just the desktop notifications, for alerts that only
+// matter to someone at the computer
+func (s Settings) DesktopOnly() Settings {
+	local := s
+	local.Sinks = nil
+	for _, sink := range s.Sinks {
+		if _, ok := sink.(desktopSink); ok {
+			local.Sinks = append(local.Sinks, sink)
+		}
+	}
+	return local
+}
+
+// desktopSink pops up system notifications
+type desktopSink struct{}
+
+func (desktopSink) Send(a Alert) error {
+	return desktopNotify(a.Title, a.Body)
+}
+
+// desktopNotify shows a system notification
+func desktopNotify(title, body string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(body), strconv.Quote(title))
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		return fmt.Errorf("desktop notifications aren't supported on %s", runtime.GOOS)
+	default:
+		cmd = exec.Command("notify-send", "--app-name=gotask", title, body)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("desktop notification: %s", msg)
+		}
+		return fmt.Errorf("desktop notification: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"gotask/internal/config"
+)
+
+// Default message templates. ntfy shows the title separately.
+const (
+	defaultNtfyTemplate = "{{.Body}}"
+	defaultChatTemplate = "{{.Title}}\n{{.Body}}"
+)
+
+// webhookTimeout bounds how long a target may take to answer
+const webhookTimeout = 10 * time.Second
+
+// WebhookSink posts alerts to ntfy, Slack, or Discord
+type WebhookSink struct {
+	kind   string
+	url    string
+	tmpl   *template.Template
+	client *http.Client
+}
+
+func NewWebhookSink(t config.NotifyTarget) (*WebhookSink, error) {
+	text := t.Template
+	switch t.Type {
+	case "ntfy":
+		if text == "" {
+			text = defaultNtfyTemplate
+		}
+	case "slack", "discord":
+		if text == "" {
+			text = defaultChatTemplate
+		}
+	default:
+		return nil, fmt.Errorf("unknown type %q (want ntfy, slack, or discord)", t.Type)
+	}
+	if !strings.HasPrefix(t.URL, "https://") && !strings.HasPrefix(t.URL, "http://") {
+		return nil, fmt.Errorf("url %q must start with https:// or http://", t.URL)
+	}
+	tmpl, err := template.New(t.Type).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("template: %w", err)
+	}
+	return &WebhookSink{
+		kind:   t.Type,
+		url:    t.URL,
+		tmpl:   tmpl,
+		client: &http.Client{Timeout: webhookTimeout},
+	}, nil
+}
+
+func (s *WebhookSink) Send(a Alert) error {
+	var msg strings.Builder
+	if err := s.tmpl.Execute(&msg, a); err != nil {
+		return fmt.Errorf("%s: %w", s.kind, err)
+	}
+
+	var req *http.Request
+	var err error
+	switch s.kind {
+	case "ntfy":
+		req, err = http.NewRequest(http.MethodPost, s.url, strings.NewReader(msg.String()))
+		if err == nil {
+			req.Header.Set("Title", a.Title)
+		}
+	case "slack", "discord":
+		field := "text"
+		if s.kind == "discord" {
+			field = "content"
+		}
+		body, _ := json.Marshal(map[string]string{field: msg.String()})
+		req, err = http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", s.kind, err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", s.kind, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("%s: %s: %s", s.kind, resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
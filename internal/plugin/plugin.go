@@ -0,0 +1,63 @@
+// Package plugin finds gotask plugins. Plugins are executables named
+// gotask-<name> on $PATH. They extend gotask in two ways:
+//
+//   - "gotask <name> [args]" runs the plugin as a subcommand, e.g. to export
+//     the board in a new format. It gets the terminal, and finds the board
+//     and config through GOTASK_BOARD and GOTASK_CONFIG.
+//   - ":plugin <name> [args]" in the board runs it as an action. It gets the
+//     board as JSON on stdin and the selected task's ID in GOTASK_TASK_ID,
+//     and may print a changed board on stdout to replace it. Printing
+//     nothing leaves the board alone.
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"gotask/internal/config"
+)
+
+// Prefix starts the name of every plugin executable
+const Prefix = "gotask-"
+
+// Installed finds the plugins on $PATH, by name. The first of a name wins, as
+// it would when run.
+func Installed() map[string]string {
+	found := make(map[string]string)
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), Prefix)
+			if !ok || entry.IsDir() {
+				continue
+			}
+			if runtime.GOOS == "windows" {
+				if name, ok = strings.CutSuffix(name, ".exe"); !ok {
+					continue
+				}
+			}
+			if _, seen := found[name]; seen || name == "" {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if info, err := os.Stat(path); err != nil || (runtime.GOOS != "windows" && info.Mode()&0111 == 0) {
+				continue
+			}
+			found[name] = path
+		}
+	}
+	return found
+}
+
+// Env is the environment plugins run with
+func Env() []string {
+	return append(os.Environ(),
+		"GOTASK_BOARD="+config.BoardPath(),
+		"GOTASK_CONFIG="+config.Path(),
+	)
+}
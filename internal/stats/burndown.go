@@ -0,0 +1,85 @@
+package stats
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gotask/internal/board"
+)
+
+// BurnPoint is the state of the board at the end of a day
+type BurnPoint struct {
+	Day   time.Time
+	Scope int // tasks created by then
+	Done  int // of those, tasks finished by then
+}
+
+// Remaining is what is left to do at the end of the day
+func (p BurnPoint) Remaining() int {
+	return p.Scope - p.Done
+}
+
+// ParseDays reads a number of days such as "30d", "2w", or "14"
+func ParseDays(s string) (int, error) {
+	number, unit := strings.TrimSuffix(s, "d"), 1
+	if n, ok := strings.CutSuffix(s, "w"); ok {
+		number, unit = n, 7
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("can't read %q, use e.g. 7d or 2w", s)
+	}
+	return n * unit, nil
+}
+
+// FinishedAt is when a task was finished, if it was. Tasks moved to the
+// last column before completion dates were recorded count as finished
+// when they were last changed.
+func FinishedAt(task board.Task, done bool) (time.Time, bool) {
+	switch {
+	case task.CompletedAt != nil:
+		return *task.CompletedAt, true
+	case done:
+		return task.LastUpdated(), true
+	}
+	return time.Time{}, false
+}
+
+// Burndown works out the scope and the finished tasks at the end of each
+// of the last days days up to now, from when the tasks on the board and in
+// the archive were created and finished. Tasks archived unfinished leave
+// the scope when they are archived.
+func Burndown(b *board.Board, days int, now time.Time) []BurnPoint {
+	points := make([]BurnPoint, days)
+	first := board.DayOf(now).AddDate(0, 0, 1-days)
+	for i := range points {
+		points[i].Day = first.AddDate(0, 0, i)
+	}
+	count := func(task board.Task, done bool, dropped *time.Time) {
+		finished, ok := FinishedAt(task, done)
+		for i := range points {
+			end := points[i].Day.AddDate(0, 0, 1)
+			if task.CreatedAt.Before(end) && (dropped == nil || !dropped.Before(end)) {
+				points[i].Scope++
+				if ok && finished.Before(end) {
+					points[i].Done++
+				}
+			}
+		}
+	}
+	for i, col := range b.Columns {
+		for _, task := range col.Tasks {
+			count(task, b.IsDone(i), nil)
+		}
+	}
+	for _, task := range b.Archive {
+		if task.CompletedAt != nil {
+			count(task.Task, true, nil)
+		} else {
+			count(task.Task, false, &task.ArchivedAt)
+		}
+	}
+	return points
+}
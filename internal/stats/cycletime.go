@@ -0,0 +1,127 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"gotask/internal/board"
+)
+
+// Durations sums up a set of durations
+type Durations struct {
+	Count int
+	Mean  time.Duration
+	P50   time.Duration
+	P85   time.Duration
+	P95   time.Duration
+}
+
+// SummarizeDurations works out the mean and percentiles of ds, which it
+// sorts
+func SummarizeDurations(ds []time.Duration) Durations {
+	if len(ds) == 0 {
+		return Durations{}
+	}
+	slices.Sort(ds)
+	var total time.Duration
+	for _, d := range ds {
+		total += d
+	}
+	// Nearest rank
+	percentile := func(p float64) time.Duration {
+		return ds[max(0, int(math.Ceil(p/100*float64(len(ds))))-1)]
+	}
+	return Durations{
+		Count: len(ds),
+		Mean:  total / time.Duration(len(ds)),
+		P50:   percentile(50),
+		P85:   percentile(85),
+		P95:   percentile(95),
+	}
+}
+
+// ColumnTime is how long tasks spent in a column
+type ColumnTime struct {
+	Title string
+	Durations
+}
+
+// CycleTimes is how long tasks took to get through the board
+type CycleTimes struct {
+	Lead    Durations    // from creation to completion
+	Cycle   Durations    // from the first move to completion
+	Columns []ColumnTime // spells in each column
+}
+
+// NewCycleTimes measures the tasks finished, and the spells in columns that
+// ended, since the given time, archived tasks included
+func NewCycleTimes(b *board.Board, since time.Time) CycleTimes {
+	var lead, cycle []time.Duration
+	inColumn := make(map[string][]time.Duration)
+	measure := func(task board.Task) {
+		for _, s := range task.Stays() {
+			if !s.Left.Before(since) {
+				inColumn[s.Column] = append(inColumn[s.Column], s.Left.Sub(s.Entered))
+			}
+		}
+		if task.CompletedAt == nil || task.CompletedAt.Before(since) {
+			return
+		}
+		lead = append(lead, task.CompletedAt.Sub(task.CreatedAt))
+		if d, ok := CycleTime(task); ok {
+			cycle = append(cycle, d)
+		}
+	}
+	for _, col := range b.Columns {
+		for _, task := range col.Tasks {
+			measure(task)
+		}
+	}
+	for _, task := range b.Archive {
+		measure(task.Task)
+	}
+
+	c := CycleTimes{Lead: SummarizeDurations(lead), Cycle: SummarizeDurations(cycle)}
+	for _, col := range b.Columns {
+		c.Columns = append(c.Columns, ColumnTime{col.Title, SummarizeDurations(inColumn[col.Title])})
+	}
+	return c
+}
+
+// CycleTime is how long a finished task took from its first move to its
+// completion
+func CycleTime(task board.Task) (time.Duration, bool) {
+	if task.CompletedAt == nil {
+		return 0, false
+	}
+	for _, e := range task.History {
+		if e.Action == board.EventMoved {
+			return task.CompletedAt.Sub(e.At), true
+		}
+	}
+	return 0, false
+}
+
+// WriteCycleTimes writes the cycle times as a table
+func WriteCycleTimes(w io.Writer, c CycleTimes) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "\ttasks\tavg\tp50\tp85\tp95")
+	row := func(name string, s Durations) {
+		if s.Count == 0 {
+			fmt.Fprintf(tw, "%s\t0\t-\t-\t-\t-\n", name)
+			return
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\n", name, s.Count,
+			board.FormatSpan(s.Mean), board.FormatSpan(s.P50), board.FormatSpan(s.P85), board.FormatSpan(s.P95))
+	}
+	for _, col := range c.Columns {
+		row(col.Title, col.Durations)
+	}
+	row("Lead time", c.Lead)
+	row("Cycle time", c.Cycle)
+	return tw.Flush()
+}
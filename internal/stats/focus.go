@@ -0,0 +1,39 @@
+package stats
+
+import (
+	"time"
+
+	"gotask/internal/board"
+)
+
+// FocusDays totals the focus sessions recorded on each of the last days
+// days, today last, and counts the sessions
+func FocusDays(b *board.Board, days int, now time.Time) ([]time.Duration, int) {
+	totals := make([]time.Duration, days)
+	first := board.DayOf(now).AddDate(0, 0, 1-days)
+	sessions := 0
+	add := func(task board.Task) {
+		for _, e := range task.History {
+			if e.Action != board.EventFocus || e.At.Before(first) {
+				continue
+			}
+			spent, err := time.ParseDuration(e.To)
+			if err != nil {
+				continue
+			}
+			if day := int(board.DayOf(e.At).Sub(first).Hours() / 24); day < days {
+				totals[day] += spent
+				sessions++
+			}
+		}
+	}
+	for _, col := range b.Columns {
+		for _, task := range col.Tasks {
+			add(task)
+		}
+	}
+	for _, task := range b.Archive {
+		add(task.Task)
+	}
+	return totals, sessions
+}
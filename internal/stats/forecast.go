@@ -0,0 +1,123 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	rand "math/rand/v2"
+	"strconv"
+	"time"
+
+	"gotask/internal/board"
+)
+
+const (
+	// forecastRuns is how many futures the forecast simulates
+	forecastRuns = 1000
+	// forecastHorizon is how many days ahead a simulated future runs
+	// before giving up on finishing
+	forecastHorizon = 5 * 365
+)
+
+// TaskForecast is when an open task is likely to be finished, taking the
+// tasks ahead of it first
+type TaskForecast struct {
+	Task   board.Task
+	Column string
+	Durations
+}
+
+// Forecast is when the open tasks are likely to be finished at the pace
+// of recent throughput
+type Forecast struct {
+	Days       int     // how many days of throughput it is based on
+	Throughput float64 // tasks finished a day, on average
+	Average    time.Duration
+	Tasks      []TaskForecast // nearest to done first; the last is all of them
+}
+
+// All is the forecast for finishing every open task
+func (f Forecast) All() Durations {
+	if len(f.Tasks) == 0 {
+		return Durations{}
+	}
+	return f.Tasks[len(f.Tasks)-1].Durations
+}
+
+// Throughput counts the tasks finished on each of the days full days
+// before now's
+func Throughput(b *board.Board, days int, now time.Time) []int {
+	finished := CompletionsByDay(b)
+	counts := make([]int, days)
+	first := board.DayOf(now).AddDate(0, 0, -days)
+	for i := range counts {
+		counts[i] = finished[first.AddDate(0, 0, i).Format(board.DueLayout)]
+	}
+	return counts
+}
+
+// NewForecast estimates when the open tasks will be finished by replaying
+// the last days days of throughput: each simulated day finishes as many
+// tasks as a day picked at random from them. Tasks are taken in board
+// order from the column nearest to done. It reports false when nothing
+// was finished in that time to forecast from.
+func NewForecast(b *board.Board, days int, now time.Time) (Forecast, bool) {
+	samples := Throughput(b, days, now)
+	total := 0
+	for _, n := range samples {
+		total += n
+	}
+	f := Forecast{Days: days, Throughput: float64(total) / float64(days)}
+	for i := len(b.Columns) - 1; i >= 0; i-- {
+		if b.IsDone(i) {
+			continue
+		}
+		for _, task := range b.Columns[i].Tasks {
+			f.Tasks = append(f.Tasks, TaskForecast{Task: task, Column: b.Columns[i].Title})
+		}
+	}
+	if total == 0 {
+		return f, false
+	}
+	open := len(f.Tasks)
+	f.Average = time.Duration(math.Ceil(float64(open)/f.Throughput)) * 24 * time.Hour
+
+	// Seeded from the board, so the forecast holds still until it changes
+	rng := rand.New(rand.NewPCG(uint64(open), uint64(total)))
+	finished := make([][]time.Duration, open)
+	for run := 0; run < forecastRuns; run++ {
+		done := 0
+		for day := 1; done < open; day++ {
+			next := done + samples[rng.IntN(len(samples))]
+			if day == forecastHorizon {
+				next = open
+			}
+			for ; done < min(next, open); done++ {
+				finished[done] = append(finished[done], time.Duration(day)*24*time.Hour)
+			}
+		}
+	}
+	for i := range f.Tasks {
+		f.Tasks[i].Durations = SummarizeDurations(finished[i])
+	}
+	return f, true
+}
+
+// ForecastDate is the day a forecast lands on, or "later" past the horizon
+func ForecastDate(d time.Duration, now time.Time) string {
+	if d >= forecastHorizon*24*time.Hour {
+		return "later"
+	}
+	return board.DayOf(now).Add(d).Format("Jan 2")
+}
+
+// Summary describes when all the open tasks are likely to be finished
+func (f Forecast) Summary(now time.Time) []string {
+	all := f.All()
+	return []string{
+		fmt.Sprintf("%d open %s at %s finished a day: about %s at the average pace",
+			len(f.Tasks), board.Plural(len(f.Tasks), "task"),
+			strconv.FormatFloat(RoundTenth(f.Throughput), 'f', -1, 64), ForecastDate(f.Average, now)),
+		fmt.Sprintf("50%% likely by %s · 85%% by %s · 95%% by %s",
+			ForecastDate(all.P50, now), ForecastDate(all.P85, now), ForecastDate(all.P95, now)),
+	}
+}
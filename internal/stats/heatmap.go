@@ -0,0 +1,49 @@
+package stats
+
+import (
+	"time"
+
+	"gotask/internal/board"
+)
+
+// CompletionsByDay counts the tasks finished each day, keyed as
+// YYYY-MM-DD, archived ones included
+func CompletionsByDay(b *board.Board) map[string]int {
+	days := make(map[string]int)
+	count := func(task board.Task) {
+		if task.CompletedAt != nil {
+			days[board.DayOf(*task.CompletedAt).Format(board.DueLayout)]++
+		}
+	}
+	for _, col := range b.Columns {
+		for _, task := range col.Tasks {
+			count(task)
+		}
+	}
+	for _, task := range b.Archive {
+		count(task.Task)
+	}
+	return days
+}
+
+// Streaks finds the run of days with completions that reaches up to today
+// or yesterday, and the longest run, looking back from now as far as from
+func Streaks(days map[string]int, from, now time.Time) (current, longest int) {
+	run := 0
+	for day := board.DayOf(from); !day.After(now); day = day.AddDate(0, 0, 1) {
+		if days[day.Format(board.DueLayout)] > 0 {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	// A day without completions yet doesn't break the streak until it's over
+	current = run
+	if run == 0 {
+		for day := board.DayOf(now).AddDate(0, 0, -1); days[day.Format(board.DueLayout)] > 0; day = day.AddDate(0, 0, -1) {
+			current++
+		}
+	}
+	return current, longest
+}
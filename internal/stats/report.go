@@ -0,0 +1,77 @@
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"gotask/internal/board"
+)
+
+// PomodoroLength is how long one pomodoro lasts
+const PomodoroLength = 25 * time.Minute
+
+// Completed is a task finished within a report's window, on the board
+// or in the archive
+type Completed struct {
+	board.Task
+	Column string // where it is now, or was archived from
+}
+
+// CompletedSince lists the tasks finished since the given time, earliest
+// first
+func CompletedSince(b *board.Board, since time.Time) []Completed {
+	var done []Completed
+	for _, col := range b.Columns {
+		for _, task := range col.Tasks {
+			if task.CompletedAt != nil && !task.CompletedAt.Before(since) {
+				done = append(done, Completed{task, col.Title})
+			}
+		}
+	}
+	for _, task := range b.Archive {
+		if task.CompletedAt != nil && !task.CompletedAt.Before(since) {
+			done = append(done, Completed{task.Task, task.Column})
+		}
+	}
+	sort.SliceStable(done, func(i, j int) bool { return done[i].CompletedAt.Before(*done[j].CompletedAt) })
+	return done
+}
+
+// Untagged heads the group of tasks without tags in a report by tag
+const Untagged = "Untagged"
+
+// Tracked is time logged against a task: a finished pomodoro or a
+// focus session
+type Tracked struct {
+	Task   board.Task
+	Column string
+	at     time.Time
+	Spent  time.Duration
+}
+
+// TrackedSince lists the time logged since the given time, on the board
+// and in the archive
+func TrackedSince(b *board.Board, since time.Time) []Tracked {
+	var tracked []Tracked
+	add := func(task board.Task, column string) {
+		for _, e := range task.History {
+			if (e.Action != board.EventPomodoro && e.Action != board.EventFocus) || e.At.Before(since) {
+				continue
+			}
+			spent, err := time.ParseDuration(e.To)
+			if err != nil || spent <= 0 {
+				spent = PomodoroLength
+			}
+			tracked = append(tracked, Tracked{task, column, e.At, spent})
+		}
+	}
+	for _, col := range b.Columns {
+		for _, task := range col.Tasks {
+			add(task, col.Title)
+		}
+	}
+	for _, task := range b.Archive {
+		add(task.Task, task.Column)
+	}
+	return tracked
+}
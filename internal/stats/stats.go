@@ -0,0 +1,80 @@
+// Package stats works out the numbers gotask reports about a board: cycle
+// times, throughput and forecasts, burndown, velocity, streaks, and time
+// tracked per tag. It only reads boards, so the TUI, the CLI and the
+// server all report the same figures.
+package stats
+
+import (
+	"time"
+
+	"gotask/internal/board"
+)
+
+// Board is what the statistics screen shows about the board
+type Board struct {
+	Columns    []Column
+	Open       int           // tasks not in the last column
+	AverageAge time.Duration // how long open tasks have been on the board
+	Oldest     *board.Task   // the open task created longest ago
+	OldestIn   string        // the column of the oldest task
+	// CompletedByDay counts the tasks finished on each day of this week,
+	// Monday first, archived ones included
+	CompletedByDay [7]int
+}
+
+// CompletedThisWeek counts the tasks finished since Monday
+func (s Board) CompletedThisWeek() int {
+	total := 0
+	for _, n := range s.CompletedByDay {
+		total += n
+	}
+	return total
+}
+
+// StartOfWeek returns midnight on the Monday of t's week
+func StartOfWeek(t time.Time) time.Time {
+	day := board.DayOf(t)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// Of measures the board for the statistics screen
+func Of(b *board.Board, now time.Time) Board {
+	s := Board{Columns: Summarize(b, now).Columns}
+	monday := StartOfWeek(now)
+	completed := func(task board.Task) {
+		if task.CompletedAt == nil || task.CompletedAt.Before(monday) {
+			return
+		}
+		if day := int(board.DayOf(*task.CompletedAt).Sub(monday).Hours() / 24); day < 7 {
+			s.CompletedByDay[day]++
+		}
+	}
+
+	var totalAge time.Duration
+	for i, col := range b.Columns {
+		for _, task := range col.Tasks {
+			completed(task)
+			if b.IsDone(i) {
+				continue
+			}
+			s.Open++
+			totalAge += now.Sub(task.CreatedAt)
+			if s.Oldest == nil || task.CreatedAt.Before(s.Oldest.CreatedAt) {
+				oldest := task
+				s.Oldest, s.OldestIn = &oldest, col.Title
+			}
+		}
+	}
+	for _, task := range b.Archive {
+		completed(task.Task)
+	}
+	if s.Open > 0 {
+		s.AverageAge = totalAge / time.Duration(s.Open)
+	}
+	return s
+}
+
+// AgeDays formats a duration in whole days, e.g. "3d" or "2w"
+func AgeDays(d time.Duration) string {
+	return board.ShortDuration(int(d.Hours() / 24))
+}
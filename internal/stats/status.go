@@ -0,0 +1,62 @@
+package stats
+
+import (
+	"time"
+
+	"gotask/internal/board"
+)
+
+// Status sums up the board for status lines. Its fields are what
+// status format templates see.
+type Status struct {
+	Total           int // every task on the board
+	Open            int // tasks not in the last column
+	TodoCount       int // tasks in the first column
+	InProgressCount int // tasks in the columns between the first and last
+	DoneCount       int // tasks in the last column
+	DueToday        int // open tasks due today
+	Overdue         int // open tasks due before today
+	DueSoon         int // open tasks due in the next week, after today
+	Columns         []Column
+}
+
+// Column is a column's title and number of tasks
+type Column struct {
+	Title string
+	Count int
+}
+
+// Summarize counts the board's tasks for a status line
+func Summarize(b *board.Board, now time.Time) Status {
+	var s Status
+	for i, col := range b.Columns {
+		n := len(col.Tasks)
+		s.Total += n
+		s.Columns = append(s.Columns, Column{col.Title, n})
+		switch {
+		case b.IsDone(i):
+			s.DoneCount += n
+			continue
+		case i == 0:
+			s.TodoCount += n
+		default:
+			s.InProgressCount += n
+		}
+		s.Open += n
+
+		for _, task := range col.Tasks {
+			if task.Due == nil || task.CompletedAt != nil {
+				continue
+			}
+			switch days := board.DaysUntil(*task.Due, now); {
+			case days < 0:
+				s.Overdue++
+			case days == 0:
+				s.DueToday++
+			case days <= board.DueSoonDays:
+				s.DueSoon++
+			}
+		}
+	}
+	return s
+}
@@ -0,0 +1,105 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gotask/internal/board"
+)
+
+// Tag is how the work under a tag is going
+type Tag struct {
+	Tag       string
+	Open      int
+	Completed int           // finished in the window
+	Cycle     Durations     // of the tasks finished in the window
+	Tracked   time.Duration // with pomodoros and focus sessions in the window
+}
+
+// TagsOf lists the tags a task counts under, untagged if it has none
+func TagsOf(task board.Task) []string {
+	if len(task.Tags) == 0 {
+		return []string{Untagged}
+	}
+	return task.Tags
+}
+
+// ByTag breaks the open tasks, and the work done since the given time,
+// down by tag, busiest tags first. Unlike the reports, a task with several
+// tags counts under each of them, so the rows don't add up to the board.
+func ByTag(b *board.Board, since time.Time) []Tag {
+	index := make(map[string]*Tag)
+	cycles := make(map[string][]time.Duration)
+	var stats []*Tag
+	get := func(tag string) *Tag {
+		key := strings.ToLower(tag)
+		if s, ok := index[key]; ok {
+			return s
+		}
+		s := &Tag{Tag: tag}
+		index[key] = s
+		stats = append(stats, s)
+		return s
+	}
+
+	for i, col := range b.Columns {
+		for _, task := range col.Tasks {
+			if !b.IsDone(i) {
+				for _, tag := range TagsOf(task) {
+					get(tag).Open++
+				}
+			}
+		}
+	}
+	for _, task := range CompletedSince(b, since) {
+		d, ok := CycleTime(task.Task)
+		for _, tag := range TagsOf(task.Task) {
+			s := get(tag)
+			s.Completed++
+			if ok {
+				cycles[strings.ToLower(tag)] = append(cycles[strings.ToLower(tag)], d)
+			}
+		}
+	}
+	for _, t := range TrackedSince(b, since) {
+		for _, tag := range TagsOf(t.Task) {
+			get(tag).Tracked += t.Spent
+		}
+	}
+
+	result := make([]Tag, len(stats))
+	for i, s := range stats {
+		s.Cycle = SummarizeDurations(cycles[strings.ToLower(s.Tag)])
+		result[i] = *s
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Open+result[i].Completed > result[j].Open+result[j].Completed
+	})
+	return result
+}
+
+// WriteTags writes the breakdown by tag as a table
+func WriteTags(w io.Writer, stats []Tag) error {
+	if len(stats) == 0 {
+		_, err := fmt.Fprintln(w, "No tasks")
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "tag\topen\tdone\tcycle avg\tp50\tp85\ttracked")
+	for _, s := range stats {
+		cycle := "-\t-\t-"
+		if s.Cycle.Count > 0 {
+			cycle = fmt.Sprintf("%s\t%s\t%s", board.FormatSpan(s.Cycle.Mean), board.FormatSpan(s.Cycle.P50), board.FormatSpan(s.Cycle.P85))
+		}
+		tracked := "-"
+		if s.Tracked > 0 {
+			tracked = board.FormatSpan(s.Tracked)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\n", s.Tag, s.Open, s.Completed, cycle, tracked)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,54 @@
+package stats
+
+import (
+	"math"
+	"time"
+
+	"gotask/internal/board"
+)
+
+// VelocityWeeks is how many weeks of velocity the statistics show
+const VelocityWeeks = 8
+
+// WeekVelocity is the work finished in a week, Monday to Sunday
+type WeekVelocity struct {
+	Start  time.Time
+	Points float64
+	Tasks  int
+}
+
+// Velocity totals the points of the tasks finished in each of the last
+// weeks weeks, this one last, archived tasks included
+func Velocity(b *board.Board, weeks int, now time.Time) []WeekVelocity {
+	v := make([]WeekVelocity, weeks)
+	first := StartOfWeek(now).AddDate(0, 0, -7*(weeks-1))
+	for i := range v {
+		v[i].Start = first.AddDate(0, 0, 7*i)
+	}
+	for _, task := range CompletedSince(b, first) {
+		week := int(board.DayOf(*task.CompletedAt).Sub(first).Hours() / (24 * 7))
+		if week < weeks {
+			v[week].Points += task.Points
+			v[week].Tasks++
+		}
+	}
+	return v
+}
+
+// AverageVelocity averages the points of the full weeks, leaving out the
+// one under way
+func AverageVelocity(v []WeekVelocity) float64 {
+	if len(v) < 2 {
+		return 0
+	}
+	total := 0.0
+	for _, week := range v[:len(v)-1] {
+		total += week.Points
+	}
+	return total / float64(len(v)-1)
+}
+
+// RoundTenth rounds points to a tenth, e.g. 12.5
+func RoundTenth(points float64) float64 {
+	return math.Round(points*10) / 10
+}
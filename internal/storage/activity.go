@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"time"
+)
+
+// MaxActivity is how many events the activity panel lists
+const MaxActivity = 300
+
+// ActivityEntry is an event in the board's activity feed
+type ActivityEntry struct {
+	At    time.Time
+	ID    int
+	Title string // empty for deleted tasks
+	What  string
+}
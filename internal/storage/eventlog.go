@@ -0,0 +1,828 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gotask/internal/board"
+)
+
+// Kinds of events in the change log
+const (
+	EventChange   = "change"   // a change made on the board
+	EventUndo     = "undo"     // the last change undone
+	EventRedo     = "redo"     // the last undone change made again
+	EventExternal = "external" // the board changed outside the log, e.g. by sync
+)
+
+// ErrNoStep is returned when there is nothing to undo or redo
+var ErrNoStep = errors.New("nothing to undo or redo")
+
+// Event is an entry in the change log: the slots of the board that
+// changed, with what they held before and after
+type Event struct {
+	Seq     int          `json:"seq"`
+	At      time.Time    `json:"at"`
+	Kind    string       `json:"kind"`
+	Target  int          `json:"target,omitempty"` // the change an undo or redo is of
+	Summary string       `json:"summary,omitempty"`
+	Changes []slotChange `json:"changes,omitempty"`
+
+	offset int64 // where the event is in the log file
+}
+
+// slotChange is a slot of the board before and after an event, each
+// missing if the slot didn't exist
+type slotChange struct {
+	Slot   string          `json:"slot"`
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// slotLayout is where the tasks are: their order in each column and in
+// the archive
+type slotLayout struct {
+	Columns []slotColumn `json:"columns"`
+	Archive []int        `json:"archive,omitempty"`
+}
+
+type slotColumn struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	Tasks []int  `json:"tasks"`
+}
+
+// boardSlots splits the board into the slots the change log tracks: each
+// task and archived task by ID, the layout, the deletions, and the flow,
+// each as JSON
+func boardSlots(b board.Board) (map[string]json.RawMessage, error) {
+	slots := make(map[string]json.RawMessage)
+	var err error
+	put := func(slot string, v any) {
+		if err == nil {
+			slots[slot], err = json.Marshal(v)
+		}
+	}
+	var layout slotLayout
+	for _, col := range b.Columns {
+		lc := slotColumn{ID: col.ID, Title: col.Title, Tasks: []int{}}
+		for _, task := range col.Tasks {
+			lc.Tasks = append(lc.Tasks, task.ID)
+			put("task:"+strconv.Itoa(task.ID), task)
+		}
+		layout.Columns = append(layout.Columns, lc)
+	}
+	for _, task := range b.Archive {
+		layout.Archive = append(layout.Archive, task.ID)
+		put("archived:"+strconv.Itoa(task.ID), task)
+	}
+	put("layout", layout)
+	if len(b.Deleted) > 0 {
+		put("deleted", b.Deleted)
+	}
+	if len(b.Flow) > 0 {
+		put("flow", b.Flow)
+	}
+	return slots, err
+}
+
+// slotsBoard puts a board back together from its slots
+func slotsBoard(slots map[string]json.RawMessage) (board.Board, error) {
+	var b board.Board
+	var layout slotLayout
+	if data, ok := slots["layout"]; ok {
+		if err := json.Unmarshal(data, &layout); err != nil {
+			return b, fmt.Errorf("layout: %w", err)
+		}
+	}
+	for _, lc := range layout.Columns {
+		col := board.Column{ID: lc.ID, Title: lc.Title, Tasks: []board.Task{}}
+		for _, id := range lc.Tasks {
+			var task board.Task
+			if err := json.Unmarshal(slots["task:"+strconv.Itoa(id)], &task); err != nil {
+				return b, fmt.Errorf("task #%d: %w", id, err)
+			}
+			col.Tasks = append(col.Tasks, task)
+		}
+		b.Columns = append(b.Columns, col)
+	}
+	for _, id := range layout.Archive {
+		var task board.ArchivedTask
+		if err := json.Unmarshal(slots["archived:"+strconv.Itoa(id)], &task); err != nil {
+			return b, fmt.Errorf("archived task #%d: %w", id, err)
+		}
+		b.Archive = append(b.Archive, task)
+	}
+	if data, ok := slots["deleted"]; ok {
+		if err := json.Unmarshal(data, &b.Deleted); err != nil {
+			return b, fmt.Errorf("deleted: %w", err)
+		}
+	}
+	if data, ok := slots["flow"]; ok {
+		if err := json.Unmarshal(data, &b.Flow); err != nil {
+			return b, fmt.Errorf("flow: %w", err)
+		}
+	}
+	return b, nil
+}
+
+// diffSlots lists the slots that differ between two boards, in order
+func diffSlots(before, after map[string]json.RawMessage) []slotChange {
+	var changes []slotChange
+	for slot, a := range after {
+		if b, ok := before[slot]; !ok || !bytes.Equal(a, b) {
+			changes = append(changes, slotChange{Slot: slot, Before: before[slot], After: a})
+		}
+	}
+	for slot, b := range before {
+		if _, ok := after[slot]; !ok {
+			changes = append(changes, slotChange{Slot: slot, Before: b})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Slot < changes[j].Slot })
+	return changes
+}
+
+// invert swaps the before and after of each change, to undo them
+func invert(changes []slotChange) []slotChange {
+	inverted := make([]slotChange, len(changes))
+	for i, c := range changes {
+		inverted[i] = slotChange{Slot: c.Slot, Before: c.After, After: c.Before}
+	}
+	return inverted
+}
+
+// slotTaskID returns the task a task or archived task slot holds
+func slotTaskID(slot string) (int, bool) {
+	rest, ok := strings.CutPrefix(slot, "task:")
+	if !ok {
+		if rest, ok = strings.CutPrefix(slot, "archived:"); !ok {
+			return 0, false
+		}
+	}
+	id, err := strconv.Atoi(rest)
+	return id, err == nil
+}
+
+// rebase fits changes being undone or redone to the board the log has
+// now, so that they don't take back what has changed since, such as
+// changes made outside the board. Tasks changed since keep their changes
+// and places, and the other tasks move within the layout as it is now.
+func (l *EventLog) rebase(changes []slotChange) ([]slotChange, error) {
+	skip := make(map[int]bool)
+	for _, c := range changes {
+		if id, ok := slotTaskID(c.Slot); ok && !bytes.Equal(l.slots[c.Slot], c.Before) {
+			skip[id] = true
+		}
+	}
+	var rebased []slotChange
+	for _, c := range changes {
+		now := l.slots[c.Slot]
+		id, isTask := slotTaskID(c.Slot)
+		switch {
+		case isTask && skip[id]:
+		case c.Slot == "layout" && (len(skip) > 0 || !bytes.Equal(now, c.Before)):
+			layout, err := rebaseLayout(c.Before, c.After, now, skip)
+			if err != nil {
+				return nil, err
+			}
+			rebased = append(rebased, slotChange{Slot: c.Slot, Before: now, After: layout})
+		case bytes.Equal(now, c.Before):
+			rebased = append(rebased, c)
+		}
+	}
+	return rebased, nil
+}
+
+// taskList returns the list of task IDs in the layout named by key, a
+// column's ID or "archive", or nil if there is none
+func (l *slotLayout) taskList(key string) *[]int {
+	if key == "archive" {
+		return &l.Archive
+	}
+	for i := range l.Columns {
+		if strconv.Itoa(l.Columns[i].ID) == key {
+			return &l.Columns[i].Tasks
+		}
+	}
+	return nil
+}
+
+// place finds a task in the layout: the key of its list, where it is in
+// the list, and the task before it there, or 0 if it is first
+func (l *slotLayout) place(id int) (key string, index, after int) {
+	find := func(k string, ids []int) bool {
+		for i, other := range ids {
+			if other == id {
+				key, index = k, i
+				if i > 0 {
+					after = ids[i-1]
+				}
+				return true
+			}
+		}
+		return false
+	}
+	for _, col := range l.Columns {
+		if find(strconv.Itoa(col.ID), col.Tasks) {
+			return key, index, after
+		}
+	}
+	find("archive", l.Archive)
+	return key, index, after
+}
+
+// rebaseLayout moves the tasks that a change from one layout to another
+// moved, except those in skip, within the layout as it is now
+func rebaseLayout(from, to, now json.RawMessage, skip map[int]bool) (json.RawMessage, error) {
+	var before, after, layout slotLayout
+	for _, l := range []struct {
+		data   json.RawMessage
+		layout *slotLayout
+	}{{from, &before}, {to, &after}, {now, &layout}} {
+		if l.data == nil {
+			continue
+		}
+		if err := json.Unmarshal(l.data, l.layout); err != nil {
+			return nil, fmt.Errorf("layout: %w", err)
+		}
+	}
+
+	// A task moved if its list or the task before it changed
+	moved := make(map[int]bool)
+	note := func(ids []int) {
+		for _, id := range ids {
+			k1, _, a1 := before.place(id)
+			k2, _, a2 := after.place(id)
+			if !skip[id] && (k1 != k2 || a1 != a2) {
+				moved[id] = true
+			}
+		}
+	}
+	for _, l := range []slotLayout{before, after} {
+		for _, col := range l.Columns {
+			note(col.Tasks)
+		}
+		note(l.Archive)
+	}
+
+	// Take them out, then put them back after the task they follow
+	for i := range layout.Columns {
+		layout.Columns[i].Tasks = slices.DeleteFunc(layout.Columns[i].Tasks, func(id int) bool { return moved[id] })
+	}
+	layout.Archive = slices.DeleteFunc(layout.Archive, func(id int) bool { return moved[id] })
+	put := func(key string, ids []int) {
+		list := layout.taskList(key)
+		for i, id := range ids {
+			if !moved[id] || list == nil {
+				continue
+			}
+			at := min(i, len(*list))
+			if i == 0 {
+				at = 0
+			} else if j := slices.Index(*list, ids[i-1]); j >= 0 {
+				at = j + 1
+			}
+			*list = slices.Insert(*list, at, id)
+		}
+	}
+	for _, col := range after.Columns {
+		put(strconv.Itoa(col.ID), col.Tasks)
+	}
+	put("archive", after.Archive)
+	return json.Marshal(layout)
+}
+
+// summarizeChanges describes what changed in words, from the history the
+// changed tasks gained, e.g. `#3 "Buy milk" moved from To Do to Done`
+func summarizeChanges(changes []slotChange) string {
+	archived, unarchived := make(map[string]bool), make(map[string]bool)
+	for _, c := range changes {
+		if id, ok := strings.CutPrefix(c.Slot, "archived:"); ok {
+			archived[id], unarchived[id] = c.After != nil, c.After == nil
+		}
+	}
+	var parts []string
+	for _, c := range changes {
+		id, ok := strings.CutPrefix(c.Slot, "task:")
+		if !ok {
+			continue
+		}
+		var before, after board.Task
+		json.Unmarshal(c.Before, &before)
+		json.Unmarshal(c.After, &after)
+		what := "edited"
+		switch {
+		case c.Before == nil && unarchived[id] && len(after.History) > 0:
+			what = board.DescribeEvent(after.History[len(after.History)-1])
+		case c.Before == nil:
+			what = "created"
+		case c.After == nil && archived[id]:
+			what = "archived"
+		case c.After == nil:
+			what = "deleted"
+		case len(after.History) > len(before.History):
+			what = board.DescribeEvent(after.History[len(after.History)-1])
+		}
+		title := cmp.Or(after.Title, before.Title)
+		parts = append(parts, fmt.Sprintf("#%s %q %s", id, title, what))
+	}
+	if len(parts) == 0 {
+		for _, c := range changes {
+			if id, ok := strings.CutPrefix(c.Slot, "archived:"); ok && c.After == nil {
+				parts = append(parts, fmt.Sprintf("#%s taken out of the archive", id))
+			}
+		}
+	}
+	switch {
+	case len(parts) > 3:
+		return strings.Join(parts[:2], "; ") + fmt.Sprintf("; and %d more tasks", len(parts)-2)
+	case len(parts) > 0:
+		return strings.Join(parts, "; ")
+	}
+	for _, c := range changes {
+		if c.Slot == "layout" {
+			return "tasks reordered"
+		}
+	}
+	return "board updated"
+}
+
+// EventLog is the change log of a board: every change appended to a file
+// as an event. The board is derived from it, and changes are undone with
+// it across sessions. The TUI and the API server may share the file, so
+// each takes a lock on it to append and first reads what the others
+// appended, numbering every event in one sequence. A snapshot kept beside
+// the file spares replaying all of it on every start.
+type EventLog struct {
+	Path    string  // "" keeps the log in memory only
+	Entries []Event // the latest events, without their changes
+	seq     int
+	offset  int64                      // how much of the file has been read
+	modTime time.Time                  // when the file changed, as of the last read
+	slots   map[string]json.RawMessage // the board as the log has it
+	undo    []Event                    // changes that can be undone, the last first to go
+	redo    []Event                    // changes undone that can be made again
+	fresh   int                        // events read since the snapshot was taken
+}
+
+// snapshotEvery is how many events the log may gain before its snapshot
+// is taken again
+const snapshotEvery = 500
+
+// logSnapshot is the state of the log as of an offset into its file. The
+// changes on the undo and redo stacks are read back from the file when
+// they are needed.
+type logSnapshot struct {
+	Seq     int                        `json:"seq"`
+	Offset  int64                      `json:"offset"`
+	Slots   map[string]json.RawMessage `json:"slots"`
+	Undo    []eventRef                 `json:"undo,omitempty"`
+	Redo    []eventRef                 `json:"redo,omitempty"`
+	Entries []Event                    `json:"entries,omitempty"`
+}
+
+// eventRef is where an event is in the log file
+type eventRef struct {
+	Seq    int   `json:"seq"`
+	Offset int64 `json:"offset"`
+}
+
+// EventLogPath is where the change log of the board at path is kept,
+// e.g. ~/.kanban.events.jsonl beside ~/.kanban.json
+func EventLogPath(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ".events.jsonl"
+}
+
+// snapshotPath is where the snapshot of the log at path is kept, e.g.
+// ~/.kanban.events.snapshot.json
+func snapshotPath(path string) string {
+	return strings.TrimSuffix(path, ".jsonl") + ".snapshot.json"
+}
+
+// OpenEventLog reads the change log at path, from its snapshot on if it
+// has one that fits. Lines that can't be read, e.g. from a crash halfway
+// through writing one, are skipped and reported once the rest is read.
+func OpenEventLog(path string) (*EventLog, error) {
+	l := &EventLog{Path: path, slots: make(map[string]json.RawMessage)}
+	if path == "" {
+		return l, nil
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return l, nil
+	} else if err != nil {
+		return l, err
+	}
+	defer f.Close()
+	l.loadSnapshot(f)
+	bad, err := l.catchUp(f)
+	if err != nil {
+		return l, err
+	}
+	if l.fresh >= snapshotEvery {
+		if err := l.saveSnapshot(); err != nil {
+			return l, err
+		}
+	}
+	if bad > 0 {
+		return l, fmt.Errorf("%s: skipped %d unreadable %s", path, bad, board.Plural(bad, "event"))
+	}
+	return l, nil
+}
+
+// loadSnapshot starts the log from its snapshot, unless there is none or
+// it doesn't fit the file, e.g. because the file was replaced
+func (l *EventLog) loadSnapshot(f *os.File) {
+	data, err := os.ReadFile(snapshotPath(l.Path))
+	if err != nil {
+		return
+	}
+	var snap logSnapshot
+	if json.Unmarshal(data, &snap) != nil || snap.Slots == nil {
+		return
+	}
+	info, err := f.Stat()
+	if err != nil || snap.Offset > info.Size() {
+		return
+	}
+	// The snapshot must end where a line does, before the event after it
+	if snap.Offset > 0 {
+		last := make([]byte, 1)
+		if _, err := f.ReadAt(last, snap.Offset-1); err != nil || last[0] != '\n' {
+			return
+		}
+	}
+	if snap.Offset < info.Size() {
+		line, err := bufio.NewReader(io.NewSectionReader(f, snap.Offset, info.Size()-snap.Offset)).ReadBytes('\n')
+		var next Event
+		if err == nil && (json.Unmarshal(line, &next) != nil || next.Seq != snap.Seq+1) {
+			return
+		}
+	}
+
+	l.seq, l.offset, l.slots, l.Entries = snap.Seq, snap.Offset, snap.Slots, snap.Entries
+	for _, ref := range snap.Undo {
+		l.undo = append(l.undo, Event{Seq: ref.Seq, offset: ref.Offset})
+	}
+	for _, ref := range snap.Redo {
+		l.redo = append(l.redo, Event{Seq: ref.Seq, offset: ref.Offset})
+	}
+}
+
+// saveSnapshot writes the log's state as of what it has read of the file
+func (l *EventLog) saveSnapshot() error {
+	snap := logSnapshot{Seq: l.seq, Offset: l.offset, Slots: l.slots, Entries: l.Entries}
+	for _, e := range l.undo {
+		snap.Undo = append(snap.Undo, eventRef{e.Seq, e.offset})
+	}
+	for _, e := range l.redo {
+		snap.Redo = append(snap.Redo, eventRef{e.Seq, e.offset})
+	}
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	var w Writer
+	if err := w.Write(snapshotPath(l.Path), data, 1); err != nil {
+		return fmt.Errorf("snapshot: %w", err)
+	}
+	l.fresh = 0
+	return nil
+}
+
+// catchUp replays the events added to the file since it was last read,
+// returning how many lines couldn't be read. A line not yet finished is
+// left for later.
+func (l *EventLog) catchUp(f *os.File) (bad int, err error) {
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	l.modTime = info.ModTime()
+	r := bufio.NewReader(io.NewSectionReader(f, l.offset, info.Size()-l.offset))
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			return bad, nil
+		} else if err != nil {
+			return bad, err
+		}
+		offset := l.offset
+		l.offset += int64(len(line))
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var e Event
+		if json.Unmarshal(line, &e) != nil {
+			bad++
+			continue
+		}
+		e.offset = offset
+		l.replay(e)
+	}
+}
+
+// withLock runs fn with the log file locked against other processes and
+// caught up with what they appended. fn gets the file to append to, or
+// nil for a log kept in memory.
+func (l *EventLog) withLock(fn func(f *os.File) error) error {
+	if l.Path == "" {
+		return fn(nil)
+	}
+	f, err := os.OpenFile(l.Path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := lockFile(f); err != nil {
+		return err
+	}
+	defer unlockFile(f)
+	if _, err := l.catchUp(f); err != nil {
+		return err
+	}
+	if err := fn(f); err != nil {
+		return err
+	}
+	if l.fresh >= snapshotEvery {
+		return l.saveSnapshot()
+	}
+	return nil
+}
+
+// replay applies an event to the board the log has, and to the undo and
+// redo stacks. Events from the file are stacked without their changes,
+// which are read back when undone or redone.
+func (l *EventLog) replay(e Event) {
+	l.seq = max(l.seq, e.Seq)
+	l.fresh++
+	for _, c := range e.Changes {
+		if c.After == nil {
+			delete(l.slots, c.Slot)
+		} else {
+			l.slots[c.Slot] = c.After
+		}
+	}
+	stacked := e
+	if l.Path != "" {
+		stacked.Changes = nil
+	}
+	switch e.Kind {
+	case EventChange:
+		l.undo = append(l.undo, stacked)
+		l.redo = nil
+	case EventUndo:
+		if n := len(l.undo); n > 0 {
+			l.redo = append(l.redo, l.undo[n-1])
+			l.undo = l.undo[:n-1]
+		}
+	case EventRedo:
+		if n := len(l.redo); n > 0 {
+			l.undo = append(l.undo, l.redo[n-1])
+			l.redo = l.redo[:n-1]
+		}
+	}
+	e.Changes = nil
+	l.Entries = append(l.Entries, e)
+	if n := len(l.Entries) - MaxActivity; n > 0 {
+		l.Entries = slices.Delete(l.Entries, 0, n)
+	}
+}
+
+// append numbers an event, writes it to the end of the log file f, which
+// withLock gives, and applies it
+func (l *EventLog) append(f *os.File, e Event) error {
+	e.Seq = l.seq + 1
+	if f != nil {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		info, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		if info.Size() > l.offset {
+			// A line left unfinished by a crash; end it, so that it
+			// reads as a bad line instead of spoiling this one
+			if _, err := f.Write([]byte("\n")); err != nil {
+				return err
+			}
+			l.offset = info.Size() + 1
+		}
+		if _, err := f.Write(append(data, '\n')); err != nil {
+			return err
+		}
+		e.offset = l.offset
+		l.offset += int64(len(data)) + 1
+		if info, err := f.Stat(); err == nil {
+			l.modTime = info.ModTime()
+		}
+	}
+	l.replay(e)
+	return nil
+}
+
+// appendDiff logs how the board differs from the one the log has, if it
+// does
+func (l *EventLog) appendDiff(f *os.File, kind string, b board.Board, now time.Time) error {
+	slots, err := boardSlots(b)
+	if err != nil {
+		return err
+	}
+	changes := diffSlots(l.slots, slots)
+	if len(changes) == 0 {
+		return nil
+	}
+	return l.append(f, Event{At: now, Kind: kind, Summary: summarizeChanges(changes), Changes: changes})
+}
+
+// Record logs how the board differs from the one the log has, if it does
+func (l *EventLog) Record(kind string, b board.Board, now time.Time) error {
+	return l.withLock(func(f *os.File) error {
+		return l.appendDiff(f, kind, b, now)
+	})
+}
+
+// ReadBoard derives the board from the log. It also reports whether the
+// board file at path is missing or behind the log, and wants writing
+// again. See derive for how the file is taken into the log.
+func (l *EventLog) ReadBoard(path string, now time.Time) (board.Board, bool, error) {
+	var b board.Board
+	var stale bool
+	err := l.withLock(func(f *os.File) (err error) {
+		b, stale, err = l.derive(f, path, now)
+		return err
+	})
+	return b, stale, err
+}
+
+// update derives the board from the log, lets fn change it, and logs the
+// change as kind, with no other change coming in between
+func (l *EventLog) update(path, kind string, now time.Time, fn func(b *board.Board) error) (board.Board, error) {
+	var b board.Board
+	err := l.withLock(func(f *os.File) (err error) {
+		if b, _, err = l.derive(f, path, now); err != nil {
+			return err
+		}
+		if err := fn(&b); err != nil {
+			return err
+		}
+		return l.appendDiff(f, kind, b, now)
+	})
+	return b, err
+}
+
+// derive does the work of readBoard under the lock. The board file at
+// path is logged as a change made outside the board when it changed since
+// the log did, e.g. by hand, by git, or by an older gotask, or when the
+// log has no board yet. Otherwise the log is what counts, since the file
+// may be behind a change that is yet to be written to it.
+func (l *EventLog) derive(f *os.File, path string, now time.Time) (board.Board, bool, error) {
+	info, err := os.Stat(path)
+	missing := os.IsNotExist(err)
+	if err != nil && !missing {
+		return board.Board{}, false, err
+	}
+	file, err := Read(path)
+	if err != nil {
+		return board.Board{}, false, err
+	}
+	logged := len(l.slots) > 0
+	if !logged || !missing && info.ModTime().After(l.modTime) {
+		if err := l.appendDiff(f, EventExternal, file, now); err != nil {
+			return board.Board{}, false, err
+		}
+	}
+	// A missing file is written again from the log, unless there's no
+	// board yet, which is left to the first change
+	b, err := slotsBoard(l.slots)
+	if err != nil || missing {
+		return b, missing && logged, err
+	}
+	have, err := Encode(file)
+	if err != nil {
+		return b, false, err
+	}
+	want, err := Encode(b)
+	return b, !bytes.Equal(have, want), err
+}
+
+// Step undoes the last change, or makes the last undone change again for
+// EventRedo, logging that it did. It returns the board as it is now and
+// the change it stepped over.
+func (l *EventLog) Step(kind string, now time.Time) (board.Board, Event, error) {
+	var b board.Board
+	var last Event
+	err := l.withLock(func(f *os.File) error {
+		stack := l.undo
+		if kind == EventRedo {
+			stack = l.redo
+		}
+		if len(stack) == 0 {
+			return ErrNoStep
+		}
+		var err error
+		if last, err = l.full(f, stack[len(stack)-1]); err != nil {
+			return err
+		}
+		changes := last.Changes
+		if kind == EventUndo {
+			changes = invert(changes)
+		}
+		if changes, err = l.rebase(changes); err != nil {
+			return err
+		}
+		if err := l.append(f, Event{At: now, Kind: kind, Target: last.Seq, Summary: last.Summary, Changes: changes}); err != nil {
+			return err
+		}
+		b, err = slotsBoard(l.slots)
+		return err
+	})
+	return b, last, err
+}
+
+// full returns an event with its changes, reading them back from the log
+// file f if they aren't held in memory
+func (l *EventLog) full(f *os.File, e Event) (Event, error) {
+	if e.Changes != nil {
+		return e, nil
+	}
+	if f == nil {
+		return e, fmt.Errorf("event %d is not in memory", e.Seq)
+	}
+	line, err := bufio.NewReader(io.NewSectionReader(f, e.offset, l.offset-e.offset)).ReadBytes('\n')
+	if err != nil {
+		return e, fmt.Errorf("event %d: %w", e.Seq, err)
+	}
+	var full Event
+	if err := json.Unmarshal(line, &full); err != nil || full.Seq != e.Seq {
+		return e, fmt.Errorf("event %d is not where the log had it", e.Seq)
+	}
+	full.offset = e.offset
+	return full, nil
+}
+
+// LogEntries reads every event in the log file at path, without their
+// changes
+func LogEntries(path string) ([]Event, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var entries []Event
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		var e Event
+		if json.Unmarshal(line, &e) == nil {
+			e.Changes = nil
+			entries = append(entries, e)
+		}
+		if err == io.EOF {
+			return entries, nil
+		} else if err != nil {
+			return entries, err
+		}
+	}
+}
+
+// Board is the board derived from the log
+func (l *EventLog) Board() (board.Board, error) {
+	return slotsBoard(l.slots)
+}
+
+// Activity lists the undos, redos, and outside changes in the log for the
+// activity panel, which gets the rest from the tasks' histories
+func (l *EventLog) Activity() []ActivityEntry {
+	var entries []ActivityEntry
+	for _, e := range l.Entries {
+		switch e.Kind {
+		case EventUndo:
+			entries = append(entries, ActivityEntry{At: e.At, What: "undid: " + e.Summary})
+		case EventRedo:
+			entries = append(entries, ActivityEntry{At: e.At, What: "redid: " + e.Summary})
+		case EventExternal:
+			if e.Seq > 1 {
+				entries = append(entries, ActivityEntry{At: e.At, What: "changed outside the board: " + e.Summary})
+			}
+		}
+	}
+	return entries
+}
@@ -0,0 +1,236 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotask/internal/board"
+)
+
+func TestUndoLeavesExternalChange(t *testing.T) {
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	l, err := OpenEventLog("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := board.New()
+	b.AddTask(0, board.Task{ID: 1, Title: "Ours", CreatedAt: now})
+	if err := l.Record(EventChange, b, now); err != nil {
+		t.Fatal(err)
+	}
+
+	// The API adds a task to the same column, then the board undoes its own
+	b.AddTask(0, board.Task{ID: 2, Title: "From the API", CreatedAt: now})
+	if err := l.Record(EventExternal, b, now); err != nil {
+		t.Fatal(err)
+	}
+	undone, last, err := l.Step(EventUndo, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last.Kind != EventChange {
+		t.Errorf("undid a %q event, want %q", last.Kind, EventChange)
+	}
+	if _, _, ok := undone.FindTask(1); ok {
+		t.Error("undo kept #1")
+	}
+	if _, _, ok := undone.FindTask(2); !ok {
+		t.Fatal("undo took back the change made outside the board")
+	}
+
+	redone, _, err := l.Step(EventRedo, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var titles []string
+	for _, task := range redone.Columns[0].Tasks {
+		titles = append(titles, task.Title)
+	}
+	if len(titles) != 2 || titles[0] != "Ours" || titles[1] != "From the API" {
+		t.Errorf("after redo To Do holds %q, want [Ours From the API]", titles)
+	}
+}
+
+// titles lists the titles of the tasks in a column, in order
+func titles(col board.Column) []string {
+	var titles []string
+	for _, task := range col.Tasks {
+		titles = append(titles, task.Title)
+	}
+	return titles
+}
+
+func TestEventsShareOneSequence(t *testing.T) {
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "board.events.jsonl")
+
+	// The board and the API server, each with the log open
+	tui, err := OpenEventLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := OpenEventLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := board.New()
+	for id := 1; id <= 4; id++ {
+		l := tui
+		if id%2 == 0 {
+			l = server
+		}
+		b.AddTask(0, board.Task{ID: id, Title: "Task", CreatedAt: now})
+		if err := l.Record(EventChange, b, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := LogEntries(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, e := range entries {
+		if e.Seq != i+1 {
+			t.Errorf("event %d is numbered %d, want %d", i, e.Seq, i+1)
+		}
+	}
+	if len(entries) != 4 {
+		t.Errorf("the log has %d events, want 4", len(entries))
+	}
+
+	// Each undoes the last change, whoever made it
+	undone, last, err := tui.Step(EventUndo, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last.Seq != 4 || len(undone.Columns[0].Tasks) != 3 {
+		t.Errorf("undo stepped over event %d leaving %d tasks, want event 4 leaving 3", last.Seq, len(undone.Columns[0].Tasks))
+	}
+	if _, last, err = server.Step(EventUndo, now); err != nil || last.Seq != 3 {
+		t.Errorf("the second undo stepped over event %d (%v), want event 3", last.Seq, err)
+	}
+}
+
+func TestSnapshotMatchesReplay(t *testing.T) {
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "board.events.jsonl")
+	l, err := OpenEventLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := board.New()
+	b.AddTask(0, board.Task{ID: 1, Title: "Count", CreatedAt: now})
+	for i := 0; i <= snapshotEvery; i++ {
+		b.Columns[0].Tasks[0].Pomodoros = i
+		if err := l.Record(EventChange, b, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(snapshotPath(path)); err != nil {
+		t.Fatalf("no snapshot after %d events: %v", snapshotEvery+1, err)
+	}
+
+	fromSnapshot, err := OpenEventLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromSnapshot.fresh >= snapshotEvery {
+		t.Errorf("reopening replayed %d events, want the snapshot used", fromSnapshot.fresh)
+	}
+	if err := os.Remove(snapshotPath(path)); err != nil {
+		t.Fatal(err)
+	}
+	replayed, err := OpenEventLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range []*EventLog{fromSnapshot, replayed} {
+		got, err := l.Board()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := got.Columns[0].Tasks[0].Pomodoros; n != snapshotEvery {
+			t.Errorf("the board has %d pomodoros, want %d", n, snapshotEvery)
+		}
+	}
+
+	// Changes from before the snapshot are read back to undo them
+	undone, _, err := fromSnapshot.Step(EventUndo, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := undone.Columns[0].Tasks[0].Pomodoros; n != snapshotEvery-1 {
+		t.Errorf("after undo the board has %d pomodoros, want %d", n, snapshotEvery-1)
+	}
+}
+
+func TestBoardDerivedFromLog(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "board.json")
+	f := NewBoardFile(path)
+	if err := f.Change(func(b *board.Board) error {
+		b.AddTask(0, board.Task{ID: 1, Title: "Buy milk"})
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without the board file, the board comes from the log
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	b, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := titles(b.Columns[0]); len(got) != 1 || got[0] != "Buy milk" {
+		t.Errorf("To Do holds %q, want [Buy milk]", got)
+	}
+
+	// A board file behind the log is ignored
+	older := board.New()
+	data, err := Encode(older)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	long := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, long, long); err != nil {
+		t.Fatal(err)
+	}
+	if b, err = Load(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := titles(b.Columns[0]); len(got) != 1 {
+		t.Errorf("with the file behind the log To Do holds %q, want [Buy milk]", got)
+	}
+
+	// A board file changed since the log is taken into it
+	older.AddTask(0, board.Task{ID: 2, Title: "Edited by hand"})
+	if data, err = Encode(older); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Change(func(b *board.Board) error {
+		b.AddTask(0, board.Task{ID: 3, Title: "From the API"})
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if b, err = Load(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := titles(b.Columns[0]); len(got) != 2 || got[0] != "Edited by hand" || got[1] != "From the API" {
+		t.Errorf("To Do holds %q, want [Edited by hand From the API]", got)
+	}
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+	"gotask/internal/hooks"
+)
+
+// ErrBoardChanged is returned when the board was changed by someone else
+// since it was last read
+var ErrBoardChanged = errors.New("the board has changed")
+
+// WatchInterval is how often WatchBoard looks for changes to the board file
+const WatchInterval = time.Second
+
+// ErrReadOnly is returned for changes to a board opened read-only
+var ErrReadOnly = errors.New("the board is read-only")
+
+// BoardFile gives commands that run outside the board, such as the HTTP
+// API, access to the board. The board is derived from its change log
+// every time, so that changes made elsewhere are picked up, and the board
+// file is written after every change.
+type BoardFile struct {
+	mu       sync.Mutex
+	Path     string
+	saver    *Writer
+	seq      int
+	hooks    hooks.Runner
+	events   *EventLog // opened with the first use
+	readOnly bool      // refuse changes
+}
+
+func NewBoardFile(path string) *BoardFile {
+	cfg, _ := config.Load()
+	return &BoardFile{Path: path, saver: &Writer{}, hooks: hooks.Configured(), readOnly: cfg.ReadOnly}
+}
+
+// Read loads the board
+func (f *BoardFile) Read() (board.Board, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	board, _, err := f.openEvents().ReadBoard(f.Path, time.Now())
+	return board, err
+}
+
+// Load reads the board at path for commands that only look at it,
+// derived from its change log like any other
+func Load(path string) (board.Board, error) {
+	return (&BoardFile{Path: path, readOnly: true}).Read()
+}
+
+// openEvents opens the change log the board is derived from. Read-only
+// boards read it afresh every time and keep what they take into it from
+// the board file in memory. Lines of the log that can't be read are
+// logged and skipped.
+func (f *BoardFile) openEvents() *EventLog {
+	if f.events != nil && !f.readOnly {
+		return f.events
+	}
+	var err error
+	if f.events, err = OpenEventLog(EventLogPath(f.Path)); err != nil {
+		log.Print(err)
+	}
+	if f.readOnly {
+		f.events.Path = ""
+	}
+	return f.events
+}
+
+// Change loads the board, lets fn change it, and saves it unless fn fails.
+// Hooks for the changes run once the board is saved; their failures are
+// logged rather than returned, since the change itself went through.
+func (f *BoardFile) Change(fn func(b *board.Board) error) error {
+	events, err := f.apply(fn)
+	if err != nil {
+		return err
+	}
+	if err := f.hooks.Run(events); err != nil {
+		log.Print(err)
+	}
+	return nil
+}
+
+// apply does the work of change under the lock, returning the events to
+// run hooks for
+func (f *BoardFile) apply(fn func(b *board.Board) error) ([]hooks.Event, error) {
+	if f.readOnly {
+		return nil, ErrReadOnly
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var before board.Board
+	// Logged as made outside the board, so that undo there leaves it be
+	after, err := f.openEvents().update(f.Path, EventExternal, time.Now(), func(b *board.Board) error {
+		if f.hooks.Enabled() {
+			// A copy, since fn changes the tasks' slices in place
+			data, err := Encode(*b)
+			if err != nil {
+				return err
+			}
+			if err := json.Unmarshal(data, &before); err != nil {
+				return err
+			}
+		}
+		if err := fn(b); err != nil {
+			return err
+		}
+		b.RecordFlow(time.Now())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, err := Encode(after)
+	if err != nil {
+		return nil, err
+	}
+	f.seq++
+	if err := f.saver.Write(f.Path, data, f.seq); err != nil {
+		return nil, err
+	}
+	if !f.hooks.Enabled() {
+		return nil, nil
+	}
+	return f.hooks.Events(board.Diff(&before, &after), &after), nil
+}
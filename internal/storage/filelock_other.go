@@ -0,0 +1,11 @@
+//go:build !unix && !windows
+
+package storage
+
+import "os"
+
+// lockFile does nothing where there are no file locks; gotask processes
+// sharing a board there may number events the same
+func lockFile(f *os.File) error { return nil }
+
+func unlockFile(f *os.File) error { return nil }
@@ -0,0 +1,252 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gotask/internal/board"
+)
+
+// The board file is a state that merges: each task carries its own history,
+// whose last entry dates its latest change, and deleted tasks leave a date
+// in board.Board.Deleted. Two copies of the board that were changed apart,
+// such as the conflicted copies Syncthing and Dropbox make, are merged task
+// by task, keeping the later version of each.
+
+// MergeStats counts what merging another copy of the board changed
+type MergeStats struct {
+	added, updated, removed, renumbered int
+}
+
+func (s MergeStats) String() string {
+	var parts []string
+	add := func(n int, what string) {
+		if n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, what))
+		}
+	}
+	add(s.added, "added")
+	add(s.updated, "updated")
+	add(s.removed, "removed")
+	add(s.renumbered, "renumbered")
+	if len(parts) == 0 {
+		return "no changes"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// taskState is where one copy of the board has a task
+type taskState struct {
+	task     board.Task
+	col      int // -1 when archived
+	archived board.ArchivedTask
+}
+
+func (s taskState) updated() time.Time {
+	return s.task.LastUpdated()
+}
+
+// taskStates indexes the tasks on the board and in the archive by ID
+func taskStates(b *board.Board) map[int]taskState {
+	states := make(map[int]taskState)
+	for i, col := range b.Columns {
+		for _, task := range col.Tasks {
+			states[task.ID] = taskState{task: task, col: i}
+		}
+	}
+	for _, a := range b.Archive {
+		states[a.ID] = taskState{task: a.Task, col: -1, archived: a}
+	}
+	return states
+}
+
+// Merge folds another copy of the board into this one. Where both copies
+// have a task, the one changed last wins; a task one copy deleted goes
+// unless the other changed it afterwards. Tasks the copies added
+// separately under the same ID keep theirs, and the other's are given new
+// IDs. Columns are matched by position.
+func Merge(b *board.Board, other board.Board) MergeStats {
+	var stats MergeStats
+	ours, theirs := taskStates(b), taskStates(&other)
+
+	// Give tasks created separately under the same ID their own IDs
+	next := max(b.MaxID(), other.MaxID())
+	renumber := func(old int) {
+		next++
+		for i := range other.Columns {
+			for j := range other.Columns[i].Tasks {
+				if other.Columns[i].Tasks[j].ID == old {
+					other.Columns[i].Tasks[j].ID = next
+				}
+			}
+		}
+		for i := range other.Archive {
+			if other.Archive[i].ID == old {
+				other.Archive[i].ID = next
+			}
+		}
+		stats.renumbered++
+	}
+	for id, t := range theirs {
+		if o, ok := ours[id]; ok && !o.task.CreatedAt.Equal(t.task.CreatedAt) {
+			renumber(id)
+		}
+	}
+	theirs = taskStates(&other)
+
+	// Pick the version of each task to keep
+	deleted := func(tombstones map[int]time.Time, states map[int]taskState, id int, since time.Time) bool {
+		at, ok := tombstones[id]
+		_, present := states[id]
+		return ok && !present && !at.Before(since)
+	}
+	winners := make(map[int]taskState)
+	fromTheirs := make(map[int]bool)
+	for id, o := range ours {
+		t, ok := theirs[id]
+		switch {
+		case !ok && deleted(other.Deleted, theirs, id, o.updated()):
+			stats.removed++
+		case !ok || !t.updated().After(o.updated()):
+			winners[id] = o
+		default:
+			winners[id], fromTheirs[id] = t, true
+			stats.updated++
+		}
+	}
+	for id, t := range theirs {
+		if _, ok := ours[id]; ok || deleted(b.Deleted, ours, id, t.updated()) {
+			continue
+		}
+		winners[id], fromTheirs[id] = t, true
+		stats.added++
+	}
+
+	// Lay the columns out in our order, slotting tasks placed by the other
+	// copy in after the task they follow there
+	for i := range b.Columns {
+		var tasks []board.Task
+		placed := make(map[int]bool)
+		for _, task := range b.Columns[i].Tasks {
+			if w, ok := winners[task.ID]; ok && w.col == i && !fromTheirs[task.ID] {
+				tasks = append(tasks, w.task)
+				placed[task.ID] = true
+			}
+		}
+		if i < len(other.Columns) {
+			at := 0
+			for _, task := range other.Columns[i].Tasks {
+				if placed[task.ID] {
+					at = indexOfTask(tasks, task.ID) + 1
+					continue
+				}
+				if w, ok := winners[task.ID]; ok && w.col == i && fromTheirs[task.ID] {
+					tasks = append(tasks[:at], append([]board.Task{w.task}, tasks[at:]...)...)
+					placed[task.ID] = true
+					at++
+				}
+			}
+		}
+		if tasks == nil {
+			tasks = []board.Task{}
+		}
+		b.Columns[i].Tasks = tasks
+	}
+	// Tasks the other copy has in a column this one lacks go in the last
+	for _, w := range winners {
+		if w.col >= len(b.Columns) {
+			last := len(b.Columns) - 1
+			b.Columns[last].Tasks = append(b.Columns[last].Tasks, w.task)
+		}
+	}
+
+	var archive []board.ArchivedTask
+	for _, w := range winners {
+		if w.col < 0 {
+			archive = append(archive, w.archived)
+		}
+	}
+	sort.SliceStable(archive, func(i, j int) bool { return archive[i].ArchivedAt.Before(archive[j].ArchivedAt) })
+	b.Archive = archive
+
+	for id, at := range other.Deleted {
+		if b.Deleted == nil {
+			b.Deleted = make(map[int]time.Time)
+		}
+		if at.After(b.Deleted[id]) {
+			b.Deleted[id] = at
+		}
+	}
+	for id := range winners {
+		delete(b.Deleted, id)
+	}
+	b.MergeFlow(other.Flow)
+	return stats
+}
+
+func indexOfTask(tasks []board.Task, id int) int {
+	for i, task := range tasks {
+		if task.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// ConflictCopies finds the conflicted copies of the board that file sync
+// tools leave beside it: Syncthing's name.sync-conflict-*.json and
+// Dropbox's "name (... conflicted copy ...).json"
+func ConflictCopies(path string) []string {
+	dir, file := filepath.Split(path)
+	ext := filepath.Ext(file)
+	base := strings.TrimSuffix(file, ext)
+	var found []string
+	for _, pattern := range []string{
+		base + ".sync-conflict-*" + ext,
+		base + " (*conflicted copy*)" + ext,
+	} {
+		matches, _ := filepath.Glob(filepath.Join(dir, escapeGlob(pattern)))
+		found = append(found, matches...)
+	}
+	sort.Strings(found)
+	return found
+}
+
+// escapeGlob escapes the characters of a pattern that Glob would read
+// specially, other than "*"
+func escapeGlob(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "[", `\[`, "?", `\?`).Replace(s)
+}
+
+// MergeFiles merges copies of the board into the board at path, deleting
+// each copy once it is merged in unless keep is set
+func MergeFiles(path string, copies []string, keep bool) (MergeStats, error) {
+	var total MergeStats
+	for _, file := range copies {
+		other, err := Read(file)
+		if err != nil {
+			return total, fmt.Errorf("%s: %w", file, err)
+		}
+		err = NewBoardFile(path).Change(func(b *board.Board) error {
+			s := Merge(b, other)
+			total.added += s.added
+			total.updated += s.updated
+			total.removed += s.removed
+			total.renumbered += s.renumbered
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+		if !keep {
+			if err := os.Remove(file); err != nil {
+				return total, err
+			}
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"gotask/internal/board"
+)
+
+func TestMergeKeepsDeletedTaskDeleted(t *testing.T) {
+	created := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	copyWithOld := func() board.Board {
+		b := board.New()
+		b.AddTask(0, board.Task{ID: 1, Title: "Old", CreatedAt: created})
+		return b
+	}
+	older := copyWithOld()
+
+	// Delete the highest-numbered task, then add another
+	ours := copyWithOld()
+	ours.DeleteTask(1)
+	next := ours.MaxID() + 1
+	if next != 2 {
+		t.Errorf("after deleting #1 the next ID is %d, want 2", next)
+	}
+	ours.AddTask(0, board.Task{ID: next, Title: "New", CreatedAt: created.Add(time.Hour)})
+
+	stats := Merge(&ours, older)
+	if stats.added != 0 || stats.renumbered != 0 {
+		t.Errorf("merge = %s, want nothing added or renumbered", stats)
+	}
+	if _, _, ok := ours.FindTask(1); ok {
+		t.Error("the deleted task #1 came back")
+	}
+	tasks := ours.Columns[0].Tasks
+	if len(tasks) != 1 || tasks[0].Title != "New" {
+		t.Errorf("To Do = %+v, want only the new task", tasks)
+	}
+}
@@ -0,0 +1,94 @@
+// Package storage keeps gotask boards on disk as indented JSON. Writes
+// replace the file atomically, so a reader never sees half a board.
+//
+// Every change to a board is also appended to a change log beside it,
+// which the board is derived from and which undo, redo and the activity
+// feed are read from. The package also merges the conflicted copies that
+// file sync tools leave behind.
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gotask/internal/board"
+)
+
+// Read loads the board saved at path. A missing file is a new board.
+func Read(path string) (board.Board, error) {
+	b := board.New()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return b, nil
+		}
+		return b, err
+	}
+	if err := json.Unmarshal(data, &b); err != nil {
+		return b, err
+	}
+	return b, nil
+}
+
+// Encode serializes the board the way it is stored on disk
+func Encode(b board.Board) ([]byte, error) {
+	return json.MarshalIndent(b, "", "  ")
+}
+
+// Stamp tells whether a file has changed since it was last looked at
+type Stamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Stat stamps the file at path. A missing file has the zero Stamp.
+func Stat(path string) Stamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Stamp{}
+	}
+	return Stamp{info.ModTime(), info.Size()}
+}
+
+// Writer writes board snapshots to disk, possibly from several goroutines.
+// Each snapshot carries a sequence number so a slow write never replaces
+// newer data.
+type Writer struct {
+	mu      sync.Mutex
+	written int // sequence number of the snapshot on disk
+}
+
+// Write stores the snapshot unless a newer one was already written. The
+// file is replaced atomically so an interrupted write can't corrupt it.
+func (w *Writer) Write(path string, data []byte, seq int) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if seq <= w.written {
+		return nil
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	w.written = seq
+	return nil
+}
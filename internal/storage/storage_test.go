@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotask/internal/board"
+)
+
+func TestWriteReplacesFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "board.json")
+	if err := os.WriteFile(path, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var w Writer
+	if err := w.Write(path, []byte("new"), 1); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "new" {
+		t.Errorf("file holds %q, want %q", data, "new")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Errorf("directory holds %v, want only board.json", names)
+	}
+	if info, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	} else if info.Mode().Perm() != 0644 {
+		t.Errorf("file mode is %v, want 0644", info.Mode().Perm())
+	}
+}
+
+func TestWriteKeepsNewerSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "board.json")
+	var w Writer
+	if err := w.Write(path, []byte("second"), 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(path, []byte("first"), 1); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "second" {
+		t.Errorf("file holds %q, want the newer snapshot", data)
+	}
+}
+
+func TestStat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "board.json")
+	if Stat(path) != (Stamp{}) {
+		t.Error("a missing file has a non-zero stamp")
+	}
+
+	var w Writer
+	if err := w.Write(path, []byte("one"), 1); err != nil {
+		t.Fatal(err)
+	}
+	first := Stat(path)
+	if first == (Stamp{}) {
+		t.Fatal("a written file has the zero stamp")
+	}
+	if Stat(path) != first {
+		t.Error("the stamp changed without a write")
+	}
+	if err := w.Write(path, []byte("three"), 2); err != nil {
+		t.Fatal(err)
+	}
+	if Stat(path) == first {
+		t.Error("the stamp didn't change after a write")
+	}
+}
+
+func TestReadEncode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "board.json")
+	b, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Columns) != len(board.DefaultColumns) {
+		t.Fatalf("a missing file reads as %d columns, want a new board", len(b.Columns))
+	}
+
+	b.AddTask(0, board.Task{ID: 1, Title: "Buy milk"})
+	data, err := Encode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var w Writer
+	if err := w.Write(path, data, 1); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := got.FindTask(1); !ok || got.Columns[0].Tasks[0].Title != "Buy milk" {
+		t.Errorf("read back %+v, want Buy milk in To Do", got.Columns[0].Tasks)
+	}
+}
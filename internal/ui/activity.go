@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"gotask/internal/board"
+	"gotask/internal/storage"
+)
+
+// activity gathers the events in the histories of the tasks on the board
+// and in the archive, the deletions, and the undos and outside changes in
+// the change log, most recent first
+func activity(b *board.Board, log *storage.EventLog) []storage.ActivityEntry {
+	var entries []storage.ActivityEntry
+	add := func(task board.Task) {
+		for _, e := range task.History {
+			entries = append(entries, storage.ActivityEntry{At: e.At, ID: task.ID, Title: task.Title, What: board.DescribeEvent(e)})
+		}
+	}
+	for _, col := range b.Columns {
+		for _, task := range col.Tasks {
+			add(task)
+		}
+	}
+	for _, task := range b.Archive {
+		add(task.Task)
+	}
+	for id, at := range b.Deleted {
+		entries = append(entries, storage.ActivityEntry{At: at, ID: id, What: "deleted"})
+	}
+	entries = append(entries, log.Activity()...)
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].At.After(entries[j].At) })
+	if len(entries) > storage.MaxActivity {
+		entries = entries[:storage.MaxActivity]
+	}
+	return entries
+}
+
+// openActivity shows the activity panel
+func (m *model) openActivity() {
+	m.showActivity = true
+	m.activityView = viewport.New(max(20, m.width-6), max(5, m.height-4))
+	m.activityView.SetContent(m.renderActivity(m.activityView.Width, time.Now()))
+}
+
+// renderActivity lists the board's recent events with how long ago they
+// happened
+func (m model) renderActivity(width int, now time.Time) string {
+	entries := activity(&m.board, m.events)
+	if len(entries) == 0 {
+		return helpStyle.Render("No activity yet")
+	}
+	const whenWidth = 10
+	lines := make([]string, len(entries))
+	for i, e := range entries {
+		when := relativeTime(e.At, now)
+		what := e.What
+		if e.ID != 0 { // changes to the board as a whole have no task
+			task := taskIDStyle.Render(fmt.Sprintf("#%d", e.ID))
+			if e.Title != "" {
+				task += fmt.Sprintf(" %q", e.Title)
+			}
+			what = task + " " + what
+		}
+		line := helpStyle.Render(when+strings.Repeat(" ", max(1, whenWidth-len(when)))) + what
+		lines[i] = ansi.Truncate(line, width, "…")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// updateActivity handles key presses while the activity panel is open
+func (m model) updateActivity(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Activity, m.keys.Cancel, m.keys.Quit):
+		m.showActivity = false
+	case key.Matches(msg, m.keys.Up):
+		m.activityView.LineUp(1)
+	case key.Matches(msg, m.keys.Down):
+		m.activityView.LineDown(1)
+	case key.Matches(msg, m.keys.HalfPageUp):
+		m.activityView.HalfViewUp()
+	case key.Matches(msg, m.keys.HalfPageDown):
+		m.activityView.HalfViewDown()
+	}
+	return m, nil
+}
+
+// activityViewBox renders the activity feed as a full-screen panel
+func (m model) activityViewBox() string {
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(highlight).
+		Padding(0, 1).
+		Render(m.activityView.View())
+	return box + "\n" + m.renderHints()
+}
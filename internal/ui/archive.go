@@ -0,0 +1,230 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"gotask/internal/board"
+)
+
+// archiveWindows are the periods the archive explorer can show, in days
+// archived ago; 0 shows the whole archive
+var archiveWindows = []int{0, 7, 30, 90, 365}
+
+// archiveExplorer browses the archive, newest first, narrowed down by a
+// search query and how long ago tasks were archived
+type archiveExplorer struct {
+	query     textinput.Model
+	searching bool // whether the query is being typed
+	window    int  // index into archiveWindows
+	cursor    int  // position in the matching tasks
+	offset    int  // first matching task shown
+}
+
+// openArchive shows the archive explorer
+func (m *model) openArchive() {
+	if len(m.board.Archive) == 0 {
+		m.notify("The archive is empty")
+		return
+	}
+	q := textinput.New()
+	q.Prompt = "/"
+	q.Placeholder = "search the archive..."
+	if len(m.archiveHits) > 0 {
+		q.SetValue(m.searchQuery)
+	}
+	m.archive = &archiveExplorer{query: q}
+}
+
+// maxArchiveHits is how many archived matches the search panel lists
+const maxArchiveHits = 6
+
+// overlayArchiveHits floats the archived tasks matching the search over
+// the bottom left of the board, clear of the toasts, dimmed to set them
+// apart from the board's
+func (m model) overlayArchiveHits(view string) string {
+	now := time.Now()
+	width := min(previewWidth, m.width/2)
+	lines := []string{helpStyle.Render(fmt.Sprintf("In the archive (%d)", len(m.archiveHits)))}
+	for i, hit := range m.archiveHits {
+		if i == maxArchiveHits {
+			lines = append(lines, helpStyle.Render(fmt.Sprintf("…and %d more", len(m.archiveHits)-i)))
+			break
+		}
+		task := m.board.Archive[hit]
+		done := "  "
+		if task.CompletedAt != nil {
+			done = "✓ "
+		}
+		meta := fmt.Sprintf("  %s · %s", task.Column, relativeTime(task.ArchivedAt, now))
+		title := ansi.Truncate(fmt.Sprintf("%s#%d %s", done, task.ID, task.Title), max(10, width-lipgloss.Width(meta)), "…")
+		lines = append(lines, dimText(title+meta))
+	}
+	lines = append(lines, helpStyle.Render(m.keys.Archived.Help().Key+" to browse them"))
+	popup := previewStyle.Copy().BorderForeground(subtle).Render(strings.Join(lines, "\n"))
+	return placeOverlay(1, m.height-lipgloss.Height(popup)-2, popup, view)
+}
+
+// matches lists the positions in the board's archive of the tasks the
+// explorer shows
+func (e *archiveExplorer) matches(b *board.Board, now time.Time) []int {
+	var since time.Time
+	if days := archiveWindows[e.window]; days > 0 {
+		since = board.DayOf(now).AddDate(0, 0, 1-days)
+	}
+	return searchArchive(b, e.query.Value(), since)
+}
+
+// searchArchive lists the positions in the archive of the tasks archived
+// since the given time whose title, description, or a tag matches the
+// query, most recently archived first
+func searchArchive(b *board.Board, query string, since time.Time) []int {
+	query = strings.TrimSpace(query)
+	var found []int
+	for i, task := range b.Archive {
+		if task.ArchivedAt.Before(since) {
+			continue
+		}
+		if query != "" && !board.MatchTask(query, task.Task) && !board.HasTag(task.Task, strings.TrimPrefix(query, "#")) {
+			continue
+		}
+		found = append(found, i)
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return b.Archive[found[i]].ArchivedAt.After(b.Archive[found[j]].ArchivedAt)
+	})
+	return found
+}
+
+// updateArchive handles key presses in the archive explorer
+func (m model) updateArchive(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	e := m.archive
+	if e.searching {
+		switch {
+		case key.Matches(msg, m.keys.Submit):
+			e.searching = false
+			e.query.Blur()
+		case key.Matches(msg, m.keys.Cancel):
+			e.searching = false
+			e.query.Blur()
+			e.query.SetValue("")
+			e.cursor, e.offset = 0, 0
+		default:
+			var cmd tea.Cmd
+			e.query, cmd = e.query.Update(msg)
+			e.cursor, e.offset = 0, 0
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	found := e.matches(&m.board, time.Now())
+	switch {
+	case key.Matches(msg, m.keys.Archived, m.keys.Cancel, m.keys.Quit):
+		m.archive = nil
+	case key.Matches(msg, m.keys.Search):
+		e.searching = true
+		return m, e.query.Focus()
+	case key.Matches(msg, m.keys.Up):
+		e.cursor = max(0, e.cursor-1)
+	case key.Matches(msg, m.keys.Down):
+		e.cursor = max(0, min(len(found)-1, e.cursor+1))
+	case key.Matches(msg, m.keys.Top):
+		e.cursor = 0
+	case key.Matches(msg, m.keys.Bottom):
+		e.cursor = max(0, len(found)-1)
+	case key.Matches(msg, m.keys.PrevPeriod):
+		e.window = max(0, e.window-1)
+		e.cursor, e.offset = 0, 0
+	case key.Matches(msg, m.keys.NextPeriod):
+		e.window = min(len(archiveWindows)-1, e.window+1)
+		e.cursor, e.offset = 0, 0
+	case key.Matches(msg, m.keys.Restore):
+		if e.cursor >= len(found) {
+			return m, nil
+		}
+		id := m.board.Archive[found[e.cursor]].ID
+		column := m.board.RestoreTask(found[e.cursor])
+		e.cursor = max(0, min(len(found)-2, e.cursor))
+		if m.searchQuery != "" {
+			m.runSearch()
+		}
+		m.refreshViewports()
+		m.save()
+		m.notify("Restored #%d to %s", id, column)
+	}
+	return m, nil
+}
+
+// describeWindow names an archive explorer period, e.g. "last 30 days"
+func describeWindow(days int) string {
+	if days == 0 {
+		return "all time"
+	}
+	return fmt.Sprintf("last %d days", days)
+}
+
+// archiveViewBox renders the archive explorer as a full-screen panel: the
+// search and period, then the matching tasks with the selected one's
+// description underneath
+func (m model) archiveViewBox() string {
+	e := m.archive
+	now := time.Now()
+	width := max(20, m.width-6)
+	height := max(5, m.height-4)
+	label := lipgloss.NewStyle().Foreground(highlight).Bold(true)
+
+	found := e.matches(&m.board, now)
+	heading := fmt.Sprintf("Archive · %d of %d · %s", len(found), len(m.board.Archive), describeWindow(archiveWindows[e.window]))
+	lines := []string{label.Render(heading)}
+	if e.searching || e.query.Value() != "" {
+		lines = append(lines, e.query.View())
+	}
+	lines = append(lines, "")
+
+	// Keep the cursor in view of the rows left for the list
+	rows := max(1, height-len(lines)-3)
+	if e.cursor < e.offset {
+		e.offset = e.cursor
+	} else if e.cursor >= e.offset+rows {
+		e.offset = e.cursor - rows + 1
+	}
+	if len(found) == 0 {
+		lines = append(lines, helpStyle.Render("No archived tasks match"))
+	}
+	for i := e.offset; i < len(found) && i < e.offset+rows; i++ {
+		task := m.board.Archive[found[i]]
+		meta := helpStyle.Render(fmt.Sprintf("  %s · archived %s", task.Column, relativeTime(task.ArchivedAt, now)))
+		title := fmt.Sprintf("#%d %s%s", task.ID, m.display.iconPrefix(task.Task), task.Title)
+		prefix := "  "
+		if i == e.cursor {
+			prefix = markedStyle.Render("❯ ")
+			title = lipgloss.NewStyle().Bold(true).Render(title)
+		}
+		title = ansi.Truncate(prefix+title, max(10, width-lipgloss.Width(meta)), "…")
+		lines = append(lines, title+meta)
+	}
+	if e.cursor < len(found) {
+		if desc := m.board.Archive[found[e.cursor]].Description; desc != "" {
+			first, _, _ := strings.Cut(desc, "\n")
+			lines = append(lines, "", helpStyle.Render(ansi.Truncate(first, width, "…")))
+		}
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(highlight).
+		Padding(0, 1).
+		Width(width + 2).
+		Height(height).
+		Render(strings.Join(lines, "\n"))
+	return box + "\n" + m.renderHints()
+}
@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"hash/fnv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"gotask/internal/board"
+)
+
+// dueBadge renders a short badge such as "due 2d" or "overdue 3d" for a
+// task's due date, more urgent colors the closer it gets. Finished tasks
+// and tasks without a due date have no badge.
+func dueBadge(task board.Task, done bool, now time.Time) string {
+	if task.Due == nil || done {
+		return ""
+	}
+	days := board.DaysUntil(*task.Due, now)
+	switch {
+	case days < 0:
+		return overdueStyle.Render("overdue " + board.ShortDuration(-days))
+	case days == 0:
+		return dueTodayStyle.Render("due today")
+	case days <= board.DueSoonDays:
+		return dueSoonStyle.Render("due " + board.ShortDuration(days))
+	default:
+		return dueStyle.Render("due " + board.ShortDuration(days))
+	}
+}
+
+// priorityBadge renders the task's priority mark, or "" for none
+func (d displaySettings) priorityBadge(p board.Priority) string {
+	if d.priority == nil || p <= board.PriorityNone || int(p) >= len(d.priority) {
+		return ""
+	}
+	return priorityStyles[p].Render(d.priority[p])
+}
+
+// iconPrefix is the task's icon and a space, or nothing when it has no
+// icon or icons are turned off
+func (d displaySettings) iconPrefix(task board.Task) string {
+	if !d.showIcons {
+		return ""
+	}
+	return board.IconPrefix(task)
+}
+
+// tagColor picks a tag's color: the configured one, or else a theme color
+// chosen by its name so a tag looks the same on every card
+func (d displaySettings) tagColor(tag string) lipgloss.TerminalColor {
+	tag = strings.ToLower(tag)
+	if color, ok := d.tagColors[tag]; ok {
+		return color
+	}
+	palette := []lipgloss.TerminalColor{highlight, special, todoColor, inProgColor, doneColor, searchMatchColor}
+	h := fnv.New32a()
+	h.Write([]byte(tag))
+	return palette[h.Sum32()%uint32(len(palette))]
+}
+
+// tagChips renders a task's tags, or nothing while tags are hidden
+func (d displaySettings) tagChips(task board.Task) []string {
+	if !d.showTags {
+		return nil
+	}
+	chips := make([]string, len(task.Tags))
+	for i, tag := range task.Tags {
+		chips[i] = lipgloss.NewStyle().Foreground(d.tagColor(tag)).Render("#" + tag)
+	}
+	return chips
+}
+
+// defaultStaleDays is how many days an open task can go unchanged before
+// it is stale, unless the config says otherwise
+const defaultStaleDays = 14
+
+// isStale reports whether an open task has gone unchanged for longer than
+// after
+func isStale(task board.Task, done bool, after time.Duration, now time.Time) bool {
+	return after > 0 && !done && now.Sub(task.LastUpdated()) >= after
+}
+
+// staleBadge renders e.g. "stale 3w" for a task gone stale
+func (d displaySettings) staleBadge(task board.Task, done bool, now time.Time) string {
+	if !isStale(task, done, d.staleAfter, now) {
+		return ""
+	}
+	return dueStyle.Italic(true).Render("stale " + board.ShortDuration(int(now.Sub(task.LastUpdated()).Hours()/24)))
+}
+
+// timestampBadge renders the task's age or last update, if enabled
+func (d displaySettings) timestampBadge(task board.Task, now time.Time) string {
+	switch d.timestamp {
+	case "created":
+		return dueStyle.Render("created " + relativeTime(task.CreatedAt, now))
+	case "updated":
+		return dueStyle.Render("updated " + relativeTime(task.LastUpdated(), now))
+	}
+	return ""
+}
+
+// cardBadges returns the badges shown with a task's title
+func (m *model) cardBadges(task board.Task, done bool) []string {
+	now := m.now()
+	var badges []string
+	if due := dueBadge(task, done, now); due != "" {
+		badges = append(badges, due)
+	}
+	if ts := m.display.timestampBadge(task, now); ts != "" {
+		badges = append(badges, ts)
+	}
+	if stale := m.display.staleBadge(task, done, now); stale != "" {
+		badges = append(badges, stale)
+	}
+	if task.Points > 0 {
+		badges = append(badges, dueStyle.Render(board.FormatPoints(task.Points)))
+	}
+	return badges
+}
+
+// clockMsg fires every minute so relative times and due badges stay current
+type clockMsg time.Time
+
+func tickClock() tea.Cmd {
+	return tea.Tick(time.Minute, func(t time.Time) tea.Msg { return clockMsg(t) })
+}
+
+// badgeLine lays out badges on a line of their own, from the card's left
+// edge. Badges that don't fit in width are left off whole, with "…" in
+// their place, so that none is cut off mid-word.
+func badgeLine(badges []string, width int) string {
+	line := ""
+	for i, badge := range badges {
+		next := badge
+		if line != "" {
+			next = line + " " + badge
+		}
+		rest := 0
+		if i < len(badges)-1 {
+			rest = 2 // room for " …"
+		}
+		if lipgloss.Width(next)+rest > width {
+			if line == "" {
+				// Even alone it's too wide, and nothing else would fit
+				return ansi.Truncate(badge, max(1, width), "…")
+			}
+			return line + " …"
+		}
+		line = next
+	}
+	return line
+}
@@ -0,0 +1,16 @@
+package ui
+
+import (
+	"strings"
+)
+
+// parseTags splits a comma separated list of tags
+func parseTags(s string) []string {
+	var tags []string
+	for _, tag := range strings.Split(s, ",") {
+		if tag = strings.TrimPrefix(strings.TrimSpace(tag), "#"); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"gotask/internal/stats"
+)
+
+// burnWindows are the numbers of days the burndown on the statistics
+// screen can cover
+var burnWindows = []int{7, 14, 30, 90}
+
+// defaultBurnWindow is the index in burnWindows shown at first
+const defaultBurnWindow = 1
+
+// burnChartHeight is how many lines the burndown chart takes up
+const burnChartHeight = 8
+
+// renderBurndown draws a burnup chart: a bar for each day as tall as the
+// scope, the finished part at the bottom and what remains above it, so
+// the remaining part traces the burndown
+func renderBurndown(points []stats.BurnPoint, width int) string {
+	most := 0
+	for _, p := range points {
+		most = max(most, p.Scope)
+	}
+	if most == 0 {
+		return helpStyle.Render("  No tasks yet")
+	}
+	axis := len(strconv.Itoa(most))
+	// Spread the bars out when there's room, and drop the earliest days
+	// when there isn't
+	room := max(1, width-axis-3)
+	barWidth := max(1, min(3, room/len(points)))
+	if len(points)*barWidth > room {
+		points = points[len(points)-room/barWidth:]
+	}
+
+	cells := func(n int) int {
+		return (n*burnChartHeight + most/2) / most
+	}
+	done := lipgloss.NewStyle().Foreground(doneColor)
+	left := lipgloss.NewStyle().Foreground(todoColor)
+	gutter := lipgloss.NewStyle().Foreground(subtle)
+
+	var lines []string
+	for row := burnChartHeight; row > 0; row-- {
+		label := strings.Repeat(" ", axis)
+		if row == burnChartHeight {
+			label = strconv.Itoa(most)
+		}
+		var line strings.Builder
+		line.WriteString("  " + helpStyle.Render(label) + gutter.Render("│"))
+		for _, p := range points {
+			cell := strings.Repeat(" ", barWidth)
+			switch {
+			case row <= cells(p.Done):
+				cell = done.Render(strings.Repeat("█", barWidth))
+			case row <= cells(p.Scope):
+				cell = left.Render(strings.Repeat("█", barWidth))
+			}
+			line.WriteString(cell)
+		}
+		lines = append(lines, line.String())
+	}
+
+	first, last := points[0], points[len(points)-1]
+	lines = append(lines, dateAxis(first.Day, last.Day, axis, len(points)*barWidth))
+	lines = append(lines, fmt.Sprintf("  %s done %d  %s remaining %d  %s",
+		done.Render("█"), last.Done, left.Render("█"), last.Remaining(),
+		helpStyle.Render(fmt.Sprintf("(%+d remaining, %+d scope since %s)", last.Remaining()-first.Remaining(), last.Scope-first.Scope, first.Day.Format("Jan 2")))))
+	return strings.Join(lines, "\n")
+}
+
+// dateAxis draws the bottom of a day-by-day chart whose value labels are
+// axis wide and whose bars span cells: the axis line, then the first and
+// last days under its ends
+func dateAxis(first, last time.Time, axis, span int) string {
+	gutter := lipgloss.NewStyle().Foreground(subtle)
+	start, end := first.Format("Jan 2"), last.Format("Jan 2")
+	gap := max(1, span-len(start)-len(end))
+	return "  " + helpStyle.Render(fmt.Sprintf("%*d", axis, 0)) + gutter.Render("└"+strings.Repeat("─", span)) + "\n" +
+		"  " + strings.Repeat(" ", axis+1) + helpStyle.Render(start+strings.Repeat(" ", gap)+end)
+}
@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"gotask/internal/board"
+	"gotask/internal/stats"
+)
+
+// calendarView is the calendar of due dates shown instead of the board
+type calendarView struct {
+	day  time.Time // selected day, at midnight
+	week bool      // whether one week is shown instead of the whole month
+}
+
+// calendarTask is a task placed on the calendar along with its column
+type calendarTask struct {
+	board.Task
+	column int
+}
+
+// maxDayList is how many of the selected day's tasks are listed under the
+// calendar
+const maxDayList = 5
+
+// openCalendar shows the calendar, starting on the selected task's due
+// date if it has one, otherwise today
+func (m *model) openCalendar() {
+	day := board.DayOf(time.Now())
+	if task := m.selectedTask(); task != nil && task.Due != nil {
+		day = board.DayOf(*task.Due)
+	}
+	m.calendar = &calendarView{day: day}
+}
+
+// tasksByDay groups the visible tasks with due dates by day, keyed as
+// YYYY-MM-DD
+func (m model) tasksByDay() map[string][]calendarTask {
+	days := make(map[string][]calendarTask)
+	for i, col := range m.board.Columns {
+		for _, task := range col.Tasks {
+			if task.Due == nil || !m.taskVisible(task) {
+				continue
+			}
+			key := board.DayOf(*task.Due).Format(board.DueLayout)
+			days[key] = append(days[key], calendarTask{task, i})
+		}
+	}
+	return days
+}
+
+// calendarRange returns the first day shown and how many weeks are shown.
+// Weeks start on Monday.
+func (c *calendarView) calendarRange() (time.Time, int) {
+	if c.week {
+		return stats.StartOfWeek(c.day), 1
+	}
+	first := time.Date(c.day.Year(), c.day.Month(), 1, 0, 0, 0, 0, time.Local)
+	start := stats.StartOfWeek(first)
+	last := first.AddDate(0, 1, -1)
+	days := int(last.Sub(start).Hours()/24) + 1
+	return start, (days + 6) / 7
+}
+
+// calendarTitle names the month or week being shown
+func (c *calendarView) calendarTitle() string {
+	if !c.week {
+		return c.day.Format("January 2006")
+	}
+	start, _ := c.calendarRange()
+	end := start.AddDate(0, 0, 6)
+	if start.Month() == end.Month() {
+		return fmt.Sprintf("%s %d – %d, %d", start.Format("January"), start.Day(), end.Day(), end.Year())
+	}
+	return fmt.Sprintf("%s – %s", start.Format("Jan 2"), end.Format("Jan 2, 2006"))
+}
+
+// updateCalendar handles key presses while the calendar is open
+func (m model) updateCalendar(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c := m.calendar
+	switch {
+	case key.Matches(msg, m.keys.Calendar, m.keys.Cancel, m.keys.Quit):
+		m.calendar = nil
+	case key.Matches(msg, m.keys.Left):
+		c.day = c.day.AddDate(0, 0, -1)
+	case key.Matches(msg, m.keys.Right):
+		c.day = c.day.AddDate(0, 0, 1)
+	case key.Matches(msg, m.keys.Up):
+		c.day = c.day.AddDate(0, 0, -7)
+	case key.Matches(msg, m.keys.Down):
+		c.day = c.day.AddDate(0, 0, 7)
+	case key.Matches(msg, m.keys.PrevPeriod):
+		if c.week {
+			c.day = c.day.AddDate(0, 0, -7)
+		} else {
+			c.day = c.day.AddDate(0, -1, 0)
+		}
+	case key.Matches(msg, m.keys.NextPeriod):
+		if c.week {
+			c.day = c.day.AddDate(0, 0, 7)
+		} else {
+			c.day = c.day.AddDate(0, 1, 0)
+		}
+	case key.Matches(msg, m.keys.CalendarWeek):
+		c.week = !c.week
+	case key.Matches(msg, m.keys.Today):
+		c.day = board.DayOf(time.Now())
+	case key.Matches(msg, m.keys.Detail):
+		// Go to the first task due on the selected day
+		if tasks := m.tasksByDay()[c.day.Format(board.DueLayout)]; len(tasks) > 0 {
+			m.calendar = nil
+			if err := m.goToTask(tasks[0].ID); err != nil {
+				m.logError(err)
+			}
+		}
+	}
+	return m, nil
+}
+
+// calendarViewBox renders the calendar as a full-screen panel
+func (m model) calendarViewBox() string {
+	c := m.calendar
+	width := max(7*4, m.width-4)
+	cell := width / 7
+	// The last column takes up what's left over
+	cellWidth := func(d int) int {
+		if d == 6 {
+			return width - 6*cell
+		}
+		return cell - 1
+	}
+	days := m.tasksByDay()
+	start, weeks := c.calendarRange()
+	today := board.DayOf(time.Now())
+
+	selected := days[c.day.Format(board.DueLayout)]
+	listLines := min(len(selected), maxDayList) + 1
+	if len(selected) > maxDayList {
+		listLines++
+	}
+
+	// Title, weekday names, the selected day's list, separators between
+	// weeks, the border, and the hint line
+	gridHeight := m.height - 2 - listLines - 1 - (weeks - 1) - 2 - 1
+	cellHeight := max(2, gridHeight/weeks)
+
+	fit := func(s string, w int) string {
+		s = ansi.Truncate(s, w, "…")
+		return s + strings.Repeat(" ", max(0, w-lipgloss.Width(s)))
+	}
+	sep := lipgloss.NewStyle().Foreground(subtle)
+	columnColors := []lipgloss.TerminalColor{todoColor, inProgColor, doneColor}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.PlaceHorizontal(width, lipgloss.Center, searchPromptStyle.Render(c.calendarTitle())))
+	b.WriteString("\n")
+	var names []string
+	for i := 0; i < 7; i++ {
+		name := start.AddDate(0, 0, i).Format("Mon")
+		names = append(names, fit(helpStyle.Render(name), cellWidth(i)))
+	}
+	b.WriteString(strings.Join(names, " "))
+
+	for w := 0; w < weeks; w++ {
+		if w > 0 {
+			b.WriteString("\n" + sep.Render(strings.Repeat("─", width)))
+		}
+		cells := make([][]string, 7)
+		for d := 0; d < 7; d++ {
+			day := start.AddDate(0, 0, w*7+d)
+			number := fmt.Sprintf("%2d", day.Day())
+			style := lipgloss.NewStyle()
+			switch {
+			case day.Equal(c.day):
+				style = style.Reverse(true).Bold(true)
+			case day.Equal(today):
+				style = style.Foreground(highlight).Bold(true).Underline(true)
+			case day.Month() != c.day.Month() && !c.week:
+				style = style.Foreground(mutedColor)
+			}
+			lines := []string{style.Render(number)}
+
+			tasks := days[day.Format(board.DueLayout)]
+			room := cellHeight - 1
+			for i, task := range tasks {
+				if i == room-1 && len(tasks) > room {
+					lines = append(lines, helpStyle.Render(fmt.Sprintf("+%d more", len(tasks)-i)))
+					break
+				}
+				color := subtle
+				if task.column < len(columnColors) {
+					color = columnColors[task.column]
+				}
+				text := fmt.Sprintf("#%d %s%s", task.ID, board.IconPrefix(task.Task), task.Title)
+				lines = append(lines, lipgloss.NewStyle().Foreground(color).Render(ansi.Truncate(text, cellWidth(d), "…")))
+			}
+			cells[d] = lines
+		}
+		for line := 0; line < cellHeight; line++ {
+			row := make([]string, 7)
+			for d := range cells {
+				text := ""
+				if line < len(cells[d]) {
+					text = cells[d][line]
+				}
+				row[d] = fit(text, cellWidth(d))
+			}
+			b.WriteString("\n" + strings.Join(row, sep.Render("│")))
+		}
+	}
+
+	// The selected day's tasks in full
+	b.WriteString("\n\n" + searchPromptStyle.Render(c.day.Format("Monday, January 2")))
+	if len(selected) == 0 {
+		b.WriteString(helpStyle.Render("  nothing due"))
+	}
+	for i, task := range selected {
+		if i == maxDayList {
+			b.WriteString("\n" + helpStyle.Render(fmt.Sprintf("  +%d more", len(selected)-i)))
+			break
+		}
+		line := fmt.Sprintf("  %s %s%s %s", taskIDStyle.Render(fmt.Sprintf("#%d", task.ID)),
+			board.IconPrefix(task.Task), task.Title, helpStyle.Render("· "+m.board.Columns[task.column].Title))
+		b.WriteString("\n" + ansi.Truncate(line, width, "…"))
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(highlight).
+		Padding(0, 1).
+		Render(b.String())
+	return box + "\n" + m.renderHints()
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"gotask/internal/board"
+)
+
+// clipboard holds tasks that were yanked or cut, ready to be pasted
+type clipboard struct {
+	tasks []board.Task
+	cut   bool     // tasks were removed from the board and keep their IDs
+	from  []string // column each task was cut from
+}
+
+// yank copies the target tasks to the clipboard
+func (m *model) yank() {
+	ids := m.targetIDs()
+	if len(ids) == 0 {
+		return
+	}
+	m.releaseCut()
+	m.clipboard = clipboard{}
+	for _, id := range ids {
+		col, idx, _ := m.board.FindTask(id)
+		m.clipboard.tasks = append(m.clipboard.tasks, m.board.Columns[col].Tasks[idx].Clone())
+	}
+	m.clearMarks()
+	m.refreshViewports()
+	m.notify("%s yanked", tasksPhrase(len(ids)))
+}
+
+// cut moves the target tasks from the board to the clipboard
+func (m *model) cut() {
+	ids := m.targetIDs()
+	if len(ids) == 0 {
+		return
+	}
+	m.releaseCut()
+	m.clipboard = clipboard{cut: true}
+	for _, id := range ids {
+		col, idx, _ := m.board.FindTask(id)
+		m.clipboard.tasks = append(m.clipboard.tasks, m.board.Columns[col].Tasks[idx])
+		m.clipboard.from = append(m.clipboard.from, m.board.Columns[col].Title)
+		m.board.DeleteTask(id)
+	}
+	m.afterBulkChange()
+	m.notify("%s cut", tasksPhrase(len(ids)))
+}
+
+// paste inserts the clipboard after (or before) the cursor. Cut tasks are
+// pasted as themselves the first time; every other paste creates copies
+// with new IDs.
+func (m *model) paste(before bool) {
+	if len(m.clipboard.tasks) == 0 {
+		m.logError(fmt.Errorf("nothing to paste"))
+		return
+	}
+
+	col := m.cursorColumn
+	idx := len(m.board.Columns[col].Tasks)
+	if m.selectedTask() != nil {
+		idx = m.cursorTask
+		if !before {
+			idx++
+		}
+	}
+
+	now := time.Now()
+	title := m.board.Columns[col].Title
+	for i, task := range m.clipboard.tasks {
+		task = task.Clone()
+		if m.clipboard.cut {
+			if m.clipboard.from[i] != title {
+				task.Record(board.EventMoved, m.clipboard.from[i], title)
+			}
+			// It's back, so it wasn't deleted after all
+			delete(m.board.Deleted, task.ID)
+		} else {
+			m.lastID++
+			task.ID = m.lastID
+			task.CreatedAt = now
+			task.History, task.Spells = nil, nil
+			task.Record(board.EventCreated, "", title)
+		}
+		task.SetCompleted(m.board.IsDone(col))
+		m.board.InsertTask(col, idx+i, task)
+	}
+
+	// Later pastes of a cut become copies, like any other yank
+	if m.clipboard.cut {
+		m.clipboard.cut = false
+		m.clipboard.from = nil
+	}
+
+	m.cursorTask = idx
+	m.clampCursor()
+	if m.searchQuery != "" {
+		m.runSearch()
+	}
+	m.refreshViewports()
+	m.save()
+	m.notify("%s pasted", tasksPhrase(len(m.clipboard.tasks)))
+}
+
+// readKeySequence handles two-key commands such as yy, dd, and gg. It reports
+// whether the key was consumed.
+func (m *model) readKeySequence(msg tea.KeyMsg) bool {
+	sequences := []struct {
+		binding key.Binding
+		run     func()
+	}{
+		{m.keys.Yank, m.yank},
+		{m.keys.Cut, m.cut},
+		{m.keys.Top, func() { m.jumpToEdge(-1) }},
+	}
+
+	pending := m.pendingKey
+	m.pendingKey = ""
+	for _, seq := range sequences {
+		if !key.Matches(msg, seq.binding) {
+			continue
+		}
+		if pending == "" {
+			m.pendingKey = msg.String()
+			return true
+		}
+		if pending == msg.String() {
+			seq.run()
+			return true
+		}
+	}
+	return pending != "" && key.Matches(msg, m.keys.Cancel)
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"testing"
+
+	"gotask/internal/hooks"
+)
+
+func hookNames(events []hooks.Event) []string {
+	var names []string
+	for _, ev := range events {
+		names = append(names, ev.Event)
+	}
+	return names
+}
+
+func TestCutThenPasteIsAMove(t *testing.T) {
+	m := testModel(t)
+	m.cursorColumn, m.cursorTask = 0, 0
+	m.cut()
+	if len(m.pendingHooks) != 0 {
+		t.Errorf("cutting queued %v hooks, want none until it's pasted", hookNames(m.pendingHooks))
+	}
+
+	m.cursorColumn, m.cursorTask = 1, 0
+	m.paste(false)
+	col, _, ok := m.board.FindTask(1)
+	if !ok || col != 1 {
+		t.Fatalf("#1 is in column %d, want it pasted in In Progress", col)
+	}
+	if _, ok := m.board.Deleted[1]; ok {
+		t.Error("#1 is still recorded as deleted after it was pasted")
+	}
+	for _, entry := range activity(&m.board, m.events) {
+		if entry.ID == 1 && entry.What == "deleted" {
+			t.Error("the activity panel lists #1 as deleted")
+		}
+	}
+	if got := hookNames(m.pendingHooks); len(got) != 1 || got[0] != "move" {
+		t.Errorf("cut and paste queued %v hooks, want [move]", got)
+	} else if ev := m.pendingHooks[0]; ev.Task.ID != 1 || ev.From != "To Do" || ev.Task.Column != "In Progress" {
+		t.Errorf("move hook is for #%d from %q to %q, want #1 from To Do to In Progress", ev.Task.ID, ev.From, ev.Task.Column)
+	}
+}
+
+func TestCutNeverPastedIsADelete(t *testing.T) {
+	m := testModel(t)
+	m.cursorColumn, m.cursorTask = 0, 0
+	m.cut()
+
+	// Yanking another task means the cut one can't be pasted back
+	m.cursorTask = 0
+	m.yank()
+	if got := hookNames(m.pendingHooks); len(got) != 1 || got[0] != "delete" || m.pendingHooks[0].Task.ID != 1 {
+		t.Errorf("a cut that can no longer be pasted queued %v hooks, want [delete] for #1", got)
+	}
+	if _, ok := m.board.Deleted[1]; !ok {
+		t.Error("#1 isn't recorded as deleted")
+	}
+}
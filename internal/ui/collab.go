@@ -0,0 +1,320 @@
+package ui
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"gotask/internal/board"
+	"gotask/internal/httpjson"
+	"gotask/internal/storage"
+)
+
+// reconnectDelay is how long an attached board waits before reconnecting
+// to a server it lost
+const reconnectDelay = 5 * time.Second
+
+// remoteBoard is a board served by "gotask serve" on another machine
+type remoteBoard struct {
+	url     string // the server, without a trailing slash
+	token   string // sent to the server as a bearer token, if set
+	mu      sync.Mutex
+	version string // version of the board last read or written
+}
+
+// request makes a request to the server, with the token if there is one
+func (r *remoteBoard) request(method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, r.url+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	return req, nil
+}
+
+func (r *remoteBoard) current() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.version
+}
+
+func (r *remoteBoard) setVersion(version string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.version = version
+}
+
+// fetch downloads the board and its version
+func (r *remoteBoard) fetch() (data []byte, version string, err error) {
+	req, err := r.request(http.MethodGet, "/board", nil)
+	if err != nil {
+		return nil, "", err
+	}
+	var board board.Board
+	header, err := httpjson.Fetch(req, &board)
+	if err != nil {
+		return nil, "", err
+	}
+	if data, err = storage.Encode(board); err != nil {
+		return nil, "", err
+	}
+	return data, strings.Trim(header.Get("ETag"), `"`), nil
+}
+
+// put uploads the board in place of the version last seen
+func (r *remoteBoard) put(data []byte) error {
+	req, err := r.request(http.MethodPut, "/board", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"`+r.current()+`"`)
+	resp, err := httpjson.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusPreconditionFailed:
+		return storage.ErrBoardChanged
+	case resp.StatusCode >= 300:
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 300))
+		return fmt.Errorf("PUT %s/board: %s: %s", r.url, resp.Status, strings.TrimSpace(string(detail)))
+	}
+	r.setVersion(strings.Trim(resp.Header.Get("ETag"), `"`))
+	return nil
+}
+
+// write saves the board. If someone else saved first, their board and
+// this one are merged and saved instead, and the merged board returned.
+func (r *remoteBoard) write(data []byte) (merged []byte, err error) {
+	for attempt := 0; attempt < 3; attempt++ {
+		if err := r.put(data); !errors.Is(err, storage.ErrBoardChanged) {
+			return merged, err
+		}
+		latest, version, err := r.fetch()
+		if err != nil {
+			return merged, err
+		}
+		if data, err = mergeBoardData(latest, data); err != nil {
+			return merged, err
+		}
+		merged = data
+		r.setVersion(version)
+	}
+	return merged, fmt.Errorf("saving to %s: %w too often to keep up", r.url, storage.ErrBoardChanged)
+}
+
+// listen sends the board's version whenever the server reports a change,
+// reconnecting as needed. It never returns.
+func (r *remoteBoard) listen(versions chan<- string) {
+	for {
+		req, err := r.request(http.MethodGet, "/events", nil)
+		if err != nil {
+			return
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				if version, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+					versions <- version
+				}
+			}
+			resp.Body.Close()
+		}
+		time.Sleep(reconnectDelay)
+	}
+}
+
+// mergeBoardData merges the board in ours into the one in theirs
+func mergeBoardData(theirs, ours []byte) ([]byte, error) {
+	var base, other board.Board
+	if err := json.Unmarshal(theirs, &base); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(ours, &other); err != nil {
+		return nil, err
+	}
+	storage.Merge(&base, other)
+	return storage.Encode(base)
+}
+
+// writeMerging writes the board file like storage.Writer.Write, but first merges in
+// changes made to the file since it was last seen, as it was at known. It
+// returns the merged board, if there was anything to merge, and the
+// file's new stamp.
+func writeMerging(s *storage.Writer, path string, data []byte, seq int, known storage.Stamp) (merged []byte, stamp storage.Stamp, err error) {
+	if known != (storage.Stamp{}) && storage.Stat(path) != known {
+		disk, err := storage.Read(path)
+		if err != nil {
+			return nil, known, err
+		}
+		latest, err := storage.Encode(disk)
+		if err != nil {
+			return nil, known, err
+		}
+		if data, err = mergeBoardData(latest, data); err != nil {
+			return nil, known, err
+		}
+		merged = data
+	}
+	if err := s.Write(path, data, seq); err != nil {
+		return nil, known, err
+	}
+	return merged, storage.Stat(path), nil
+}
+
+// Messages about changes made elsewhere
+type (
+	fileWatchMsg     struct{}
+	remoteChangedMsg string // the board's new version
+	remoteBoardMsg   struct {
+		data    []byte
+		version string
+		err     error
+	}
+)
+
+// watchBoard starts listening for changes made by others
+func (m model) watchBoard() tea.Cmd {
+	if m.remote == nil {
+		return tickWatch()
+	}
+	go m.remote.listen(m.remoteEvents)
+	return waitRemote(m.remoteEvents)
+}
+
+func tickWatch() tea.Cmd {
+	return tea.Tick(storage.WatchInterval, func(time.Time) tea.Msg { return fileWatchMsg{} })
+}
+
+func waitRemote(versions <-chan string) tea.Cmd {
+	return func() tea.Msg { return remoteChangedMsg(<-versions) }
+}
+
+// busy reports whether changes of ours are on their way to be saved
+func (m *model) busy() bool {
+	return m.saving || m.pendingSave != nil
+}
+
+// checkFile reloads the board file if something else changed it. While a
+// save is under way it waits, since the save merges the changes in.
+func (m *model) checkFile() {
+	stamp := storage.Stat(m.savePath)
+	if m.busy() || stamp == m.fileStamp {
+		return
+	}
+	board, err := storage.Read(m.savePath)
+	if err != nil {
+		m.logError(err)
+		return
+	}
+	m.fileStamp = stamp
+	data, err := storage.Encode(board)
+	if err != nil {
+		m.logError(err)
+		return
+	}
+	m.applyExternal(data)
+}
+
+// fetchRemote downloads the attached board in the background
+func (m *model) fetchRemote() tea.Cmd {
+	remote := m.remote
+	return func() tea.Msg {
+		data, version, err := remote.fetch()
+		return remoteBoardMsg{data, version, err}
+	}
+}
+
+// remoteChanged fetches the attached board when another instance changed
+// it, or notes it for after the save under way
+func (m *model) remoteChanged(version string) tea.Cmd {
+	wait := waitRemote(m.remoteEvents)
+	if version == m.remote.current() {
+		return wait
+	}
+	if m.busy() {
+		m.remoteStale = true
+		return wait
+	}
+	return tea.Batch(wait, m.fetchRemote())
+}
+
+// applyRemote shows the board fetched from the server, unless changes of
+// ours have been made since, in which case it is fetched again after they
+// are saved
+func (m *model) applyRemote(msg remoteBoardMsg) {
+	if msg.err != nil {
+		m.logError(msg.err)
+		return
+	}
+	if m.busy() {
+		m.remoteStale = true
+		return
+	}
+	m.remote.setVersion(msg.version)
+	m.applyExternal(msg.data)
+}
+
+// applyExternal replaces the board with one changed elsewhere. Changes of
+// ours not yet saved are merged into it and saved again.
+func (m *model) applyExternal(data []byte) {
+	if bytes.Equal(data, m.savedData) && m.pendingSave == nil {
+		return
+	}
+	if m.pendingSave != nil {
+		current, err := storage.Encode(m.board)
+		if err != nil {
+			m.logError(err)
+			return
+		}
+		if data, err = mergeBoardData(data, current); err != nil {
+			m.logError(err)
+			return
+		}
+	}
+	var board board.Board
+	if err := json.Unmarshal(data, &board); err != nil {
+		m.logError(err)
+		return
+	}
+	if len(board.Columns) != len(m.board.Columns) {
+		m.logError(fmt.Errorf("the board changed elsewhere to %d columns; restart gotask to see it", len(board.Columns)))
+		return
+	}
+	m.board = board
+	m.lastID = max(m.lastID, m.board.MaxID())
+	m.savedData = data
+	m.recordExternal()
+	if m.pendingSave != nil {
+		m.queueSave(data)
+	}
+	m.clampCursor()
+	if m.searchQuery != "" {
+		m.runSearch()
+	}
+	m.refreshViewports()
+}
+
+// Attach opens the board served by "gotask serve" at url, sending token
+// if it is not empty, until it is quit
+func Attach(url, token string) error {
+	remote := &remoteBoard{url: url, token: token}
+	if _, _, err := remote.fetch(); err != nil {
+		return err
+	}
+	return runBoard(newModel(remote))
+}
@@ -0,0 +1,274 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"gotask/internal/board"
+)
+
+// command is a parsed line from the ":" prompt
+type command struct {
+	name string
+	args []string
+}
+
+func newCommandInput() textinput.Model {
+	ci := textinput.New()
+	ci.Prompt = ":"
+	return ci
+}
+
+// parseCommand splits a command line into a name and arguments. Arguments
+// may be wrapped in single or double quotes to include spaces.
+func parseCommand(line string) (command, error) {
+	var fields []string
+	var current strings.Builder
+	var quote rune
+	inField := false
+
+	for _, r := range line {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inField = true
+		case unicode.IsSpace(r):
+			if inField {
+				fields = append(fields, current.String())
+				current.Reset()
+				inField = false
+			}
+		default:
+			current.WriteRune(r)
+			inField = true
+		}
+	}
+	if quote != 0 {
+		return command{}, fmt.Errorf("unterminated quote in %q", line)
+	}
+	if inField {
+		fields = append(fields, current.String())
+	}
+	if len(fields) == 0 {
+		return command{}, nil
+	}
+	return command{name: strings.ToLower(fields[0]), args: fields[1:]}, nil
+}
+
+// taskArg resolves a task ID argument, accepting an optional leading "#"
+func (m *model) taskArg(arg string) (int, int, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+	if err != nil {
+		return -1, -1, fmt.Errorf("%q is not a task ID", arg)
+	}
+	col, idx, ok := m.board.FindTask(id)
+	if !ok {
+		return -1, -1, fmt.Errorf("no task #%d", id)
+	}
+	return col, idx, nil
+}
+
+// runCommand executes a line typed at the ":" prompt
+func (m *model) runCommand(line string) tea.Cmd {
+	m.dismissErrors()
+	cmd, err := parseCommand(line)
+	if err != nil {
+		m.logError(err)
+		return nil
+	}
+
+	switch cmd.name {
+	case "":
+		return nil
+
+	case "w", "write":
+		m.saveRequested = true
+		if err := m.saveBoard(); err != nil {
+			m.logError(err)
+		} else {
+			m.notify("Saved")
+		}
+
+	case "q", "quit", "q!", "wq", "x":
+		if err := m.saveNow(); err != nil {
+			m.logError(err)
+			return nil
+		}
+		return tea.Quit
+
+	case "new", "add":
+		title := strings.Join(cmd.args, " ")
+		if title == "" {
+			m.logError(fmt.Errorf("usage: :new <title>"))
+			return nil
+		}
+		if link, ok := parseIssueLink(title); ok {
+			return m.addLinkedTask(m.cursorColumn, link)
+		}
+		m.addTask(m.cursorColumn, title)
+
+	case "move", "mv":
+		if len(cmd.args) != 2 {
+			m.logError(fmt.Errorf("usage: :move <task id> <column>"))
+			return nil
+		}
+		col, idx, err := m.taskArg(cmd.args[0])
+		if err != nil {
+			m.logError(err)
+			return nil
+		}
+		dest, ok := m.board.ColumnIndex(cmd.args[1])
+		if !ok {
+			m.logError(fmt.Errorf("no column matches %q", cmd.args[1]))
+			return nil
+		}
+		m.rememberCursor()
+		m.cursorTask = m.board.MoveTask(col, idx, dest, -1)
+		m.cursorColumn = dest
+		m.save()
+		m.notify("Task moved to %s", m.board.Columns[dest].Title)
+
+	case "delete", "del", "archive":
+		if len(cmd.args) == 0 {
+			m.logError(fmt.Errorf("usage: :%s <task id>...", cmd.name))
+			return nil
+		}
+		var ids []int
+		for _, arg := range cmd.args {
+			col, idx, err := m.taskArg(arg)
+			if err != nil {
+				m.logError(err)
+				return nil
+			}
+			ids = append(ids, m.board.Columns[col].Tasks[idx].ID)
+		}
+		if cmd.name == "archive" {
+			m.requestArchive(ids)
+		} else {
+			m.requestDelete(ids)
+		}
+
+	case "tag":
+		if len(cmd.args) < 2 {
+			m.logError(fmt.Errorf("usage: :tag <task id> <tag>..."))
+			return nil
+		}
+		col, idx, err := m.taskArg(cmd.args[0])
+		if err != nil {
+			m.logError(err)
+			return nil
+		}
+		m.rememberCursor()
+		m.cursorColumn, m.cursorTask = col, idx
+		m.clearMarks()
+		m.tagTargets(strings.Join(cmd.args[1:], " "))
+
+	case "priority", "p":
+		if len(cmd.args) != 1 {
+			m.logError(fmt.Errorf("usage: :priority <none|low|medium|high>"))
+			return nil
+		}
+		p, ok := board.ParsePriority(cmd.args[0])
+		if !ok {
+			m.logError(fmt.Errorf("unknown priority %q", cmd.args[0]))
+			return nil
+		}
+		m.perform("priority "+p.String(), func(m *model) { m.setTargetPriority(p) })
+
+	case "points", "pt":
+		if len(cmd.args) != 1 {
+			m.logError(fmt.Errorf("usage: :points <estimate>"))
+			return nil
+		}
+		points, err := strconv.ParseFloat(cmd.args[0], 64)
+		if err != nil || points < 0 {
+			m.logError(fmt.Errorf("%q is not an estimate", cmd.args[0]))
+			return nil
+		}
+		m.perform("points "+cmd.args[0], func(m *model) { m.setTargetPoints(points) })
+
+	case "filter":
+		m.applyFilter(strings.Join(cmd.args, " "))
+
+	case "nofilter":
+		m.applyFilter("")
+
+	case "goto", "g":
+		if len(cmd.args) != 1 {
+			m.logError(fmt.Errorf("usage: :goto <task id>"))
+			return nil
+		}
+		id, err := strconv.Atoi(strings.TrimPrefix(cmd.args[0], "#"))
+		if err != nil {
+			m.logError(fmt.Errorf("%q is not a task ID", cmd.args[0]))
+			return nil
+		}
+		if err := m.goToTask(id); err != nil {
+			m.logError(err)
+			return nil
+		}
+
+	case "due":
+		m.openDueSummary()
+
+	case "plugin":
+		if len(cmd.args) == 0 {
+			m.logError(fmt.Errorf("usage: :plugin <name> [args]..."))
+			return nil
+		}
+		return m.runPluginAction(cmd.args[0], cmd.args[1:])
+
+	case "undo":
+		m.undo()
+
+	case "redo":
+		m.redo()
+
+	default:
+		// ":12" jumps to task #12
+		id, err := strconv.Atoi(strings.TrimPrefix(cmd.name, "#"))
+		if err != nil {
+			m.logError(fmt.Errorf("unknown command %q", cmd.name))
+			return nil
+		}
+		if err := m.goToTask(id); err != nil {
+			m.logError(err)
+			return nil
+		}
+	}
+
+	if m.searchQuery != "" {
+		m.runSearch()
+	}
+	m.refreshViewports()
+	return nil
+}
+
+// updateCommand handles key presses while the command prompt is open
+func (m model) updateCommand(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.commanding = false
+		m.commandInput.Blur()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Submit):
+		m.commanding = false
+		m.commandInput.Blur()
+		return m, m.runCommand(m.commandInput.Value())
+	}
+
+	var cmd tea.Cmd
+	m.commandInput, cmd = m.commandInput.Update(msg)
+	return m, cmd
+}
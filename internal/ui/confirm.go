@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+)
+
+// confirmPolicy decides when a destructive action asks for confirmation
+type confirmPolicy string
+
+const (
+	confirmAlways      confirmPolicy = "always"
+	confirmNever       confirmPolicy = "never"
+	confirmDescription confirmPolicy = "description" // only tasks with a description
+)
+
+type confirmSettings struct {
+	delete  confirmPolicy
+	archive confirmPolicy
+	bulk    confirmPolicy
+}
+
+func parseConfirmPolicy(action, s string) (confirmPolicy, error) {
+	switch p := confirmPolicy(s); p {
+	case "":
+		return confirmAlways, nil
+	case confirmAlways, confirmNever, confirmDescription:
+		return p, nil
+	}
+	return confirmAlways, fmt.Errorf("confirm.%s: unknown policy %q (want always, never, or description)", action, s)
+}
+
+// newConfirmSettings validates the configured policies. Invalid entries
+// fall back to "always" so a typo never skips a confirmation.
+func newConfirmSettings(cfg config.Confirm) (confirmSettings, error) {
+	var s confirmSettings
+	var firstErr error
+	for _, p := range []struct {
+		action string
+		value  string
+		dst    *confirmPolicy
+	}{
+		{"delete", cfg.Delete, &s.delete},
+		{"archive", cfg.Archive, &s.archive},
+		{"bulk", cfg.Bulk, &s.bulk},
+	} {
+		policy, err := parseConfirmPolicy(p.action, p.value)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		*p.dst = policy
+	}
+	return s, firstErr
+}
+
+// confirmation is an action waiting for the user to answer y/n
+type confirmation struct {
+	prompt string // e.g. "Delete task?"
+	detail string // optional task title shown under the prompt
+	run    func(m *model)
+}
+
+// required reports whether the policy asks before acting on the given tasks
+func (p confirmPolicy) required(b *board.Board, ids []int) bool {
+	switch p {
+	case confirmNever:
+		return false
+	case confirmDescription:
+		for _, id := range ids {
+			if col, idx, ok := b.FindTask(id); ok && b.Columns[col].Tasks[idx].Description != "" {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// confirmOrRun opens a confirmation dialog for c when the policy asks for
+// one, and otherwise runs the action straight away
+func (m *model) confirmOrRun(policy confirmPolicy, ids []int, c confirmation) {
+	if !policy.required(&m.board, ids) {
+		c.run(m)
+		return
+	}
+	m.confirm = &c
+	m.dialogType = confirmDialog
+}
+
+// taskConfirmation builds the confirmation for acting on the given tasks
+func (m *model) taskConfirmation(verb string, ids []int, run func(m *model)) confirmation {
+	c := confirmation{prompt: fmt.Sprintf("%s %d tasks?", verb, len(ids)), run: run}
+	if len(ids) == 1 {
+		if col, idx, ok := m.board.FindTask(ids[0]); ok {
+			c.prompt = verb + " task?"
+			c.detail = m.board.Columns[col].Tasks[idx].Title
+		}
+	}
+	return c
+}
+
+// requestDelete deletes the tasks, asking first if configured to
+func (m *model) requestDelete(ids []int) {
+	if len(ids) == 0 {
+		return
+	}
+	m.confirmOrRun(m.policies.delete, ids, m.taskConfirmation("Delete", ids, func(m *model) {
+		for _, id := range ids {
+			m.board.DeleteTask(id)
+		}
+		m.afterBulkChange()
+		m.notify("%s deleted", tasksPhrase(len(ids)))
+	}))
+}
+
+// requestArchive archives the tasks, asking first if configured to
+func (m *model) requestArchive(ids []int) {
+	if len(ids) == 0 {
+		return
+	}
+	m.confirmOrRun(m.policies.archive, ids, m.taskConfirmation("Archive", ids, func(m *model) {
+		now := time.Now()
+		for _, id := range ids {
+			m.board.ArchiveTask(id, now)
+		}
+		m.afterBulkChange()
+		m.notify("%s archived", tasksPhrase(len(ids)))
+	}))
+}
+
+// requestMoveMarked moves the marked tasks, asking first if configured to
+func (m *model) requestMoveMarked(dir int) {
+	ids := m.targetIDs()
+	if len(ids) < 2 {
+		m.moveMarked(dir)
+		return
+	}
+	where := "right"
+	if dir < 0 {
+		where = "left"
+	}
+	m.confirmOrRun(m.policies.bulk, ids, confirmation{
+		prompt: fmt.Sprintf("Move %d tasks %s?", len(ids), where),
+		run:    func(m *model) { m.moveMarked(dir) },
+	})
+}
+
+// afterBulkChange tidies up the cursor and marks after tasks were removed
+func (m *model) afterBulkChange() {
+	m.clearMarks()
+	m.clampCursor()
+	if m.searchQuery != "" {
+		m.runSearch()
+	}
+	m.refreshViewports()
+	m.save()
+}
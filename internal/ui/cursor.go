@@ -0,0 +1,49 @@
+package ui
+
+// columnCursor remembers where the cursor was in a column
+type columnCursor struct {
+	task  int // ID of the selected task
+	index int // its position, used if the task has since left the column
+}
+
+// rememberCursor saves the cursor position of the current column
+func (m *model) rememberCursor() {
+	for len(m.columnCursors) < len(m.board.Columns) {
+		m.columnCursors = append(m.columnCursors, columnCursor{task: -1})
+	}
+	c := columnCursor{task: -1, index: m.cursorTask}
+	if task := m.selectedTask(); task != nil {
+		c.task = task.ID
+	}
+	m.columnCursors[m.cursorColumn] = c
+}
+
+// restoreCursor puts the cursor back where it was when the current column
+// was last left: on the same task if it is still there, otherwise at the
+// same position
+func (m *model) restoreCursor() {
+	m.cursorTask = 0
+	if m.cursorColumn < len(m.columnCursors) {
+		c := m.columnCursors[m.cursorColumn]
+		m.cursorTask = c.index
+		for i, task := range m.board.Columns[m.cursorColumn].Tasks {
+			if task.ID == c.task {
+				m.cursorTask = i
+				break
+			}
+		}
+	}
+	m.clampCursor()
+}
+
+// switchColumn moves the cursor to another column, remembering its place
+// in the one it leaves
+func (m *model) switchColumn(column int) {
+	if column == m.cursorColumn || column < 0 || column >= len(m.board.Columns) {
+		return
+	}
+	m.rememberCursor()
+	m.cursorColumn = column
+	m.restoreCursor()
+	m.refreshViewports()
+}
@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"gotask/internal/stats"
+)
+
+// renderCycleTimes lays out the cycle times for the statistics screen
+func renderCycleTimes(c stats.CycleTimes, width int) string {
+	var b strings.Builder
+	stats.WriteCycleTimes(&b, c)
+	return renderTable(b.String(), width)
+}
+
+// renderTable indents a table written for the terminal to fit the
+// statistics screen, dimming its header
+func renderTable(table string, width int) string {
+	lines := strings.Split(strings.TrimRight(table, "\n"), "\n")
+	for i, line := range lines {
+		line = ansi.Truncate("  "+line, width, "…")
+		if i == 0 {
+			line = helpStyle.Render(line)
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
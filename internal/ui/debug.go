@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"gotask/internal/debug"
+)
+
+// slowFrame is how long an update or render can take before the debug
+// log notes it: one frame at 60fps
+const slowFrame = 16 * time.Millisecond
+
+// logUpdate notes a key press, or any message that was slow to handle.
+// Letters typed outside normal mode are left out, since they spell out
+// task titles and descriptions.
+func logUpdate(msg tea.Msg, mode string, took time.Duration) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		key := msg.String()
+		if msg.Type == tea.KeyRunes && mode != "NORMAL" {
+			key = "(typed)"
+		}
+		debug.Log.Debug("key", "key", key, "mode", mode, "took", took)
+	case tea.WindowSizeMsg:
+		debug.Log.Debug("resize", "width", msg.Width, "height", msg.Height, "took", took)
+	default:
+		if took > slowFrame {
+			debug.Log.Debug("slow update", "msg", fmt.Sprintf("%T", msg), "took", took)
+		}
+	}
+}
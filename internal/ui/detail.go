@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"gotask/internal/board"
+)
+
+const timeLayout = "2006-01-02 15:04"
+
+// relativeTime formats how long ago t was, e.g. "5m ago" or "3d ago"
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// openDetail shows the detail view for the selected task
+func (m *model) openDetail() {
+	task := m.selectedTask()
+	if task == nil {
+		return
+	}
+	m.showDetail = true
+	m.detailView = viewport.New(max(20, m.width-6), max(5, m.height-6))
+	m.detailView.SetContent(m.renderDetail(*task, m.board.Columns[m.cursorColumn].Title, m.detailView.Width))
+}
+
+// renderDetail lays out everything we know about a task
+func (m model) renderDetail(task board.Task, column string, width int) string {
+	label := lipgloss.NewStyle().Foreground(highlight).Bold(true)
+	section := label.Copy().Underline(true)
+	now := time.Now()
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Width(width).Render(fmt.Sprintf("#%d %s%s", task.ID, m.display.iconPrefix(task), task.Title)))
+	b.WriteString("\n\n")
+
+	fmt.Fprintf(&b, "%s %s\n", label.Render("Column:  "), column)
+	fmt.Fprintf(&b, "%s %s\n", label.Render("Priority:"), task.Priority)
+	if task.Due != nil {
+		fmt.Fprintf(&b, "%s %s\n", label.Render("Due:     "), board.FormatDue(task.Due))
+	}
+	fmt.Fprintf(&b, "%s %s (%s)\n", label.Render("Created: "),
+		task.CreatedAt.Local().Format(timeLayout), relativeTime(task.CreatedAt, now))
+	if n := len(task.History); n > 0 {
+		last := task.History[n-1].At
+		fmt.Fprintf(&b, "%s %s (%s)\n", label.Render("Updated: "),
+			last.Local().Format(timeLayout), relativeTime(last, now))
+	}
+	tags := "none"
+	if len(task.Tags) > 0 {
+		tags = strings.Join(task.Tags, ", ")
+	}
+	fmt.Fprintf(&b, "%s %s\n", label.Render("Tags:    "), tags)
+	if task.Pomodoros > 0 {
+		unit := "pomodoros"
+		if task.Pomodoros == 1 {
+			unit = "pomodoro"
+		}
+		fmt.Fprintf(&b, "%s %d %s\n", label.Render("Focus:   "), task.Pomodoros, unit)
+	}
+	if task.Points > 0 {
+		fmt.Fprintf(&b, "%s %s\n", label.Render("Points:  "), board.FormatPoints(task.Points))
+	}
+	if task.Source != nil {
+		fmt.Fprintf(&b, "%s %s %s\n", label.Render("Source:  "), task.Source.Kind, cmp.Or(task.Source.URL, task.Source.ID))
+	}
+
+	b.WriteString("\n" + section.Render("Description") + "\n")
+	if task.Description == "" {
+		b.WriteString(helpStyle.Render("No description") + "\n")
+	} else {
+		b.WriteString(lipgloss.NewStyle().Width(width).Render(task.Description) + "\n")
+	}
+
+	if len(task.Subtasks) > 0 {
+		done := 0
+		for _, sub := range task.Subtasks {
+			if sub.Done {
+				done++
+			}
+		}
+		b.WriteString("\n" + section.Render(fmt.Sprintf("Subtasks (%d/%d)", done, len(task.Subtasks))) + "\n")
+		for _, sub := range task.Subtasks {
+			check := "[ ]"
+			if sub.Done {
+				check = "[x]"
+			}
+			b.WriteString(check + " " + sub.Title + "\n")
+		}
+	}
+
+	b.WriteString("\n" + section.Render("History") + "\n")
+	if len(task.History) == 0 {
+		b.WriteString(helpStyle.Render("No recorded changes") + "\n")
+	}
+	for i := len(task.History) - 1; i >= 0; i-- {
+		e := task.History[i]
+		b.WriteString(helpStyle.Render(e.At.Local().Format(timeLayout)) + "  " + board.DescribeEvent(e) + "\n")
+	}
+
+	return b.String()
+}
+
+// updateDetail handles key presses while the detail view is open
+func (m model) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel, m.keys.Detail, m.keys.Quit):
+		m.showDetail = false
+	case key.Matches(msg, m.keys.Up):
+		m.detailView.LineUp(1)
+	case key.Matches(msg, m.keys.Down):
+		m.detailView.LineDown(1)
+	}
+	return m, nil
+}
+
+// detailViewBox renders the detail view as a full-screen panel
+func (m model) detailViewBox() string {
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(highlight).
+		Padding(0, 1).
+		Render(m.detailView.View())
+	return box + "\n" + m.renderHints()
+}
@@ -0,0 +1,265 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+
+	"gotask/internal/config"
+)
+
+type displaySettings struct {
+	doneStyle  *lipgloss.Style // nil leaves finished tasks unstyled
+	titleLines int
+	symbols    bool
+	compact    bool
+	dim        bool     // dim unfocused columns
+	priority   []string // badge text indexed by Priority, nil to hide
+	showTags   bool
+	showIcons  bool
+	timestamp  string                            // "created", "updated", or "" for none
+	staleAfter time.Duration                     // how long until open tasks are stale, 0 for never
+	tagColors  map[string]lipgloss.TerminalColor // keyed by lowercase tag
+	border     lipgloss.Border
+	borderless bool
+	card       lipgloss.Style // border and padding of task cards
+}
+
+// Symbols used when state can't rely on color alone
+const (
+	symbolDone  = "✓ "
+	symbolMatch = "◆ "
+)
+
+// Priority badges, indexed by Priority. The icons need a Nerd Font.
+var (
+	priorityMarks = []string{"", "!", "!!", "!!!"}
+	priorityIcons = []string{"", "\uf063", "\uf12a", "\uf06d"} // arrow down, exclamation, fire
+)
+
+// minTitleWidth is the narrowest a title may wrap beside its card's head
+const minTitleWidth = 8
+
+// defaultTitleLines is how far titles wrap unless configured otherwise
+const defaultTitleLines = 3
+
+// newDisplaySettings validates the display options. Styles are built from
+// the current theme, so this runs after the theme has been applied.
+func newDisplaySettings(cfg config.Display, theme string) (displaySettings, error) {
+	d := displaySettings{
+		titleLines: defaultTitleLines,
+		compact:    cfg.Compact,
+		showTags:   !cfg.HideTags,
+		showIcons:  !cfg.HideIcons,
+		dim:        cfg.DimUnfocused,
+	}
+	d.symbols = cfg.Symbols || os.Getenv("NO_COLOR") != "" ||
+		theme == "monochrome" || theme == "high-contrast"
+	var err error
+	if cfg.TitleLines > 0 {
+		d.titleLines = cfg.TitleLines
+	} else if cfg.TitleLines < 0 {
+		err = fmt.Errorf("display.title_lines must be positive, got %d", cfg.TitleLines)
+	}
+
+	dim := lipgloss.NewStyle().Foreground(mutedColor)
+	switch cfg.Done {
+	case "", "strike":
+		style := dim.Strikethrough(true)
+		d.doneStyle = &style
+	case "dim":
+		d.doneStyle = &dim
+	case "plain":
+	default:
+		err = errors.Join(err, fmt.Errorf("display.done: unknown style %q (want strike, dim, or plain)", cfg.Done))
+		style := dim.Strikethrough(true)
+		d.doneStyle = &style
+	}
+
+	d.tagColors = make(map[string]lipgloss.TerminalColor, len(cfg.TagColors))
+	for tag, color := range cfg.TagColors {
+		d.tagColors[strings.ToLower(strings.TrimPrefix(tag, "#"))] = lipgloss.Color(color)
+	}
+
+	switch cfg.Timestamp {
+	case "", "none":
+	case "created", "updated":
+		d.timestamp = cfg.Timestamp
+	default:
+		err = errors.Join(err, fmt.Errorf("display.timestamp: unknown value %q (want created, updated, or none)", cfg.Timestamp))
+	}
+
+	switch {
+	case cfg.StaleDays == 0:
+		d.staleAfter = defaultStaleDays * 24 * time.Hour
+	case cfg.StaleDays > 0:
+		d.staleAfter = time.Duration(cfg.StaleDays) * 24 * time.Hour
+	case cfg.StaleDays != -1:
+		err = errors.Join(err, fmt.Errorf("display.stale_days must be positive or -1, got %d", cfg.StaleDays))
+	}
+
+	switch cfg.Priority {
+	case "", "marks":
+		d.priority = priorityMarks
+	case "icons":
+		d.priority = priorityIcons
+	case "none":
+	default:
+		err = errors.Join(err, fmt.Errorf("display.priority: unknown style %q (want marks, icons, or none)", cfg.Priority))
+		d.priority = priorityMarks
+	}
+
+	switch cfg.Border {
+	case "", "rounded":
+		d.border = lipgloss.RoundedBorder()
+	case "normal":
+		d.border = lipgloss.NormalBorder()
+	case "thick":
+		d.border = lipgloss.ThickBorder()
+	case "none":
+		d.borderless = true
+	default:
+		err = errors.Join(err, fmt.Errorf("display.border: unknown style %q (want rounded, normal, thick, or none)", cfg.Border))
+		d.border = lipgloss.RoundedBorder()
+	}
+
+	columnPadding, perr := parsePadding("column", cfg.Padding.Column, []int{1, 2})
+	err = errors.Join(err, perr)
+	cardPadding, perr := parsePadding("card", cfg.Padding.Card, []int{0, 1})
+	err = errors.Join(err, perr)
+
+	d.card = lipgloss.NewStyle().Padding(cardPadding...)
+	if !d.borderless {
+		d.card = d.card.Border(d.border)
+	}
+	d.restyle(columnPadding)
+	return d, err
+}
+
+// parsePadding checks a padding setting, falling back to def when it is
+// unset or invalid
+func parsePadding(name string, padding, def []int) ([]int, error) {
+	if len(padding) == 0 {
+		return def, nil
+	}
+	if len(padding) > 4 {
+		return def, fmt.Errorf("display.padding.%s takes one to four numbers, got %d", name, len(padding))
+	}
+	for _, n := range padding {
+		if n < 0 {
+			return def, fmt.Errorf("display.padding.%s can't be negative, got %d", name, n)
+		}
+	}
+	return padding, nil
+}
+
+// restyle applies the border and column padding settings to the board's
+// styles
+func (d displaySettings) restyle(columnPadding []int) {
+	withBorder := func(s lipgloss.Style) lipgloss.Style {
+		if d.borderless {
+			return s.Border(lipgloss.Border{}, false)
+		}
+		return s.BorderStyle(d.border)
+	}
+	titleStyle = withBorder(titleStyle)
+	columnHeaderStyle = withBorder(columnHeaderStyle)
+	columnStyle = withBorder(columnStyle).Padding(columnPadding...)
+	todoColumnStyle = columnStyle.Copy().BorderForeground(todoColor)
+	inProgColumnStyle = columnStyle.Copy().BorderForeground(inProgColor)
+	doneColumnStyle = columnStyle.Copy().BorderForeground(doneColor)
+}
+
+// cardStyle returns the style of a card with the given border color. The
+// selected card gets a thick border in symbols mode.
+func (d displaySettings) cardStyle(color lipgloss.TerminalColor, selected bool) lipgloss.Style {
+	style := d.card.BorderForeground(color)
+	if d.symbols && selected && !d.borderless {
+		if d.border == lipgloss.ThickBorder() {
+			return style.BorderStyle(lipgloss.DoubleBorder())
+		}
+		return style.BorderStyle(lipgloss.ThickBorder())
+	}
+	return style
+}
+
+// columnWidth is the width passed to the column and header styles
+func (m model) columnWidth() int {
+	return m.boardWidth()/len(m.board.Columns) - 3 - columnStyle.GetHorizontalBorderSize()
+}
+
+// cardWidth is the width passed to the card style, leaving a little room
+// beside cards inside the column
+func (m model) cardWidth() int {
+	return m.columnWidth() - columnStyle.GetHorizontalPadding() - 4 - m.display.card.GetHorizontalBorderSize()
+}
+
+// wrapTitle lays out a card's title after its head (selection marker, task
+// number, and so on). The title wraps within width, hanging under its first
+// line, and anything past maxLines is cut off with an ellipsis.
+func wrapTitle(head, title string, width, maxLines int) string {
+	indent := lipgloss.Width(head)
+	textWidth := max(1, width-indent)
+
+	// On very narrow cards the title gets a line of its own instead
+	if textWidth < minTitleWidth && width > textWidth {
+		head = strings.TrimRight(head, " ") + "\n"
+		indent, textWidth = 0, max(1, width)
+	}
+
+	lines := strings.Split(lipgloss.NewStyle().Width(textWidth).Render(title), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		lines[maxLines-1] = ansi.Truncate(lines[maxLines-1], textWidth-1, "") + "…"
+	}
+
+	return head + strings.Join(lines, "\n"+strings.Repeat(" ", indent))
+}
+
+// dimmed reports whether a column is drawn dimmed because the cursor is
+// in another one, or because focus mode is on a task in another one
+func (m model) dimmed(col int) bool {
+	if m.focus != nil {
+		return !m.focusColumn(col)
+	}
+	return m.display.dim && col != m.cursorColumn
+}
+
+// dimText redraws rendered text in the muted color, dropping its styles
+func dimText(s string) string {
+	return lipgloss.NewStyle().Foreground(mutedColor).Render(ansi.Strip(s))
+}
+
+// compactLine lays out a task on a single line for compact mode: a bar in
+// the card's border color, then the head and title, cut off at width
+func compactLine(bar lipgloss.TerminalColor, head, title string, width int) string {
+	line := lipgloss.NewStyle().Foreground(bar).Render("▌") + head + title
+	return ansi.Truncate(line, width, "…")
+}
+
+// renderText renders s with style like style.Render, except that underline
+// and strikethrough are applied to the text as a whole. lipgloss applies
+// them one rune at a time, which splits accents and emoji sequences apart.
+func renderText(style lipgloss.Style, s string) string {
+	underline, strike := style.GetUnderline(), style.GetStrikethrough()
+	out := style.UnsetUnderline().UnsetStrikethrough().Render(s)
+	if lipgloss.ColorProfile() == termenv.Ascii {
+		return out
+	}
+	if underline {
+		out = "\x1b[4m" + out + "\x1b[24m"
+	}
+	if strike {
+		out = "\x1b[9m" + out + "\x1b[29m"
+	}
+	return out
+}
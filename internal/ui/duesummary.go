@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"gotask/internal/board"
+	"gotask/internal/notify"
+)
+
+// dueSummaryWidth is the widest the due summary panel gets
+const dueSummaryWidth = 60
+
+// dueSummary is the panel shown on startup listing the open tasks that
+// are overdue or due today, overdue first, each a key away
+type dueSummary struct {
+	tasks   []notify.DigestTask
+	overdue int // how many of tasks are overdue
+}
+
+// newDueSummary collects the tasks for the due summary, or returns nil if
+// nothing is overdue or due today
+func newDueSummary(b *board.Board, now time.Time) *dueSummary {
+	d := notify.NewDigest(b, now)
+	if len(d.Overdue)+len(d.DueToday) == 0 {
+		return nil
+	}
+	return &dueSummary{tasks: append(d.Overdue, d.DueToday...), overdue: len(d.Overdue)}
+}
+
+// openDueSummary shows the due summary, or says there is nothing to show
+func (m *model) openDueSummary() {
+	if m.dueSummary = newDueSummary(&m.board, time.Now()); m.dueSummary == nil {
+		m.notify("Nothing is overdue or due today")
+	}
+}
+
+// updateDueSummary handles key presses while the due summary is shown:
+// a number jumps to that task, anything else dismisses the panel
+func (m model) updateDueSummary(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	s := m.dueSummary
+	switch {
+	case key.Matches(msg, m.keys.FocusColumn):
+		i := bindingIndex(msg, m.keys.FocusColumn)
+		if i >= len(s.tasks) {
+			return m, nil
+		}
+		m.dueSummary = nil
+		if err := m.goToTask(s.tasks[i].ID); err != nil {
+			m.logError(err)
+		}
+	case key.Matches(msg, m.keys.Cancel, m.keys.Submit, m.keys.Quit):
+		m.dueSummary = nil
+	}
+	return m, nil
+}
+
+// renderDueSummary draws the due summary panel, numbering the tasks the
+// column keys jump to
+func (m model) renderDueSummary(now time.Time) string {
+	s := m.dueSummary
+	width := min(dueSummaryWidth, m.width-4)
+	keys := m.keys.FocusColumn.Keys()
+	label := lipgloss.NewStyle().Foreground(highlight).Bold(true)
+
+	var heading []string
+	if s.overdue > 0 {
+		heading = append(heading, fmt.Sprintf("%d overdue", s.overdue))
+	}
+	if today := len(s.tasks) - s.overdue; today > 0 {
+		heading = append(heading, fmt.Sprintf("%d due today", today))
+	}
+	lines := []string{label.Render(strings.Join(heading, " · ")), ""}
+	for i, t := range s.tasks {
+		when := lipgloss.NewStyle().Foreground(inProgColor).Render("today")
+		if i < s.overdue {
+			when = errorStyle.Render(board.ShortDuration(-board.DaysUntil(*t.Due, now)) + " late")
+		}
+		shortcut := " "
+		if i < len(keys) {
+			shortcut = keys[i]
+		}
+		meta := fmt.Sprintf("  %s · %s", when, t.Column)
+		title := fmt.Sprintf("%s #%d %s%s", label.Render(shortcut), t.ID, m.display.iconPrefix(t.Task), t.Title)
+		title = ansi.Truncate(title, max(10, width-lipgloss.Width(meta)), "…")
+		lines = append(lines, title+meta)
+	}
+	lines = append(lines, "", helpStyle.Render(fmt.Sprintf("%s jump to task · %s dismiss",
+		m.keys.FocusColumn.Help().Key, m.keys.Cancel.Help().Key)))
+	border := errorColor
+	if s.overdue == 0 {
+		border = inProgColor
+	}
+	return previewStyle.Copy().BorderForeground(border).Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,270 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"gotask/internal/board"
+)
+
+// editorField identifies an input on the full-screen task editor
+type editorField int
+
+const (
+	fieldTitle editorField = iota
+	fieldDescription
+	fieldDue
+	fieldPriority
+	fieldIcon
+	fieldTags
+	fieldCount
+)
+
+var editorLabels = []string{"Title", "Description", "Due", "Priority", "Icon", "Tags"}
+
+// taskIcons are offered in the editor's icon field with the up and down
+// arrows. Any other text can be typed in.
+var taskIcons = []string{"", "🐛", "✨", "📝", "🔧", "🚀", "🔥", "💡", "📦", "🎨", "🔒", "⚡"}
+
+// taskEditor is the full-screen form for editing every field of a task
+type taskEditor struct {
+	taskID      int
+	focus       editorField
+	title       textinput.Model
+	description textarea.Model
+	due         textinput.Model
+	priority    board.Priority
+	icon        textinput.Model
+	tags        textinput.Model
+	err         error
+}
+
+func newTaskEditor(task board.Task, width, height int) *taskEditor {
+	e := &taskEditor{taskID: task.ID, priority: task.Priority}
+
+	e.title = textinput.New()
+	e.title.SetValue(task.Title)
+	e.title.Width = width
+
+	e.description = textarea.New()
+	e.description.ShowLineNumbers = false
+	e.description.SetWidth(width)
+	e.description.SetHeight(max(3, height-20))
+	e.description.SetValue(task.Description)
+
+	e.due = textinput.New()
+	e.due.Placeholder = "YYYY-MM-DD, today, tomorrow, +3d"
+	e.due.SetValue(board.FormatDue(task.Due))
+	e.due.Width = width
+
+	e.icon = textinput.New()
+	e.icon.Placeholder = "emoji, or ↑/↓ to pick one"
+	e.icon.SetValue(task.Icon)
+	e.icon.Width = width
+
+	e.tags = textinput.New()
+	e.tags.Placeholder = "comma separated"
+	e.tags.SetValue(strings.Join(task.Tags, ", "))
+	e.tags.Width = width
+
+	e.setFocus(fieldTitle)
+	return e
+}
+
+// setFocus moves keyboard focus to another field
+func (e *taskEditor) setFocus(f editorField) tea.Cmd {
+	e.focus = (f + fieldCount) % fieldCount
+	e.title.Blur()
+	e.description.Blur()
+	e.due.Blur()
+	e.icon.Blur()
+	e.tags.Blur()
+
+	switch e.focus {
+	case fieldTitle:
+		return e.title.Focus()
+	case fieldDescription:
+		return e.description.Focus()
+	case fieldDue:
+		return e.due.Focus()
+	case fieldIcon:
+		return e.icon.Focus()
+	case fieldTags:
+		return e.tags.Focus()
+	}
+	return nil
+}
+
+// openEditor starts the full-screen editor for the selected task
+func (m *model) openEditor() tea.Cmd {
+	task := m.selectedTask()
+	if task == nil {
+		return nil
+	}
+	m.editor = newTaskEditor(*task, max(20, m.width-20), m.height)
+	return textinput.Blink
+}
+
+// saveEditor writes the editor's fields back to the task
+func (m *model) saveEditor() error {
+	e := m.editor
+	due, err := board.ParseDue(e.due.Value(), time.Now())
+	if err != nil {
+		return err
+	}
+	title := strings.TrimSpace(e.title.Value())
+	if title == "" {
+		return fmt.Errorf("title can't be empty")
+	}
+
+	col, idx, ok := m.board.FindTask(e.taskID)
+	if !ok {
+		return fmt.Errorf("task #%d no longer exists", e.taskID)
+	}
+	task := &m.board.Columns[col].Tasks[idx]
+
+	next := *task
+	next.Title = title
+	next.Description = e.description.Value()
+	next.Due = due
+	next.Priority = e.priority
+	next.Icon = strings.TrimSpace(e.icon.Value())
+	next.Tags = parseTags(e.tags.Value())
+	task.Update(next)
+	return m.saveBoard()
+}
+
+// updateEditor handles key presses while the full-screen editor is open
+func (m model) updateEditor(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	e := m.editor
+	switch {
+	case key.Matches(msg, m.keys.Save):
+		if err := m.saveEditor(); err != nil {
+			e.err = err
+			return m, nil
+		}
+		m.editor = nil
+		if m.searchQuery != "" {
+			m.runSearch()
+		}
+		m.refreshViewports()
+		return m, nil
+
+	case key.Matches(msg, m.keys.CancelEdit):
+		m.editor = nil
+		return m, nil
+
+	case key.Matches(msg, m.keys.NextField):
+		return m, e.setFocus(e.focus + 1)
+
+	case key.Matches(msg, m.keys.PrevField):
+		return m, e.setFocus(e.focus - 1)
+	}
+
+	var cmd tea.Cmd
+	switch e.focus {
+	case fieldTitle:
+		if key.Matches(msg, m.keys.Submit) {
+			return m, e.setFocus(e.focus + 1)
+		}
+		e.title, cmd = e.title.Update(msg)
+	case fieldDescription:
+		e.description, cmd = e.description.Update(msg)
+	case fieldDue:
+		if key.Matches(msg, m.keys.Submit) {
+			return m, e.setFocus(e.focus + 1)
+		}
+		e.due, cmd = e.due.Update(msg)
+	case fieldPriority:
+		switch {
+		case key.Matches(msg, m.keys.Left, m.keys.Down):
+			e.priority = (e.priority + board.PriorityHigh) % (board.PriorityHigh + 1)
+		case key.Matches(msg, m.keys.Right, m.keys.Up, m.keys.Select):
+			e.priority = (e.priority + 1) % (board.PriorityHigh + 1)
+		case key.Matches(msg, m.keys.Submit):
+			return m, e.setFocus(e.focus + 1)
+		}
+	case fieldIcon:
+		switch {
+		case msg.String() == "up":
+			e.cycleIcon(-1)
+		case msg.String() == "down":
+			e.cycleIcon(1)
+		case key.Matches(msg, m.keys.Submit):
+			return m, e.setFocus(e.focus + 1)
+		default:
+			e.icon, cmd = e.icon.Update(msg)
+		}
+	case fieldTags:
+		e.tags, cmd = e.tags.Update(msg)
+	}
+	return m, cmd
+}
+
+// cycleIcon replaces the icon with the next or previous one in taskIcons
+func (e *taskEditor) cycleIcon(dir int) {
+	i := 0
+	for j, icon := range taskIcons {
+		if icon == e.icon.Value() {
+			i = j
+			break
+		}
+	}
+	i = (i + dir + len(taskIcons)) % len(taskIcons)
+	e.icon.SetValue(taskIcons[i])
+	e.icon.CursorEnd()
+}
+
+// editorViewBox renders the full-screen editor form
+func (m model) editorViewBox() string {
+	e := m.editor
+	label := func(f editorField) string {
+		style := lipgloss.NewStyle().Width(13).Foreground(mutedColor)
+		if e.focus == f {
+			style = style.Foreground(highlight).Bold(true)
+		}
+		return style.Render(editorLabels[f])
+	}
+
+	var priorities []string
+	for p := board.PriorityNone; p <= board.PriorityHigh; p++ {
+		name := " " + p.String() + " "
+		if p == e.priority {
+			name = lipgloss.NewStyle().Reverse(true).Render(name)
+		}
+		priorities = append(priorities, name)
+	}
+
+	rows := []string{
+		lipgloss.JoinHorizontal(lipgloss.Top, label(fieldTitle), e.title.View()),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top, label(fieldDescription), e.description.View()),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top, label(fieldDue), e.due.View()),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top, label(fieldPriority), strings.Join(priorities, " ")),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top, label(fieldIcon), e.icon.View()),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top, label(fieldTags), e.tags.View()),
+	}
+	if e.err != nil {
+		rows = append(rows, "", errorStyle.Render("Error: "+e.err.Error()))
+	}
+
+	form := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(highlight).
+		Padding(1, 2).
+		Render(searchPromptStyle.Render(fmt.Sprintf("Edit task #%d", e.taskID)) + "\n\n" +
+			strings.Join(rows, "\n"))
+	hints := m.renderHints()
+	return form + "\n" + hints
+}
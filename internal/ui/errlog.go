@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"gotask/internal/debug"
+)
+
+// maxLogEntries is how many errors the log keeps
+const maxLogEntries = 100
+
+// logEntry is an error recorded in the error log
+type logEntry struct {
+	at  time.Time
+	err error
+}
+
+// logError records an error. The latest one shows in the status bar until
+// it is dismissed.
+func (m *model) logError(err error) {
+	if err == nil {
+		return
+	}
+	debug.Log.Error("error", "err", err)
+	m.errLog = append(m.errLog, logEntry{at: time.Now(), err: err})
+	if n := len(m.errLog) - maxLogEntries; n > 0 {
+		m.errLog = m.errLog[n:]
+		m.errSeen = max(0, m.errSeen-n)
+	}
+}
+
+// latestError returns the newest error the user hasn't dismissed yet
+func (m model) latestError() error {
+	if m.errSeen >= len(m.errLog) {
+		return nil
+	}
+	return m.errLog[len(m.errLog)-1].err
+}
+
+// dismissErrors hides the current errors from the status bar. They stay
+// in the log.
+func (m *model) dismissErrors() {
+	m.errSeen = len(m.errLog)
+}
+
+// openLog shows the error log, newest first
+func (m *model) openLog() {
+	m.showLog = true
+	m.dismissErrors()
+	m.logView = viewport.New(max(20, m.width-6), max(5, m.height-4))
+	m.logView.SetContent(m.renderLog(m.logView.Width))
+}
+
+// renderLog lists the logged errors with their times
+func (m model) renderLog(width int) string {
+	if len(m.errLog) == 0 {
+		return helpStyle.Render("No errors")
+	}
+	const stamp = "15:04:05  "
+	message := lipgloss.NewStyle().Width(max(1, width-len(stamp)))
+
+	lines := make([]string, 0, len(m.errLog))
+	for i := len(m.errLog) - 1; i >= 0; i-- {
+		e := m.errLog[i]
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top,
+			taskIDStyle.Render(e.at.Format(stamp)),
+			errorStyle.Inherit(message).Render(e.err.Error())))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// updateLog handles key presses while the error log is open
+func (m model) updateLog(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.ErrorLog, m.keys.Cancel, m.keys.Quit):
+		m.showLog = false
+	case key.Matches(msg, m.keys.ClearLog):
+		m.errLog, m.errSeen = nil, 0
+		m.logView.SetContent(m.renderLog(m.logView.Width))
+	case key.Matches(msg, m.keys.Up):
+		m.logView.LineUp(1)
+	case key.Matches(msg, m.keys.Down):
+		m.logView.LineDown(1)
+	}
+	return m, nil
+}
+
+// logViewBox renders the error log as a full-screen panel
+func (m model) logViewBox() string {
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(errorColor).
+		Padding(0, 1).
+		Render(m.logView.View())
+	return box + "\n" + m.renderHints()
+}
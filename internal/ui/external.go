@@ -0,0 +1,206 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"gotask/internal/board"
+)
+
+// externalEditorMsg is sent when the user's $EDITOR exits
+type externalEditorMsg struct {
+	taskID int
+	path   string
+	err    error
+}
+
+// editorCommand returns the user's preferred editor and its arguments
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// taskToMarkdown writes a task as Markdown with a small front matter block.
+// Subtasks become a checklist under a "## Subtasks" heading.
+func taskToMarkdown(task board.Task) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "title: %s\n", task.Title)
+	fmt.Fprintf(&b, "icon: %s\n", task.Icon)
+	fmt.Fprintf(&b, "due: %s\n", board.FormatDue(task.Due))
+	fmt.Fprintf(&b, "priority: %s\n", task.Priority)
+	fmt.Fprintf(&b, "tags: %s\n", strings.Join(task.Tags, ", "))
+	b.WriteString("---\n\n")
+
+	if task.Description != "" {
+		b.WriteString(task.Description)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n## Subtasks\n\n")
+	for _, sub := range task.Subtasks {
+		check := " "
+		if sub.Done {
+			check = "x"
+		}
+		fmt.Fprintf(&b, "- [%s] %s\n", check, sub.Title)
+	}
+	return b.String()
+}
+
+// markdownToTask reads a file written by taskToMarkdown back into task
+func markdownToTask(data string, task board.Task) (board.Task, error) {
+	scanner := bufio.NewScanner(strings.NewReader(data))
+
+	// Front matter
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == "---" {
+			break
+		}
+	}
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "---" {
+			break
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "title":
+			task.Title = value
+		case "icon":
+			task.Icon = value
+		case "due":
+			due, err := board.ParseDue(value, time.Now())
+			if err != nil {
+				return task, err
+			}
+			task.Due = due
+		case "priority":
+			p, ok := board.ParsePriority(value)
+			if !ok && value != "" {
+				return task, fmt.Errorf("unknown priority %q", value)
+			}
+			task.Priority = p
+		case "tags":
+			task.Tags = parseTags(value)
+		}
+	}
+
+	// Description, then the subtask checklist
+	var description []string
+	var subtasks []board.Subtask
+	inSubtasks := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.EqualFold(strings.TrimSpace(line), "## subtasks") {
+			inSubtasks = true
+			continue
+		}
+		if !inSubtasks {
+			description = append(description, line)
+			continue
+		}
+
+		item := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(item, "- [ ]"):
+			subtasks = append(subtasks, board.Subtask{Title: strings.TrimSpace(item[5:])})
+		case strings.HasPrefix(item, "- [x]"), strings.HasPrefix(item, "- [X]"):
+			subtasks = append(subtasks, board.Subtask{Title: strings.TrimSpace(item[5:]), Done: true})
+		case strings.HasPrefix(item, "- "):
+			subtasks = append(subtasks, board.Subtask{Title: strings.TrimSpace(item[2:])})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return task, err
+	}
+
+	task.Description = strings.TrimSpace(strings.Join(description, "\n"))
+	task.Subtasks = subtasks
+	if strings.TrimSpace(task.Title) == "" {
+		return task, fmt.Errorf("title can't be empty")
+	}
+	return task, nil
+}
+
+// openExternalEditor suspends the TUI and opens the selected task in $EDITOR
+func (m *model) openExternalEditor() tea.Cmd {
+	task := m.selectedTask()
+	if task == nil {
+		return nil
+	}
+
+	f, err := os.CreateTemp("", fmt.Sprintf("gotask-%d-*.md", task.ID))
+	if err != nil {
+		m.logError(err)
+		return nil
+	}
+	path := f.Name()
+	if _, err := f.WriteString(taskToMarkdown(*task)); err != nil {
+		f.Close()
+		os.Remove(path)
+		m.logError(err)
+		return nil
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		m.logError(err)
+		return nil
+	}
+
+	args := append(editorCommand(), path)
+	cmd := exec.Command(args[0], args[1:]...)
+	id := task.ID
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return externalEditorMsg{taskID: id, path: path, err: err}
+	})
+}
+
+// applyExternalEdit reads the edited file back and updates the task
+func (m *model) applyExternalEdit(msg externalEditorMsg) {
+	defer os.Remove(msg.path)
+	if msg.err != nil {
+		m.logError(fmt.Errorf("editor: %w", msg.err))
+		return
+	}
+
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		m.logError(err)
+		return
+	}
+	col, idx, ok := m.board.FindTask(msg.taskID)
+	if !ok {
+		m.logError(fmt.Errorf("task #%d no longer exists", msg.taskID))
+		return
+	}
+	task := &m.board.Columns[col].Tasks[idx]
+	next, err := markdownToTask(string(data), *task)
+	if err != nil {
+		m.logError(err)
+		return
+	}
+
+	task.Update(next)
+	if err := m.saveBoard(); err != nil {
+		m.logError(err)
+	}
+	if m.searchQuery != "" {
+		m.runSearch()
+	}
+	m.refreshViewports()
+}
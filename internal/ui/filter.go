@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"cmp"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"gotask/internal/board"
+	"gotask/internal/stats"
+)
+
+// taskFilter narrows the visible tasks without touching the board data
+type taskFilter struct {
+	expr        string
+	text        []string
+	tags        []string
+	priority    board.Priority
+	hasPriority bool
+	stale       bool          // only tasks gone stale
+	staleAfter  time.Duration // how long until a task is stale, 0 for the board's setting
+}
+
+func newFilterInput() textinput.Model {
+	fi := textinput.New()
+	fi.Placeholder = "tag:bug priority:high is:stale text..."
+	return fi
+}
+
+// parseFilter reads a filter expression such as "tag:bug #ui p:high login".
+// "is:stale" keeps open tasks that haven't changed in a while, and
+// "stale:30d" those unchanged for 30 days. Terms without a prefix must all
+// appear in the title or description.
+func parseFilter(expr string) taskFilter {
+	f := taskFilter{expr: strings.TrimSpace(expr)}
+	for _, term := range strings.Fields(expr) {
+		lower := strings.ToLower(term)
+		switch {
+		case strings.HasPrefix(lower, "tag:"):
+			f.tags = append(f.tags, strings.TrimPrefix(lower, "tag:"))
+		case strings.HasPrefix(lower, "#") && len(lower) > 1:
+			f.tags = append(f.tags, strings.TrimPrefix(lower, "#"))
+		case lower == "is:stale":
+			f.stale = true
+		case strings.HasPrefix(lower, "stale:"):
+			if days, err := stats.ParseDays(strings.TrimPrefix(lower, "stale:")); err == nil {
+				f.stale, f.staleAfter = true, time.Duration(days)*24*time.Hour
+				continue
+			}
+			f.text = append(f.text, lower)
+		case strings.HasPrefix(lower, "priority:"), strings.HasPrefix(lower, "p:"):
+			value := lower[strings.Index(lower, ":")+1:]
+			if p, ok := board.ParsePriority(value); ok {
+				f.priority = p
+				f.hasPriority = true
+				continue
+			}
+			f.text = append(f.text, lower)
+		default:
+			f.text = append(f.text, lower)
+		}
+	}
+	return f
+}
+
+func (f taskFilter) active() bool {
+	return len(f.text) > 0 || len(f.tags) > 0 || f.hasPriority || f.stale
+}
+
+// matches reports whether a task satisfies every term of the filter
+func (f taskFilter) matches(task board.Task) bool {
+	if f.hasPriority && task.Priority != f.priority {
+		return false
+	}
+	if f.stale && !isStale(task, task.CompletedAt != nil, f.staleAfter, time.Now()) {
+		return false
+	}
+
+	for _, want := range f.tags {
+		found := false
+		for _, tag := range task.Tags {
+			if strings.EqualFold(tag, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	haystack := strings.ToLower(task.Title + "\n" + task.Description)
+	for _, term := range f.text {
+		if !strings.Contains(haystack, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// taskVisible reports whether a task passes the active filter
+func (m model) taskVisible(task board.Task) bool {
+	return !m.filter.active() || m.filter.matches(task)
+}
+
+// nextVisible returns the index of the closest visible task after (dir > 0)
+// or before (dir < 0) the given index, or -1 if there is none
+func (m model) nextVisible(column, from, dir int) int {
+	tasks := m.board.Columns[column].Tasks
+	for i := from + dir; i >= 0 && i < len(tasks); i += dir {
+		if m.taskVisible(tasks[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// selectedTask returns the task under the cursor, or nil if the column is
+// empty or the task is hidden by the filter
+func (m *model) selectedTask() *board.Task {
+	if m.cursorColumn >= len(m.board.Columns) {
+		return nil
+	}
+	col := &m.board.Columns[m.cursorColumn]
+	if m.cursorTask < 0 || m.cursorTask >= len(col.Tasks) {
+		return nil
+	}
+	if !m.taskVisible(col.Tasks[m.cursorTask]) {
+		return nil
+	}
+	return &col.Tasks[m.cursorTask]
+}
+
+// clampCursor moves the cursor onto a visible task in the current column
+func (m *model) clampCursor() {
+	col := m.board.Columns[m.cursorColumn]
+	if m.cursorTask >= len(col.Tasks) {
+		m.cursorTask = max(0, len(col.Tasks)-1)
+	}
+	if len(col.Tasks) == 0 || m.taskVisible(col.Tasks[m.cursorTask]) {
+		return
+	}
+	if i := m.nextVisible(m.cursorColumn, m.cursorTask, 1); i >= 0 {
+		m.cursorTask = i
+	} else if i := m.nextVisible(m.cursorColumn, m.cursorTask, -1); i >= 0 {
+		m.cursorTask = i
+	}
+}
+
+// applyFilter activates a new filter expression and refreshes the board
+func (m *model) applyFilter(expr string) {
+	m.filter = parseFilter(expr)
+	if m.filter.stale && m.filter.staleAfter == 0 {
+		m.filter.staleAfter = cmp.Or(m.display.staleAfter, defaultStaleDays*24*time.Hour)
+	}
+	m.clampCursor()
+	if m.searchQuery != "" {
+		m.runSearch()
+	}
+	m.refreshViewports()
+}
+
+// updateFilter handles key presses while the filter dialog is open
+func (m model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.filtering = false
+		m.filterInput.Blur()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Submit):
+		m.filtering = false
+		m.filterInput.Blur()
+		m.applyFilter(m.filterInput.Value())
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	return m, cmd
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"gotask/internal/board"
+)
+
+// flowChartHeight is how many lines the cumulative flow diagram takes up
+const flowChartHeight = 10
+
+// renderFlow draws the cumulative flow diagram: for each day, the columns'
+// counts stacked with the last column at the bottom, so each band's
+// thickness is a column's work in progress
+func renderFlow(columns []string, counts [][]int, now time.Time, width int) string {
+	most := 0
+	for _, day := range counts {
+		total := 0
+		for _, n := range day {
+			total += n
+		}
+		most = max(most, total)
+	}
+	if most == 0 {
+		return helpStyle.Render("  No counts yet; they are noted whenever the board is saved")
+	}
+	axis := len(strconv.Itoa(most))
+	room := max(1, width-axis-3)
+	barWidth := max(1, min(3, room/len(counts)))
+	if len(counts)*barWidth > room {
+		counts = counts[len(counts)-room/barWidth:]
+	}
+	colors := columnColors(len(columns))
+	gutter := lipgloss.NewStyle().Foreground(subtle)
+
+	// band finds the column whose band covers a cell height of a day's bar
+	band := func(day []int, level int) int {
+		top := 0
+		for i := len(day) - 1; i >= 0; i-- {
+			top += day[i]
+			if level <= (top*flowChartHeight+most/2)/most {
+				return i
+			}
+		}
+		return -1
+	}
+
+	var lines []string
+	for row := flowChartHeight; row > 0; row-- {
+		label := strings.Repeat(" ", axis)
+		if row == flowChartHeight {
+			label = fmt.Sprintf("%*d", axis, most)
+		}
+		var line strings.Builder
+		line.WriteString("  " + helpStyle.Render(label) + gutter.Render("│"))
+		for _, day := range counts {
+			cell := strings.Repeat(" ", barWidth)
+			if i := band(day, row); i >= 0 && i < len(colors) {
+				cell = lipgloss.NewStyle().Foreground(colors[i]).Render(strings.Repeat("█", barWidth))
+			}
+			line.WriteString(cell)
+		}
+		lines = append(lines, line.String())
+	}
+	today := board.DayOf(now)
+	lines = append(lines, dateAxis(today.AddDate(0, 0, 1-len(counts)), today, axis, len(counts)*barWidth))
+
+	legend := make([]string, len(columns))
+	for i, title := range columns {
+		legend[i] = lipgloss.NewStyle().Foreground(colors[i]).Render("█") + " " + title
+	}
+	lines = append(lines, "  "+strings.Join(legend, "  "))
+	return strings.Join(lines, "\n")
+}
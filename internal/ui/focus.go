@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"gotask/internal/board"
+)
+
+// minFocus is the shortest focus session worth recording
+const minFocus = time.Minute
+
+// focusSession is focus mode on a task: the rest of the board is dimmed
+// and the time is logged against the task when the session ends
+type focusSession struct {
+	taskID  int
+	started time.Time
+}
+
+// toggleFocus starts focus mode on the selected task, or ends the session
+// under way
+func (m *model) toggleFocus() {
+	if m.focus != nil {
+		m.stopFocus()
+		return
+	}
+	task := m.selectedTask()
+	if task == nil {
+		return
+	}
+	m.focus = &focusSession{taskID: task.ID, started: time.Now()}
+	m.refreshViewports()
+	m.notify("Focusing on #%d", task.ID)
+}
+
+// stopFocus ends focus mode, recording the session in the task's history
+// if it lasted long enough to count
+func (m *model) stopFocus() {
+	f := m.focus
+	if f == nil {
+		return
+	}
+	m.focus = nil
+	m.refreshViewports()
+	spent := time.Since(f.started).Round(time.Second)
+	col, idx, ok := m.board.FindTask(f.taskID)
+	if !ok || spent < minFocus {
+		m.notify("Focus on #%d ended", f.taskID)
+		return
+	}
+	m.board.Columns[col].Tasks[idx].Record(board.EventFocus, "", spent.String())
+	m.save()
+	m.notify("Focused on #%d for %s", f.taskID, board.FormatSpan(spent))
+}
+
+// focusStatus shows the task in focus and for how long, e.g. "◉ #3 12m"
+func (m model) focusStatus() string {
+	if m.focus == nil {
+		return ""
+	}
+	return fmt.Sprintf("◉ #%d %s", m.focus.taskID, board.FormatSpan(time.Since(m.focus.started)))
+}
+
+// focusColumn reports whether a column holds the task in focus
+func (m model) focusColumn(col int) bool {
+	if m.focus == nil {
+		return false
+	}
+	c, _, ok := m.board.FindTask(m.focus.taskID)
+	return ok && c == col
+}
+
+// renderFocus shows the focus sessions of the last week for the
+// statistics screen, in minutes a day
+func renderFocus(totals []time.Duration, sessions int, now time.Time, width int) string {
+	if sessions == 0 {
+		return helpStyle.Render("  No focus sessions yet; start one with focus mode")
+	}
+	labels := make([]string, len(totals))
+	minutes := make([]int, len(totals))
+	var total time.Duration
+	first := board.DayOf(now).AddDate(0, 0, 1-len(totals))
+	for i, d := range totals {
+		labels[i] = first.AddDate(0, 0, i).Format("Mon")
+		minutes[i] = int(d.Minutes())
+		total += d
+	}
+	var b strings.Builder
+	b.WriteString(barChart(labels, minutes, []lipgloss.TerminalColor{highlight}, width))
+	fmt.Fprintf(&b, "\n%s", helpStyle.Render(fmt.Sprintf("  Minutes a day · %d %s, %s in all, %s on average",
+		sessions, board.Plural(sessions, "session"), board.FormatSpan(total), board.FormatSpan(total/time.Duration(sessions)))))
+	return b.String()
+}
@@ -0,0 +1,26 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"gotask/internal/stats"
+)
+
+// renderForecast shows the forecast in the statistics screen
+func renderForecast(f stats.Forecast, ok bool, now time.Time, width int) string {
+	switch {
+	case len(f.Tasks) == 0:
+		return helpStyle.Render("  No open tasks")
+	case !ok:
+		return helpStyle.Render(fmt.Sprintf("  No tasks finished in the last %d days to forecast from", f.Days))
+	}
+	lines := f.Summary(now)
+	for i, line := range lines {
+		lines[i] = ansi.Truncate("  "+line, width, "…")
+	}
+	return strings.Join(lines, "\n")
+}
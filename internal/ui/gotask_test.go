@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+	"gotask/internal/hooks"
+	"gotask/internal/storage"
+)
+
+// testModel is a board with #1 and #2 in To Do, and hooks for every
+// event, with none of them queued yet
+func testModel(t *testing.T) model {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	next, _ := configuredModel(config.Config{Board: filepath.Join(home, "board.json")}, nil, nil).
+		Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m := next.(model)
+	m.hooks = hooks.New(config.Hooks{OnAdd: []string{"true"}, OnMove: []string{"true"}, OnDelete: []string{"true"}})
+	for id, title := range []string{"Buy milk", "Wash car"} {
+		m.lastID = id + 1
+		m.board.AddTask(0, board.Task{ID: m.lastID, Title: title})
+	}
+	m.save()
+	m.pendingHooks = nil
+	return m
+}
+
+// press sends keys to the model, a rune or a named key such as "enter"
+func press(m model, keys ...string) model {
+	for _, k := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		}
+		next, _ := m.Update(msg)
+		m = next.(model)
+	}
+	return m
+}
+
+func TestInlineEditSurvivesReload(t *testing.T) {
+	m := testModel(t)
+	m.cursorColumn, m.cursorTask = 0, 0
+	m = press(m, "e")
+	m.textInput.SetValue("Buy oat milk")
+
+	// The board file changes under the open edit
+	other := m.board
+	other.Columns = append([]board.Column(nil), m.board.Columns...)
+	other.Columns[0].Tasks = append([]board.Task(nil), m.board.Columns[0].Tasks...)
+	other.Columns[0].Tasks[1].Title = "Wash the car"
+	data, err := storage.Encode(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(m.savePath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	m.applyExternal(data)
+
+	m = press(m, "enter")
+	for id, want := range map[int]string{1: "Buy oat milk", 2: "Wash the car"} {
+		col, idx, ok := m.board.FindTask(id)
+		if !ok {
+			t.Fatalf("#%d is gone", id)
+		}
+		if got := m.board.Columns[col].Tasks[idx].Title; got != want {
+			t.Errorf("#%d is titled %q, want %q", id, got, want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"gotask/internal/board"
+	"gotask/internal/stats"
+)
+
+// heatLevels draw a day's completions from none to the busiest day's
+var heatLevels = []string{"·", "░", "▒", "▓", "█"}
+
+// MaxHeatWeeks is how many weeks the heatmap covers at most
+const MaxHeatWeeks = 53
+
+// RenderHeatmap draws completions per day for the last weeks weeks as a
+// calendar, a column per week and a row per weekday, GitHub style
+func RenderHeatmap(days map[string]int, weeks int, now time.Time) string {
+	weeks = max(1, min(weeks, MaxHeatWeeks))
+	first := stats.StartOfWeek(now).AddDate(0, 0, -7*(weeks-1))
+	most := 0
+	for day := first; !day.After(now); day = day.AddDate(0, 0, 1) {
+		most = max(most, days[day.Format(board.DueLayout)])
+	}
+	done := lipgloss.NewStyle().Foreground(doneColor)
+	empty := lipgloss.NewStyle().Foreground(subtle)
+
+	// Month names over the weeks they start in
+	var months strings.Builder
+	months.WriteString("      ")
+	for w := 0; w < weeks; w++ {
+		monday := first.AddDate(0, 0, 7*w)
+		if monday.Day() <= 7 && months.Len() <= 6+2*w {
+			months.WriteString(monday.Format("Jan") + " ")
+		} else if months.Len() <= 6+2*w {
+			months.WriteString("  ")
+		}
+	}
+	lines := []string{helpStyle.Render(strings.TrimRight(months.String(), " "))}
+
+	for weekday := 0; weekday < 7; weekday++ {
+		label := "   "
+		if weekday%2 == 0 && weekday < 6 {
+			label = first.AddDate(0, 0, weekday).Format("Mon")
+		}
+		var line strings.Builder
+		line.WriteString("  " + helpStyle.Render(label) + " ")
+		for w := 0; w < weeks; w++ {
+			day := first.AddDate(0, 0, 7*w+weekday)
+			if day.After(now) {
+				break
+			}
+			n := days[day.Format(board.DueLayout)]
+			if n == 0 {
+				line.WriteString(empty.Render(heatLevels[0]) + " ")
+				continue
+			}
+			level := min(len(heatLevels)-1, max(1, (n*(len(heatLevels)-1)+most-1)/most))
+			line.WriteString(done.Render(heatLevels[level]) + " ")
+		}
+		lines = append(lines, strings.TrimRight(line.String(), " "))
+	}
+
+	total := 0
+	for day := first; !day.After(now); day = day.AddDate(0, 0, 1) {
+		total += days[day.Format(board.DueLayout)]
+	}
+	current, longest := stats.Streaks(days, first, now)
+	legend := make([]string, len(heatLevels))
+	for i, glyph := range heatLevels {
+		legend[i] = done.Render(glyph)
+	}
+	lines = append(lines, "", fmt.Sprintf("  %d %s · current streak %d %s · longest %d %s   less %s more",
+		total, board.Plural(total, "completion"), current, board.Plural(current, "day"), longest, board.Plural(longest, "day"),
+		strings.Join(legend, " ")))
+	return strings.Join(lines, "\n")
+}
+
+// heatWeeks is how many weeks of heatmap fit in width
+func heatWeeks(width int) int {
+	return max(1, min(MaxHeatWeeks, (width-6)/2))
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openHelp shows the full-screen list of key bindings
+func (m *model) openHelp() {
+	m.showHelp = true
+	m.helpView = viewport.New(max(20, m.width-6), max(5, m.height-4))
+	m.helpView.SetContent(m.renderHelp(m.helpView.Width))
+}
+
+// renderHelp lays out the help sections in as many columns as fit
+func (m model) renderHelp(width int) string {
+	heading := lipgloss.NewStyle().Foreground(highlight).Bold(true).Underline(true)
+	keyStyle := lipgloss.NewStyle().Foreground(special)
+
+	var blocks []string
+	for _, section := range m.keys.helpSections() {
+		keyWidth := 0
+		for _, b := range section.keys {
+			keyWidth = max(keyWidth, lipgloss.Width(b.Help().Key))
+		}
+
+		lines := []string{heading.Render(section.title)}
+		for _, b := range section.keys {
+			if !b.Enabled() {
+				continue
+			}
+			k := b.Help().Key
+			lines = append(lines, keyStyle.Render(k)+strings.Repeat(" ", keyWidth-lipgloss.Width(k)+2)+b.Help().Desc)
+		}
+		blocks = append(blocks, lipgloss.NewStyle().PaddingRight(4).PaddingBottom(1).Render(strings.Join(lines, "\n")))
+	}
+
+	// Every block gets the width of the widest one so the grid lines up
+	blockWidth := 0
+	for _, block := range blocks {
+		blockWidth = max(blockWidth, lipgloss.Width(block))
+	}
+	perRow := max(1, width/blockWidth)
+
+	var rows []string
+	for i := 0; i < len(blocks); i += perRow {
+		row := blocks[i:min(i+perRow, len(blocks))]
+		for j := range row {
+			row[j] = lipgloss.NewStyle().Width(blockWidth).Render(row[j])
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// updateHelp handles key presses while the help screen is open
+func (m model) updateHelp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Help, m.keys.Cancel, m.keys.Quit):
+		m.showHelp = false
+	case key.Matches(msg, m.keys.Up):
+		m.helpView.LineUp(1)
+	case key.Matches(msg, m.keys.Down):
+		m.helpView.LineDown(1)
+	}
+	return m, nil
+}
+
+// helpViewBox renders the help screen as a full-screen panel
+func (m model) helpViewBox() string {
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(highlight).
+		Padding(0, 1).
+		Render(m.helpView.View())
+	return box + "\n" + m.renderHints()
+}
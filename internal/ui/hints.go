@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// relabel returns a copy of b described differently, e.g. enter reads
+// "search" at the search prompt rather than "save"
+func relabel(b key.Binding, desc string) key.Binding {
+	b.SetHelp(b.Help().Key, desc)
+	return b
+}
+
+// contextHints returns the keys that do something in the current mode,
+// for the hint line at the bottom of the screen
+func (m model) contextHints() []key.Binding {
+	k := m.keys
+	switch {
+	case m.editor != nil:
+		return []key.Binding{k.NextField, k.PrevField, k.Save, k.CancelEdit}
+	case m.showDetail:
+		return []key.Binding{k.Up, k.Down, relabel(k.Cancel, "close")}
+	case m.showHelp:
+		return []key.Binding{k.Up, k.Down, relabel(k.Help, "close")}
+	case m.calendar != nil:
+		prev, next := k.PrevPeriod, k.NextPeriod
+		if m.calendar.week {
+			prev, next = relabel(prev, "previous week"), relabel(next, "next week")
+		}
+		return []key.Binding{k.Left, k.Right, k.Up, k.Down, prev, next, k.CalendarWeek, k.Today,
+			relabel(k.Detail, "go to task"), relabel(k.Calendar, "close")}
+	case m.showStats:
+		return []key.Binding{k.Up, k.Down, relabel(k.PrevPeriod, "shorter burnup"), relabel(k.NextPeriod, "longer burnup"), relabel(k.Stats, "close")}
+	case m.review != nil && m.review.rescheduling:
+		return []key.Binding{relabel(k.Submit, "reschedule"), k.Cancel}
+	case m.review != nil && m.review.index == len(m.review.ids):
+		return []key.Binding{relabel(k.Keep, "save"), relabel(k.Left, "back"), relabel(k.Cancel, "save")}
+	case m.review != nil:
+		return []key.Binding{k.Keep, k.Reschedule, k.Archive, relabel(k.FocusColumn, "move to column"), relabel(k.Left, "back"), relabel(k.Right, "skip"), relabel(k.Cancel, "finish")}
+	case m.archive != nil && m.archive.searching:
+		return []key.Binding{relabel(k.Submit, "done"), relabel(k.Cancel, "clear")}
+	case m.archive != nil:
+		return []key.Binding{k.Up, k.Down, k.Restore, relabel(k.Search, "search"), relabel(k.PrevPeriod, "shorter period"), relabel(k.NextPeriod, "longer period"), relabel(k.Archived, "close")}
+	case m.showActivity:
+		return []key.Binding{k.Up, k.Down, k.HalfPageDown, k.HalfPageUp, relabel(k.Activity, "close")}
+	case m.showLog:
+		return []key.Binding{k.Up, k.Down, k.ClearLog, relabel(k.ErrorLog, "close")}
+	case m.dialogType == confirmDialog:
+		return []key.Binding{k.Confirm, k.Deny}
+	case m.dueSummary != nil:
+		return []key.Binding{relabel(k.FocusColumn, "jump to task"), relabel(k.Cancel, "dismiss")}
+	case m.inputMode && m.inputState == insertMode:
+		return []key.Binding{k.Submit, relabel(k.Cancel, "normal mode")}
+	case m.inputMode:
+		return []key.Binding{k.Insert, k.Submit, k.Cancel, k.Help}
+	case m.commanding:
+		return []key.Binding{relabel(k.Submit, "run"), k.Cancel}
+	case m.searching:
+		return []key.Binding{relabel(k.Submit, "search"), k.AlsoArchive, k.Cancel}
+	case m.filtering:
+		return []key.Binding{relabel(k.Submit, "apply"), k.Cancel}
+	case m.drag != nil && m.drag.moved:
+		return nil
+	case m.visual:
+		return []key.Binding{k.Up, k.Down, k.Yank, k.Cut, relabel(k.Visual, "keep selection"), k.Cancel}
+	case len(m.marked) > 0:
+		return []key.Binding{k.MoveLeft, k.MoveRight, k.Delete, k.Yank, k.Cut, k.Tag, k.Archive, relabel(k.Cancel, "clear selection")}
+	case m.searchQuery != "" && len(m.archiveHits) > 0:
+		return []key.Binding{k.NextMatch, k.PrevMatch, relabel(k.Archived, "open archive matches"), relabel(k.Cancel, "clear search"), k.Help}
+	case m.searchQuery != "":
+		return []key.Binding{k.NextMatch, k.PrevMatch, relabel(k.Cancel, "clear search"), k.Help}
+	}
+	return k.ShortHelp()
+}
+
+// renderHints draws the hint line for the current mode
+func (m model) renderHints() string {
+	return m.help.ShortHelpView(m.contextHints())
+}
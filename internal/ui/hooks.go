@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"encoding/json"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"gotask/internal/board"
+)
+
+// hooksDoneMsg reports hooks that failed in the TUI
+type hooksDoneMsg struct {
+	err error
+}
+
+// queueHooks notes the events between the last saved board and the
+// current one, for startHooks
+func (m *model) queueHooks() {
+	if !m.hooks.Enabled() || m.savedData == nil {
+		return
+	}
+	var before board.Board
+	if err := json.Unmarshal(m.savedData, &before); err != nil {
+		m.logError(err)
+		return
+	}
+	changes := m.holdCut(board.Diff(&before, &m.board))
+	m.pendingHooks = append(m.pendingHooks, m.hooks.Events(changes, &m.board)...)
+}
+
+// holdCut holds back the removal of tasks cut to the clipboard, and turns
+// their paste into a move, or into nothing if they're pasted where they
+// were, so that cutting and pasting a task doesn't run delete and add hooks
+func (m *model) holdCut(changes []board.Change) []board.Change {
+	cut := make(map[int]bool)
+	if m.clipboard.cut {
+		for _, task := range m.clipboard.tasks {
+			cut[task.ID] = true
+		}
+	}
+	var kept []board.Change
+	for _, c := range changes {
+		held, pasted := m.cutHooks[c.ID]
+		switch {
+		case c.Kind == board.ChangeRemoved && cut[c.ID]:
+			if m.cutHooks == nil {
+				m.cutHooks = make(map[int]board.Change)
+			}
+			m.cutHooks[c.ID] = c
+			continue
+		case c.Kind == board.ChangeAdded && pasted:
+			delete(m.cutHooks, c.ID)
+			if held.Column == c.Column {
+				continue
+			}
+			c.Kind, c.From = board.ChangeMoved, held.Column
+		}
+		kept = append(kept, c)
+	}
+	return kept
+}
+
+// releaseCut queues the delete hooks held back for cut tasks, once they
+// can no longer be pasted
+func (m *model) releaseCut() {
+	if len(m.cutHooks) == 0 {
+		return
+	}
+	changes := make([]board.Change, 0, len(m.cutHooks))
+	for _, c := range m.cutHooks {
+		changes = append(changes, c)
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].ID < changes[j].ID })
+	m.pendingHooks = append(m.pendingHooks, m.hooks.Events(changes, &m.board)...)
+	m.cutHooks = nil
+}
+
+// startHooks runs the queued hooks in the background
+func (m *model) startHooks() tea.Cmd {
+	if len(m.pendingHooks) == 0 {
+		return nil
+	}
+	h, events := m.hooks, m.pendingHooks
+	m.pendingHooks = nil
+	return func() tea.Msg {
+		return hooksDoneMsg{h.Run(events)}
+	}
+}
@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// goToTask moves the cursor onto the task with the given ID in any column
+func (m *model) goToTask(id int) error {
+	col, idx, ok := m.board.FindTask(id)
+	if !ok {
+		return fmt.Errorf("no task #%d", id)
+	}
+	if !m.taskVisible(m.board.Columns[col].Tasks[idx]) {
+		return fmt.Errorf("task #%d is hidden by the filter", id)
+	}
+	m.rememberCursor()
+	m.cursorColumn = col
+	m.cursorTask = idx
+	m.refreshViewports()
+	return nil
+}
+
+// bindingIndex returns the position of the pressed key among a binding's
+// keys, e.g. 2 for "3" in the column keys, or -1 if it isn't one of them
+func bindingIndex(msg tea.KeyMsg, b key.Binding) int {
+	for i, k := range b.Keys() {
+		if msg.String() == k {
+			return i
+		}
+	}
+	return -1
+}
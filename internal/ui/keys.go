@@ -0,0 +1,396 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+
+	"gotask/internal/config"
+)
+
+// keyMap holds every key binding in the app so they can be remapped from the
+// config file and the help text can be generated from the active bindings
+type keyMap struct {
+	// Navigation
+	Up           key.Binding
+	Down         key.Binding
+	Left         key.Binding
+	Right        key.Binding
+	Top          key.Binding
+	Bottom       key.Binding
+	HalfPageDown key.Binding
+	HalfPageUp   key.Binding
+	FocusColumn  key.Binding
+
+	// Tasks
+	Add           key.Binding
+	New           key.Binding
+	Edit          key.Binding
+	FullEdit      key.Binding
+	Detail        key.Binding
+	Preview       key.Binding
+	Density       key.Binding
+	ToggleTags    key.Binding
+	Split         key.Binding
+	Pomodoro      key.Binding
+	Focus         key.Binding
+	ExternalEdit  key.Binding
+	Delete        key.Binding
+	Yank          key.Binding
+	Cut           key.Binding
+	Paste         key.Binding
+	PasteBefore   key.Binding
+	MoveLeft      key.Binding
+	MoveRight     key.Binding
+	SendToColumn  key.Binding
+	Tag           key.Binding
+	Archive       key.Binding
+	RaisePriority key.Binding
+	LowerPriority key.Binding
+	Repeat        key.Binding
+	Select        key.Binding
+	Visual        key.Binding
+	Undo          key.Binding
+	Redo          key.Binding
+
+	// Search and filter
+	Search      key.Binding
+	NextMatch   key.Binding
+	PrevMatch   key.Binding
+	Filter      key.Binding
+	ClearFilter key.Binding
+	AlsoArchive key.Binding
+
+	// Dialogs and text input
+	Confirm key.Binding
+	Deny    key.Binding
+	Submit  key.Binding
+	Insert  key.Binding
+	Cancel  key.Binding
+
+	// Calendar
+	Calendar     key.Binding
+	CalendarWeek key.Binding
+	PrevPeriod   key.Binding
+	NextPeriod   key.Binding
+	Today        key.Binding
+
+	// Statistics
+	Stats    key.Binding
+	Activity key.Binding
+
+	// Weekly review
+	Review     key.Binding
+	Keep       key.Binding
+	Reschedule key.Binding
+
+	// Archive explorer
+	Archived key.Binding
+	Restore  key.Binding
+
+	// Full-screen editor
+	NextField  key.Binding
+	PrevField  key.Binding
+	Save       key.Binding
+	CancelEdit key.Binding
+
+	// General
+	GoTo     key.Binding
+	ErrorLog key.Binding
+	ClearLog key.Binding
+	Command  key.Binding
+	Help     key.Binding
+	Quit     key.Binding
+}
+
+func defaultKeyMap() keyMap {
+	return keyMap{
+		Up:           key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+		Down:         key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+		Left:         key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←/h", "left")),
+		Right:        key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→/l", "right")),
+		Top:          key.NewBinding(key.WithKeys("g"), key.WithHelp("gg", "first task")),
+		Bottom:       key.NewBinding(key.WithKeys("G"), key.WithHelp("G", "last task")),
+		HalfPageDown: key.NewBinding(key.WithKeys("ctrl+d", "pgdown"), key.WithHelp("ctrl+d", "half page down")),
+		HalfPageUp:   key.NewBinding(key.WithKeys("ctrl+u", "pgup"), key.WithHelp("ctrl+u", "half page up")),
+		FocusColumn:  key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"), key.WithHelp("1-9", "focus column")),
+
+		Add:           key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "add task")),
+		New:           key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "add task (normal mode)")),
+		Edit:          key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit title")),
+		FullEdit:      key.NewBinding(key.WithKeys("E"), key.WithHelp("E", "edit all fields")),
+		ExternalEdit:  key.NewBinding(key.WithKeys("ctrl+e"), key.WithHelp("ctrl+e", "edit in $EDITOR")),
+		Detail:        key.NewBinding(key.WithKeys("enter", "o"), key.WithHelp("enter/o", "task details")),
+		Preview:       key.NewBinding(key.WithKeys("K"), key.WithHelp("K", "toggle preview")),
+		Density:       key.NewBinding(key.WithKeys("z"), key.WithHelp("z", "compact view")),
+		ToggleTags:    key.NewBinding(key.WithKeys("T"), key.WithHelp("T", "show/hide tags")),
+		Split:         key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "split view")),
+		Pomodoro:      key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "start/stop pomodoro")),
+		Focus:         key.NewBinding(key.WithKeys("Z"), key.WithHelp("Z", "focus mode")),
+		Delete:        key.NewBinding(key.WithKeys("D", "delete"), key.WithHelp("D", "delete task")),
+		Yank:          key.NewBinding(key.WithKeys("y"), key.WithHelp("yy", "yank task")),
+		Cut:           key.NewBinding(key.WithKeys("d"), key.WithHelp("dd", "cut task")),
+		Paste:         key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "paste after")),
+		PasteBefore:   key.NewBinding(key.WithKeys("P"), key.WithHelp("P", "paste before")),
+		MoveLeft:      key.NewBinding(key.WithKeys("[", "{"), key.WithHelp("[", "move task left")),
+		MoveRight:     key.NewBinding(key.WithKeys("]", "}"), key.WithHelp("]", "move task right")),
+		SendToColumn:  key.NewBinding(key.WithKeys("!", "@", "#", "$", "%", "^", "&", "*", "("), key.WithHelp("shift+1-9", "send task to column")),
+		Tag:           key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "tag")),
+		Archive:       key.NewBinding(key.WithKeys("A"), key.WithHelp("A", "archive")),
+		RaisePriority: key.NewBinding(key.WithKeys("+", "="), key.WithHelp("+", "raise priority")),
+		LowerPriority: key.NewBinding(key.WithKeys("-"), key.WithHelp("-", "lower priority")),
+		Repeat:        key.NewBinding(key.WithKeys("."), key.WithHelp(".", "repeat last change")),
+		Select:        key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "select")),
+		Visual:        key.NewBinding(key.WithKeys("V"), key.WithHelp("V", "select range")),
+		Undo:          key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "undo")),
+		Redo:          key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "redo")),
+
+		Search:      key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
+		NextMatch:   key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "next match")),
+		PrevMatch:   key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "previous match")),
+		Filter:      key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "filter")),
+		ClearFilter: key.NewBinding(key.WithKeys("F"), key.WithHelp("F", "clear filter")),
+		AlsoArchive: key.NewBinding(key.WithKeys("ctrl+a"), key.WithHelp("ctrl+a", "search the archive too")),
+
+		Confirm: key.NewBinding(key.WithKeys("y", "Y"), key.WithHelp("y", "confirm")),
+		Deny:    key.NewBinding(key.WithKeys("n", "N", "esc", "q", "ctrl+c"), key.WithHelp("n", "cancel")),
+		Submit:  key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "save")),
+		Insert:  key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "insert mode")),
+		Cancel:  key.NewBinding(key.WithKeys("esc", "ctrl+c"), key.WithHelp("esc", "cancel")),
+
+		Calendar:     key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "calendar")),
+		CalendarWeek: key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "week/month")),
+		PrevPeriod:   key.NewBinding(key.WithKeys("["), key.WithHelp("[", "previous month")),
+		NextPeriod:   key.NewBinding(key.WithKeys("]"), key.WithHelp("]", "next month")),
+		Today:        key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "today")),
+
+		Stats:    key.NewBinding(key.WithKeys("I"), key.WithHelp("I", "statistics")),
+		Activity: key.NewBinding(key.WithKeys("H"), key.WithHelp("H", "activity")),
+
+		Review:     key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "weekly review")),
+		Keep:       key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "keep")),
+		Reschedule: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "reschedule")),
+
+		Archived: key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "browse archive")),
+		Restore:  key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "restore")),
+
+		NextField:  key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next field")),
+		PrevField:  key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "previous field")),
+		Save:       key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save")),
+		CancelEdit: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "discard")),
+
+		GoTo:     key.NewBinding(key.WithKeys("ctrl+g"), key.WithHelp("ctrl+g", "go to task")),
+		ErrorLog: key.NewBinding(key.WithKeys("L"), key.WithHelp("L", "error log")),
+		ClearLog: key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "clear log")),
+		Command:  key.NewBinding(key.WithKeys(":"), key.WithHelp(":", "command")),
+		Help:     key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "toggle help")),
+		Quit:     key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+	}
+}
+
+// arrowsKeyMap is the default map without the vim letters for navigation,
+// for people who prefer the arrow keys
+func arrowsKeyMap() keyMap {
+	k := defaultKeyMap()
+	k.Up = key.NewBinding(key.WithKeys("up"), key.WithHelp("↑", "up"))
+	k.Down = key.NewBinding(key.WithKeys("down"), key.WithHelp("↓", "down"))
+	k.Left = key.NewBinding(key.WithKeys("left"), key.WithHelp("←", "left"))
+	k.Right = key.NewBinding(key.WithKeys("right"), key.WithHelp("→", "right"))
+	k.MoveLeft = key.NewBinding(key.WithKeys("shift+left", "["), key.WithHelp("shift+←", "move task left"))
+	k.MoveRight = key.NewBinding(key.WithKeys("shift+right", "]"), key.WithHelp("shift+→", "move task right"))
+	return k
+}
+
+// actions maps the action names used in the config file to their bindings
+func (k *keyMap) actions() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":             &k.Up,
+		"down":           &k.Down,
+		"left":           &k.Left,
+		"right":          &k.Right,
+		"top":            &k.Top,
+		"bottom":         &k.Bottom,
+		"half_page_down": &k.HalfPageDown,
+		"half_page_up":   &k.HalfPageUp,
+		"focus_column":   &k.FocusColumn,
+		"add":            &k.Add,
+		"new":            &k.New,
+		"edit":           &k.Edit,
+		"full_edit":      &k.FullEdit,
+		"editor":         &k.ExternalEdit,
+		"detail":         &k.Detail,
+		"preview":        &k.Preview,
+		"density":        &k.Density,
+		"toggle_tags":    &k.ToggleTags,
+		"split":          &k.Split,
+		"pomodoro":       &k.Pomodoro,
+		"focus":          &k.Focus,
+		"delete":         &k.Delete,
+		"yank":           &k.Yank,
+		"cut":            &k.Cut,
+		"paste":          &k.Paste,
+		"paste_before":   &k.PasteBefore,
+		"move_left":      &k.MoveLeft,
+		"move_right":     &k.MoveRight,
+		"send_to_column": &k.SendToColumn,
+		"tag":            &k.Tag,
+		"archive":        &k.Archive,
+		"raise_priority": &k.RaisePriority,
+		"lower_priority": &k.LowerPriority,
+		"repeat":         &k.Repeat,
+		"select":         &k.Select,
+		"visual":         &k.Visual,
+		"undo":           &k.Undo,
+		"redo":           &k.Redo,
+		"search":         &k.Search,
+		"next_match":     &k.NextMatch,
+		"prev_match":     &k.PrevMatch,
+		"filter":         &k.Filter,
+		"clear_filter":   &k.ClearFilter,
+		"also_archive":   &k.AlsoArchive,
+		"confirm":        &k.Confirm,
+		"deny":           &k.Deny,
+		"submit":         &k.Submit,
+		"insert":         &k.Insert,
+		"cancel":         &k.Cancel,
+		"calendar":       &k.Calendar,
+		"calendar_week":  &k.CalendarWeek,
+		"prev_period":    &k.PrevPeriod,
+		"next_period":    &k.NextPeriod,
+		"today":          &k.Today,
+		"stats":          &k.Stats,
+		"activity":       &k.Activity,
+		"review":         &k.Review,
+		"keep":           &k.Keep,
+		"reschedule":     &k.Reschedule,
+		"archived":       &k.Archived,
+		"restore":        &k.Restore,
+		"next_field":     &k.NextField,
+		"prev_field":     &k.PrevField,
+		"save":           &k.Save,
+		"cancel_edit":    &k.CancelEdit,
+		"goto":           &k.GoTo,
+		"error_log":      &k.ErrorLog,
+		"clear_log":      &k.ClearLog,
+		"command":        &k.Command,
+		"help":           &k.Help,
+		"quit":           &k.Quit,
+	}
+}
+
+// newKeyMap builds the key map for a profile and applies the overrides
+// from the config file
+func newKeyMap(cfg config.Keys) (keyMap, error) {
+	var k keyMap
+	switch cfg.Profile {
+	case "", "vim":
+		k = defaultKeyMap()
+	case "arrows":
+		k = arrowsKeyMap()
+	default:
+		return defaultKeyMap(), fmt.Errorf("unknown key profile %q", cfg.Profile)
+	}
+
+	actions := k.actions()
+	names := make([]string, 0, len(cfg.Bindings))
+	for name := range cfg.Bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var unknown []string
+	for _, name := range names {
+		binding, ok := actions[name]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		keys := cfg.Bindings[name]
+		if len(keys) == 0 {
+			binding.Unbind()
+			continue
+		}
+		binding.SetKeys(keys...)
+		help := helpKeys(keys)
+		if slices.Contains(k.sequences(), binding) {
+			help = helpKeys(doubled(keys))
+		}
+		binding.SetHelp(help, binding.Help().Desc)
+	}
+
+	if len(unknown) > 0 {
+		return k, fmt.Errorf("unknown key actions in config: %s", strings.Join(unknown, ", "))
+	}
+	return k, nil
+}
+
+// sequences returns the bindings that are pressed twice in a row, like yy
+func (k *keyMap) sequences() []*key.Binding {
+	return []*key.Binding{&k.Yank, &k.Cut, &k.Top}
+}
+
+// doubled repeats each key for the help text of a two-key command
+func doubled(keys []string) []string {
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		out[i] = k + k
+	}
+	return out
+}
+
+// helpKeys formats a list of keys for the help text
+func helpKeys(keys []string) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		if k == " " {
+			k = "space"
+		}
+		names[i] = k
+	}
+	return strings.Join(names, "/")
+}
+
+// ShortHelp returns the bindings shown in the help line on the board
+func (k keyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.Add, k.Edit, k.Detail, k.Delete, k.MoveLeft, k.MoveRight, k.Search,
+		k.Filter, k.Select, k.Undo, k.Help, k.Quit,
+	}
+}
+
+// helpSection is a titled group of bindings on the help screen
+type helpSection struct {
+	title string
+	keys  []key.Binding
+}
+
+// helpSections returns every binding grouped by category
+func (k keyMap) helpSections() []helpSection {
+	return []helpSection{
+		{"Navigation", []key.Binding{k.Up, k.Down, k.Left, k.Right, k.Top, k.Bottom, k.HalfPageDown, k.HalfPageUp, k.FocusColumn}},
+		{"Tasks", []key.Binding{k.Add, k.New, k.Edit, k.FullEdit, k.ExternalEdit, k.Detail, k.Preview, k.Density, k.ToggleTags, k.Split, k.Calendar, k.Stats, k.Activity, k.Review, k.Archived, k.Pomodoro, k.Focus, k.Delete, k.MoveLeft, k.MoveRight, k.SendToColumn, k.Tag, k.Archive, k.RaisePriority, k.LowerPriority, k.Repeat}},
+		{"Clipboard", []key.Binding{k.Yank, k.Cut, k.Paste, k.PasteBefore}},
+		{"Selection & history", []key.Binding{k.Select, k.Visual, k.Undo, k.Redo}},
+		{"Search & filter", []key.Binding{k.Search, k.AlsoArchive, k.NextMatch, k.PrevMatch, k.Filter, k.ClearFilter}},
+		{"Adding & editing", []key.Binding{k.Submit, k.Cancel, k.Insert}},
+		{"Calendar", []key.Binding{k.CalendarWeek, k.PrevPeriod, k.NextPeriod, k.Today}},
+		{"Weekly review", []key.Binding{k.Keep, k.Reschedule, k.Archive, relabel(k.FocusColumn, "move to column"), relabel(k.Left, "previous task"), relabel(k.Right, "next task")}},
+		{"Archive explorer", []key.Binding{k.Restore, relabel(k.Search, "search archive"), relabel(k.PrevPeriod, "shorter period"), relabel(k.NextPeriod, "longer period")}},
+		{"Task editor", []key.Binding{k.NextField, k.PrevField, k.Save, k.CancelEdit}},
+		{"Confirmation", []key.Binding{k.Confirm, k.Deny}},
+		{"General", []key.Binding{k.GoTo, k.Command, k.ErrorLog, k.ClearLog, k.Help, k.Quit}},
+	}
+}
+
+// FullHelp returns every binding grouped by category
+func (k keyMap) FullHelp() [][]key.Binding {
+	sections := k.helpSections()
+	groups := make([][]key.Binding, len(sections))
+	for i, section := range sections {
+		groups[i] = section.keys
+	}
+	return groups
+}
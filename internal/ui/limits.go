@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+	"gotask/internal/stats"
+)
+
+// limitSettings are the parsed limits, keyed by lowercase column title
+type limitSettings struct {
+	wip    map[string]int
+	maxAge map[string]time.Duration
+}
+
+// newLimitSettings checks the limits from the config file
+func newLimitSettings(cfg config.Limits) (limitSettings, error) {
+	s := limitSettings{wip: make(map[string]int), maxAge: make(map[string]time.Duration)}
+	var errs []string
+	for column, n := range cfg.WIP {
+		if n <= 0 {
+			errs = append(errs, fmt.Sprintf("limits.wip %q: must be at least 1, not %d", column, n))
+			continue
+		}
+		s.wip[strings.ToLower(column)] = n
+	}
+	for column, age := range cfg.MaxAge {
+		days, err := stats.ParseDays(age)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("limits.max_age %q: %v", column, err))
+			continue
+		}
+		s.maxAge[strings.ToLower(column)] = time.Duration(days) * 24 * time.Hour
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return s, errors.New(strings.Join(errs, "; "))
+	}
+	return s, nil
+}
+
+// violations describes where the board breaks its limits, column by
+// column, e.g. "In Progress 5/3" or "2 in In Progress over 5d"
+func (s limitSettings) violations(b *board.Board, now time.Time) []string {
+	var out []string
+	for _, col := range b.Columns {
+		name := strings.ToLower(col.Title)
+		if limit, ok := s.wip[name]; ok && len(col.Tasks) > limit {
+			out = append(out, fmt.Sprintf("%s %d/%d", col.Title, len(col.Tasks), limit))
+		}
+		if age, ok := s.maxAge[name]; ok {
+			old := 0
+			for _, task := range col.Tasks {
+				if now.Sub(task.EnteredColumn()) > age {
+					old++
+				}
+			}
+			if old > 0 {
+				out = append(out, fmt.Sprintf("%d in %s over %s", old, col.Title, board.ShortDuration(int(age.Hours()/24))))
+			}
+		}
+	}
+	return out
+}
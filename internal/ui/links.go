@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+	"gotask/internal/httpjson"
+)
+
+// issueLink is a GitHub or GitLab issue, pull request, or merge request
+// found in pasted text
+type issueLink struct {
+	kind    string // "github" or "gitlab"
+	url     *url.URL
+	project string // e.g. "owner/repo" or "group/subgroup/project"
+	number  int
+	pull    bool // a pull or merge request rather than an issue
+}
+
+// parseIssueLink recognizes text that is just an issue or pull request
+// URL: github.com/owner/repo/issues/N or /pull/N, or a GitLab
+// .../project/-/issues/N or /-/merge_requests/N on any host
+func parseIssueLink(text string) (issueLink, bool) {
+	text = strings.TrimSpace(text)
+	if strings.ContainsAny(text, " \t\n") {
+		return issueLink{}, false
+	}
+	u, err := url.Parse(text)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return issueLink{}, false
+	}
+	u.Fragment, u.RawQuery = "", ""
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 {
+		return issueLink{}, false
+	}
+	number, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil || number <= 0 {
+		return issueLink{}, false
+	}
+	kind := parts[len(parts)-2]
+
+	if u.Host == "github.com" || u.Host == "www.github.com" {
+		if len(parts) != 4 || (kind != "issues" && kind != "pull") {
+			return issueLink{}, false
+		}
+		return issueLink{kind: "github", url: u, project: parts[0] + "/" + parts[1], number: number, pull: kind == "pull"}, true
+	}
+	if parts[len(parts)-3] != "-" || (kind != "issues" && kind != "merge_requests") || len(parts) < 5 {
+		return issueLink{}, false
+	}
+	project := strings.Join(parts[:len(parts)-3], "/")
+	return issueLink{kind: "gitlab", url: u, project: project, number: number, pull: kind == "merge_requests"}, true
+}
+
+// source records where the task came from. GitLab issues get the ID
+// "gotask sync gitlab" uses, so they sync like imported ones; merge
+// requests are written with "!" as GitLab does.
+func (l issueLink) source() *board.TaskSource {
+	sep := "#"
+	if l.pull && l.kind == "gitlab" {
+		sep = "!"
+	}
+	return &board.TaskSource{Kind: l.kind, ID: l.project + sep + strconv.Itoa(l.number), URL: l.url.String()}
+}
+
+// title fetches the title of the issue or pull request
+func (l issueLink) title() (string, error) {
+	var u, header, token string
+	switch l.kind {
+	case "github":
+		// The issues endpoint serves pull requests too
+		u = fmt.Sprintf("https://api.github.com/repos/%s/issues/%d", l.project, l.number)
+		header, token = "Authorization", os.Getenv("GITHUB_TOKEN")
+		if token != "" {
+			token = "Bearer " + token
+		}
+	case "gitlab":
+		kind := "issues"
+		if l.pull {
+			kind = "merge_requests"
+		}
+		u = fmt.Sprintf("%s://%s/api/v4/projects/%s/%s/%d", l.url.Scheme, l.url.Host, url.PathEscape(l.project), kind, l.number)
+		header, token = "PRIVATE-TOKEN", l.gitlabToken()
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	if token != "" {
+		req.Header.Set(header, token)
+	}
+	var item struct {
+		Title string `json:"title"`
+	}
+	if _, err := httpjson.Fetch(req, &item); err != nil {
+		return "", err
+	}
+	if item.Title == "" {
+		return "", fmt.Errorf("%s has no title", l.url)
+	}
+	return item.Title, nil
+}
+
+// gitlabToken is the GitLab token to send, which is only sent to the
+// instance it was configured for
+func (l issueLink) gitlabToken() string {
+	cfg, _ := config.Load()
+	base, err := url.Parse(cmp.Or(cfg.GitLab.URL, "https://gitlab.com"))
+	if err != nil || base.Host != l.url.Host {
+		return ""
+	}
+	return cmp.Or(os.Getenv("GITLAB_TOKEN"), cfg.GitLab.Token)
+}
+
+// linkTitleMsg carries the title fetched for a pasted link
+type linkTitleMsg struct {
+	link   issueLink
+	column int
+	title  string
+	err    error
+}
+
+// addLinkedTask fetches the title of a pasted issue or pull request in the
+// background; the task is added once it arrives
+func (m *model) addLinkedTask(column int, link issueLink) tea.Cmd {
+	m.notify("Fetching %s", link.url)
+	return func() tea.Msg {
+		title, err := link.title()
+		return linkTitleMsg{link: link, column: column, title: title, err: err}
+	}
+}
+
+// finishLinkedTask adds the task for a pasted link. Without a title, the
+// URL stands in for one.
+func (m *model) finishLinkedTask(msg linkTitleMsg) {
+	title := msg.title
+	if msg.err != nil {
+		m.logError(fmt.Errorf("fetching the title: %w", msg.err))
+		title = msg.link.url.String()
+	}
+	m.lastID++
+	m.board.AddTask(msg.column, board.Task{ID: m.lastID, Title: title, Source: msg.link.source()})
+	m.save()
+	if m.searchQuery != "" {
+		m.runSearch()
+	}
+	m.refreshViewports()
+}
@@ -0,0 +1,1456 @@
+// Package ui is gotask's terminal interface: the interactive board, the
+// overlays on top of it, and a one-shot render of the board for scripts.
+// The kanban logic itself lives in gotask/internal/board.
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+	"gotask/internal/debug"
+	"gotask/internal/hooks"
+	"gotask/internal/notify"
+	"gotask/internal/storage"
+)
+
+// keyMode represents different input modes (like vim)
+type keyMode int
+
+const (
+	normalMode keyMode = iota
+	insertMode
+)
+
+// dialogKind represents different types of dialogs
+type dialogKind int
+
+const (
+	noDialog dialogKind = iota
+	confirmDialog
+	editDialog
+	tagDialog
+)
+
+// Model holds the application state
+type model struct {
+	board         board.Board
+	cursorColumn  int
+	cursorTask    int
+	textInput     textinput.Model
+	inputMode     bool
+	inputState    keyMode
+	width         int
+	height        int
+	errLog        []logEntry        // errors reported this session, oldest first
+	errSeen       int               // how many logged errors have been dismissed
+	showLog       bool              // whether the error log panel is open
+	logView       viewport.Model    // scrollable error log
+	savePath      string
+	lastSave      time.Time         // when the board was last written to disk
+	saveErr       error             // error from the last write, if it failed
+	lastID        int
+	showTaskInput bool
+	showHelp      bool
+	dialogType    dialogKind
+	editingID     int               // task whose title is being edited inline
+	viewports     []viewport.Model  // viewports for scrollable columns
+	headerHeight  int               // height of the header section
+	searching     bool              // whether the search prompt is open
+	searchInput   textinput.Model
+	searchQuery   string
+	searchMatches []searchMatch
+	searchIndex   int               // index of the current match in searchMatches
+	searchOrigin  searchMatch       // cursor position when the search prompt opened
+	searchArchive bool              // whether search also looks through the archive
+	archiveHits   []int             // positions in the archive of tasks matching the search
+	filter        taskFilter        // narrows the visible tasks in every column
+	filtering     bool              // whether the filter dialog is open
+	filterInput   textinput.Model
+	cardSpans     [][]cardSpan      // where each rendered task sits inside its viewport
+	drag          *dragState        // card being dragged with the mouse, if any
+	marked        map[int]bool      // IDs of tasks selected for bulk operations
+	savedData     []byte            // board as last saved
+	events        *storage.EventLog         // every change to the board, for undo
+	keys          keyMap
+	help          help.Model
+	showDetail    bool              // whether the task detail view is open
+	detailView    viewport.Model
+	helpView      viewport.Model    // scrollable help screen
+	showStats     bool              // whether the statistics screen is open
+	statsView     viewport.Model    // scrollable statistics screen
+	burnWindow    int               // index in burnWindows of the days the burndown covers
+	showActivity  bool              // whether the activity panel is open
+	activityView  viewport.Model    // scrollable feed of recent events
+	review        *weeklyReview     // weekly review of the open tasks, if under way
+	archive       *archiveExplorer  // archive explorer, if open
+	dueSummary    *dueSummary       // overdue and due-today tasks shown on startup, until dismissed
+	editor        *taskEditor       // full-screen task editor, if open
+	commanding    bool              // whether the ":" command prompt is open
+	commandInput  textinput.Model
+	confirm       *confirmation     // action waiting on the confirmation dialog
+	policies      confirmSettings   // when destructive actions ask first
+	limits        limitSettings     // WIP and aging limits to warn about
+	pendingKey    string            // first key of a two-key command like dd
+	clipboard     clipboard         // tasks yanked or cut for pasting
+	lastAction    *action           // last repeatable change, for "."
+	visual        bool              // whether a visual range is being selected
+	visualAnchor  int               // ID of the task where the visual range started
+	display       displaySettings   // how cards are drawn
+	showPreview   bool              // whether the selected task's preview popup is shown
+	columnCursors []columnCursor    // cursor position last used in each column
+	split         bool              // whether the selected task's details show beside the board
+	calendar      *calendarView     // calendar of due dates, if open
+	pomodoro      *pomodoro         // focus timer for a task, if running
+	pomodoroSeq   int               // number of the latest pomodoro started
+	focus         *focusSession     // focus mode on a task, if on
+	toasts        []toast           // short-lived messages about what just happened
+	nextToast     int               // ID of the last toast queued
+	saver         *storage.Writer   // writes the board in the background
+	saveSeq       int               // sequence number of the latest snapshot
+	autosave      autosavePolicy    // when changes are written unasked
+	saveRequested bool              // write the queued snapshot despite autosave
+	autosaveDue   bool              // a write held back by autosave is scheduled
+	pendingSave   []byte            // snapshot waiting to be written
+	saving        bool              // whether a background write is running
+	saveSpinner   spinner.Model     // shown in the status bar while saving
+	notifier      notify.Settings    // which notifications to send
+	alerted       map[string]bool   // keys of the due-date alerts already sent
+	hooks         hooks.Runner             // commands run when tasks change
+	pendingHooks  []hooks.Event       // events waiting for their hooks to run
+	cutHooks      map[int]board.Change // removals of cut tasks, held back from hooks until pasted
+	fileStamp     storage.Stamp     // board file as last read or written
+	remote        *remoteBoard      // server the board is attached to, if any
+	readOnly      bool              // changes are undone instead of saved
+	remoteEvents  chan string       // versions announced by the server
+	remoteStale   bool              // whether to fetch the board after saving
+	pinnedNow     time.Time         // time the board is drawn at, if not the clock's
+	titleWraps    map[titleWrap]int // lines taken by titles already wrapped
+}
+
+func initialModel() model {
+	return newModel(nil)
+}
+
+// newModel sets up the board, attached to a server if remote is given and
+// on the local board file otherwise
+func newModel(remote *remoteBoard) model {
+	cfg, err := config.Load()
+	return configuredModel(cfg, err, remote)
+}
+
+// configuredModel sets up the board with the given settings, falling back
+// to the defaults on errors. err is the error from loading them, if any.
+func configuredModel(cfg config.Config, err error, remote *remoteBoard) model {
+	ti := textinput.New()
+	ti.Placeholder = "Add a new task..."
+	ti.Focus()
+
+	m := model{
+		board:        board.New(),
+		textInput:    ti,
+		inputMode:    false,
+		inputState:   normalMode,
+		savePath:     cfg.BoardPath(),
+		lastID:       0,
+		showTaskInput: false,
+		dialogType:   noDialog,
+		editingID:    0,
+		headerHeight: 5, // Fixed height for title (1) + padding (2) + column headers (1) + padding (1)
+		searchInput:  newSearchInput(),
+		filterInput:  newFilterInput(),
+		commandInput: newCommandInput(),
+		marked:       make(map[int]bool),
+		keys:         defaultKeyMap(),
+		help:         help.New(),
+		saver:        &storage.Writer{},
+		burnWindow:   defaultBurnWindow,
+		alerted:      make(map[string]bool),
+		titleWraps:   make(map[titleWrap]int),
+		saveSpinner:  spinner.New(spinner.WithSpinner(spinner.MiniDot)),
+		remote:       remote,
+		remoteEvents: make(chan string),
+	}
+
+	m.logError(err)
+	if m.keys, err = newKeyMap(cfg.Keys); err != nil {
+		m.logError(err)
+	}
+	if m.policies, err = newConfirmSettings(cfg.Confirm); err != nil {
+		m.logError(err)
+	}
+	if cfg.NoColor {
+		// Without color, state shows through symbols
+		lipgloss.SetColorProfile(termenv.Ascii)
+		cfg.Display.Symbols = true
+	}
+	m.readOnly = cfg.ReadOnly
+	if err := setBackground(cfg.Background); err != nil {
+		m.logError(err)
+	}
+	if err := applyTheme(cfg.Theme, cfg.Colors); err != nil {
+		m.logError(err)
+	}
+	if m.display, err = newDisplaySettings(cfg.Display, cfg.Theme); err != nil {
+		m.logError(err)
+	}
+	if m.notifier, err = notify.NewSettings(cfg.Notify); err != nil {
+		m.logError(err)
+	}
+	if m.limits, err = newLimitSettings(cfg.Limits); err != nil {
+		m.logError(err)
+	}
+	if m.autosave, err = newAutosavePolicy(cfg.Autosave); err != nil {
+		m.logError(err)
+	}
+	if err := config.ApplyColumns(cfg); err != nil {
+		m.logError(err)
+	}
+	m.hooks = hooks.New(cfg.Hooks)
+	m.help.Styles = helpStyles()
+
+	// Fold in conflicted copies left by file sync, then load the board
+	if copies := storage.ConflictCopies(m.savePath); len(copies) > 0 && remote == nil && !m.readOnly {
+		if stats, err := storage.MergeFiles(m.savePath, copies, false); err != nil {
+			m.logError(err)
+		} else {
+			m.notify("Merged conflicted copies of the board: %s", stats)
+		}
+	}
+	start := time.Now()
+	m.openEvents()
+	stale, err := m.loadBoard()
+	if err != nil {
+		m.logError(err)
+	}
+	debug.Log.Debug("load", "path", m.savePath, "remote", remote != nil, "took", time.Since(start))
+	m.fitColumns()
+	m.savedData = m.snapshot()
+	if stale && !m.readOnly {
+		// The board file is behind the log, e.g. after a crash
+		m.queueSave(m.savedData)
+	}
+	m.dueSummary = newDueSummary(&m.board, time.Now())
+
+	return m
+}
+
+// loadBoard loads the board from the server it is attached to, or else
+// from its change log. It reports whether the board file is behind the
+// log and wants writing again.
+func (m *model) loadBoard() (stale bool, err error) {
+	if m.remote != nil {
+		data, version, err := m.remote.fetch()
+		if err != nil {
+			return false, err
+		}
+		if err := json.Unmarshal(data, &m.board); err != nil {
+			return false, err
+		}
+		m.remote.setVersion(version)
+		m.lastID = m.board.MaxID()
+		m.recordExternal()
+		return false, nil
+	}
+	m.fileStamp = storage.Stat(m.savePath)
+	board, stale, err := m.events.ReadBoard(m.savePath, time.Now())
+	if err != nil {
+		return false, err
+	}
+	m.board = board
+	m.lastID = m.board.MaxID()
+	return stale, nil
+}
+
+func (m *model) saveBoard() error {
+	if m.readOnly {
+		m.reset(m.savedData)
+		return storage.ErrReadOnly
+	}
+	m.board.RecordFlow(time.Now())
+	data, err := storage.Encode(m.board)
+	if err != nil {
+		return err
+	}
+	m.queueHooks()
+	m.savedData = data
+	m.queueSave(data)
+	return m.events.Record(storage.EventChange, m.board, time.Now())
+}
+
+func (m model) Init() tea.Cmd {
+	return tea.Batch(tickClock(), m.checkAlerts(time.Now()), m.watchBoard())
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	start := time.Now()
+	next, cmd := m.update(msg)
+	logUpdate(msg, m.mode(), time.Since(start))
+	if nm, ok := next.(model); ok {
+		save, toasts, hooks := nm.startSave(), nm.scheduleToasts(), nm.startHooks()
+		if save != nil || toasts != nil || hooks != nil {
+			return nm, tea.Batch(cmd, save, toasts, hooks)
+		}
+		return nm, cmd
+	}
+	return next, cmd
+}
+
+func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	
+	// Update all viewports
+	for i := range m.viewports {
+		// Mouse events only scroll the column under the pointer, and not
+		// while the board is hidden
+		if mouse, ok := msg.(tea.MouseMsg); ok && (m.boardCovered() || m.columnAt(mouse.X) != i) {
+			continue
+		}
+
+		var cmd tea.Cmd
+		offset := m.viewports[i].YOffset
+		m.viewports[i], cmd = m.viewports[i].Update(msg)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		// Draw the cards scrolled into view
+		if m.viewports[i].YOffset != offset {
+			m.drawCards(i)
+		}
+	}
+	
+	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		return m.updateMouse(msg, cmds)
+
+	case externalEditorMsg:
+		m.applyExternalEdit(msg)
+
+	case pluginDoneMsg:
+		m.applyPluginAction(msg)
+
+	case linkTitleMsg:
+		m.finishLinkedTask(msg)
+
+	case clockMsg:
+		m.refreshViewports()
+		return m, tea.Batch(tickClock(), m.checkAlerts(time.Time(msg)))
+
+	case pomodoroTickMsg:
+		return m, m.updatePomodoro(msg)
+
+	case notifyErrMsg:
+		m.logError(msg.err)
+		return m, nil
+
+	case saveDoneMsg:
+		return m, m.finishSave(msg)
+
+	case autosaveMsg:
+		// Update starts the held back write once this returns
+		m.autosaveDue = false
+		return m, nil
+
+	case fileWatchMsg:
+		m.checkFile()
+		return m, tickWatch()
+
+	case remoteChangedMsg:
+		return m, m.remoteChanged(string(msg))
+
+	case remoteBoardMsg:
+		m.applyRemote(msg)
+		return m, nil
+
+	case hooksDoneMsg:
+		if msg.err != nil {
+			m.logError(msg.err)
+		}
+		return m, nil
+
+	case spinner.TickMsg:
+		if !m.saving {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.saveSpinner, cmd = m.saveSpinner.Update(msg)
+		return m, cmd
+
+	case toastExpiredMsg:
+		m.dismissToast(int(msg))
+		return m, nil
+
+	case tea.KeyMsg:
+		// Handle the confirmation dialog
+		if m.dialogType == confirmDialog {
+			switch {
+			case key.Matches(msg, m.keys.Confirm):
+				c := m.confirm
+				m.confirm = nil
+				m.dialogType = noDialog
+				c.run(&m)
+			case key.Matches(msg, m.keys.Deny):
+				m.confirm = nil
+				m.dialogType = noDialog
+			}
+			return m, nil
+		}
+
+		// Handle the due summary
+		if m.dueSummary != nil {
+			return m.updateDueSummary(msg)
+		}
+
+		// Handle the help screen
+		if m.showHelp {
+			return m.updateHelp(msg)
+		}
+
+		// Handle the error log
+		if m.showLog {
+			return m.updateLog(msg)
+		}
+
+		// Handle the statistics screen
+		if m.showStats {
+			return m.updateStats(msg)
+		}
+
+		// Handle the activity panel
+		if m.showActivity {
+			return m.updateActivity(msg)
+		}
+
+		// Handle the weekly review
+		if m.review != nil {
+			return m.updateReview(msg)
+		}
+
+		// Handle the archive explorer
+		if m.archive != nil {
+			return m.updateArchive(msg)
+		}
+
+		// Handle the calendar
+		if m.calendar != nil {
+			return m.updateCalendar(msg)
+		}
+
+		// Handle the full-screen task editor
+		if m.editor != nil {
+			return m.updateEditor(msg)
+		}
+
+		// Handle the task detail view
+		if m.showDetail {
+			return m.updateDetail(msg)
+		}
+
+		// Handle the search prompt
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+
+		// Handle the filter dialog
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
+
+		// Handle the command prompt
+		if m.commanding {
+			return m.updateCommand(msg)
+		}
+		
+		// Handle input based on current mode
+		if m.inputMode {
+			switch m.inputState {
+			case normalMode:
+				// In normal mode, handle vim-like commands
+				switch {
+				case key.Matches(msg, m.keys.Insert):
+					// Switch to insert mode
+					m.inputState = insertMode
+					return m, nil
+				
+				case key.Matches(msg, m.keys.Cancel):
+					// Exit input mode
+					m.inputMode = false
+					m.textInput.Reset()
+					m.inputState = normalMode
+					m.editingID = 0
+					m.dialogType = noDialog
+					m.refreshViewports()
+					return m, nil
+					
+				case key.Matches(msg, m.keys.Submit):
+					return m, m.submitInput()
+				
+				// Allow navigation while in normal mode
+				case key.Matches(msg, m.keys.Quit):
+					m.stopFocus()
+					if err := m.saveNow(); err != nil {
+						m.logError(err)
+						return m, nil
+					}
+					return m, tea.Quit
+					
+				case key.Matches(msg, m.keys.Help):
+					m.openHelp()
+					return m, nil
+				}
+				
+			case insertMode:
+				// In insert mode, handle text input normally
+				switch {
+				case key.Matches(msg, m.keys.Cancel):
+					// Switch back to normal mode
+					m.inputState = normalMode
+					return m, nil
+					
+				case key.Matches(msg, m.keys.Submit):
+					return m, m.submitInput()
+				
+				default:
+					// Update text input normally in insert mode
+					return m, m.updateTextInput(msg)
+				}
+			}
+			
+			// Default case for handling input in any mode
+			return m, m.updateTextInput(msg)
+		} else {
+			// Visual mode selects a range of tasks with the cursor
+			if m.updateVisual(msg) {
+				return m, nil
+			}
+
+			// Two-key commands such as yy and dd
+			if m.readKeySequence(msg) {
+				return m, nil
+			}
+
+			// When not in input mode, handle normal application commands
+			switch {
+			case key.Matches(msg, m.keys.Quit):
+				m.stopFocus()
+				if err := m.saveNow(); err != nil {
+					m.logError(err)
+					return m, nil
+				}
+				return m, tea.Quit
+
+			case key.Matches(msg, m.keys.Help):
+				m.openHelp()
+				return m, nil
+
+			case key.Matches(msg, m.keys.Command):
+				// Open the command prompt
+				m.commanding = true
+				m.commandInput.Reset()
+				m.commandInput.Focus()
+				return m, textinput.Blink
+
+			case key.Matches(msg, m.keys.GoTo):
+				// Open the command prompt ready for a task number
+				m.commanding = true
+				m.commandInput.SetValue("goto ")
+				m.commandInput.CursorEnd()
+				m.commandInput.Focus()
+				return m, textinput.Blink
+
+			case key.Matches(msg, m.keys.Search):
+				// Open the search prompt, remembering where to return on cancel
+				m.searching = true
+				m.searchOrigin = searchMatch{column: m.cursorColumn, task: m.cursorTask}
+				m.searchInput.Reset()
+				m.searchInput.Focus()
+				return m, textinput.Blink
+
+			case key.Matches(msg, m.keys.Cancel):
+				if m.searchQuery != "" {
+					m.clearSearch()
+				} else if len(m.marked) > 0 {
+					m.clearMarks()
+					m.refreshViewports()
+				} else {
+					m.dismissErrors()
+				}
+				return m, nil
+
+			case key.Matches(msg, m.keys.ErrorLog):
+				m.openLog()
+				return m, nil
+
+			case key.Matches(msg, m.keys.Undo):
+				m.undo()
+				return m, nil
+
+			case key.Matches(msg, m.keys.Preview):
+				m.showPreview = !m.showPreview
+				return m, nil
+
+			case key.Matches(msg, m.keys.Density):
+				m.display.compact = !m.display.compact
+				m.refreshViewports()
+				return m, nil
+
+			case key.Matches(msg, m.keys.Split):
+				m.toggleSplit()
+				return m, nil
+
+			case key.Matches(msg, m.keys.Calendar):
+				m.openCalendar()
+				return m, nil
+
+			case key.Matches(msg, m.keys.Stats):
+				m.openStats()
+				return m, nil
+
+			case key.Matches(msg, m.keys.Activity):
+				m.openActivity()
+				return m, nil
+
+			case key.Matches(msg, m.keys.Review):
+				m.openReview()
+				return m, nil
+
+			case key.Matches(msg, m.keys.Archived):
+				m.openArchive()
+				return m, nil
+
+			case key.Matches(msg, m.keys.Pomodoro):
+				return m, m.togglePomodoro()
+
+			case key.Matches(msg, m.keys.Focus):
+				m.toggleFocus()
+				return m, nil
+
+			case key.Matches(msg, m.keys.ToggleTags):
+				m.display.showTags = !m.display.showTags
+				m.refreshViewports()
+				return m, nil
+
+			case key.Matches(msg, m.keys.Detail):
+				m.openDetail()
+				return m, nil
+
+			case key.Matches(msg, m.keys.FullEdit):
+				return m, m.openEditor()
+
+			case key.Matches(msg, m.keys.ExternalEdit):
+				return m, m.openExternalEditor()
+
+			case key.Matches(msg, m.keys.Redo):
+				m.redo()
+				return m, nil
+
+			case key.Matches(msg, m.keys.Filter):
+				// Open the filter dialog with the current expression
+				m.filtering = true
+				m.filterInput.SetValue(m.filter.expr)
+				m.filterInput.CursorEnd()
+				m.filterInput.Focus()
+				return m, textinput.Blink
+
+			case key.Matches(msg, m.keys.ClearFilter):
+				m.applyFilter("")
+				return m, nil
+
+			// Match navigation shares keys with other actions, so it only
+			// applies while a search is active
+			case key.Matches(msg, m.keys.NextMatch) && m.searchQuery != "":
+				m.jumpToMatch(m.searchIndex + 1)
+				return m, nil
+
+			case key.Matches(msg, m.keys.PrevMatch) && m.searchQuery != "":
+				m.jumpToMatch(m.searchIndex - 1)
+				return m, nil
+				
+			case key.Matches(msg, m.keys.Add):
+				// Enter input mode in insert mode
+				m.inputMode = true
+				m.inputState = insertMode
+				m.textInput.Reset()
+				return m, textinput.Blink
+				
+			case key.Matches(msg, m.keys.New):
+				// Enter input mode in normal mode 
+				m.inputMode = true
+				m.inputState = normalMode
+				m.textInput.Reset()
+				return m, textinput.Blink
+
+			case key.Matches(msg, m.keys.Edit):
+				if task := m.selectedTask(); task != nil {
+					// Enter edit mode
+					m.dialogType = editDialog
+					m.editingID = task.ID
+					m.textInput.SetValue(task.Title)
+					m.inputMode = true
+					m.inputState = insertMode
+					m.updateViewportContent(m.cursorColumn)
+					return m, textinput.Blink
+				}
+				
+			case key.Matches(msg, m.keys.Delete):
+				m.perform("delete", func(m *model) { m.requestDelete(m.targetIDs()) })
+
+			case key.Matches(msg, m.keys.Paste):
+				m.perform("paste", func(m *model) { m.paste(false) })
+
+			case key.Matches(msg, m.keys.PasteBefore):
+				m.perform("paste before", func(m *model) { m.paste(true) })
+
+			case key.Matches(msg, m.keys.Archive):
+				m.perform("archive", func(m *model) { m.requestArchive(m.targetIDs()) })
+
+			case key.Matches(msg, m.keys.Tag):
+				if len(m.targetIDs()) > 0 {
+					// Ask for tags to add to the selected tasks
+					m.dialogType = tagDialog
+					m.inputMode = true
+					m.inputState = insertMode
+					m.textInput.Reset()
+					return m, textinput.Blink
+				}
+
+			case key.Matches(msg, m.keys.Select):
+				// Toggle the mark on the selected task and advance
+				if task := m.selectedTask(); task != nil {
+					m.toggleMark(task.ID)
+					if i := m.nextVisible(m.cursorColumn, m.cursorTask, 1); i >= 0 {
+						m.cursorTask = i
+					}
+					m.updateViewportContent(m.cursorColumn)
+				}
+
+			case key.Matches(msg, m.keys.Up):
+				if i := m.nextVisible(m.cursorColumn, m.cursorTask, -1); i >= 0 {
+					m.cursorTask = i
+					m.updateViewportContent(m.cursorColumn)
+				}
+
+			case key.Matches(msg, m.keys.Down):
+				if i := m.nextVisible(m.cursorColumn, m.cursorTask, 1); i >= 0 {
+					m.cursorTask = i
+					m.updateViewportContent(m.cursorColumn)
+				}
+
+			case key.Matches(msg, m.keys.FocusColumn):
+				if i := bindingIndex(msg, m.keys.FocusColumn); i < len(m.board.Columns) {
+					m.switchColumn(i)
+				}
+
+			case key.Matches(msg, m.keys.SendToColumn):
+				if i := bindingIndex(msg, m.keys.SendToColumn); i < len(m.board.Columns) {
+					title := m.board.Columns[i].Title
+					m.perform("send to "+title, func(m *model) { m.sendTargets(i) })
+				}
+
+			case key.Matches(msg, m.keys.Bottom):
+				m.jumpToEdge(1)
+
+			case key.Matches(msg, m.keys.HalfPageDown):
+				m.halfPage(1)
+
+			case key.Matches(msg, m.keys.HalfPageUp):
+				m.halfPage(-1)
+
+			case key.Matches(msg, m.keys.Left):
+				m.switchColumn(m.cursorColumn - 1)
+
+			case key.Matches(msg, m.keys.Right):
+				m.switchColumn(m.cursorColumn + 1)
+
+			case key.Matches(msg, m.keys.MoveLeft):
+				m.perform("move left", func(m *model) { m.moveTargets(-1) })
+
+			case key.Matches(msg, m.keys.MoveRight):
+				m.perform("move right", func(m *model) { m.moveTargets(1) })
+
+			case key.Matches(msg, m.keys.RaisePriority):
+				m.perform("raise priority", func(m *model) { m.shiftTargetPriority(1) })
+
+			case key.Matches(msg, m.keys.LowerPriority):
+				m.perform("lower priority", func(m *model) { m.shiftTargetPriority(-1) })
+
+			case key.Matches(msg, m.keys.Repeat):
+				m.repeatLast()
+			}
+
+			// Extend the visual range to the new cursor position
+			if m.visual {
+				m.syncVisual()
+			}
+
+			// Keep search results pointing at the right tasks after changes
+			if m.searchQuery != "" {
+				m.runSearch()
+				m.refreshViewports()
+			}
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.help.Width = msg.Width
+		if m.showDetail {
+			m.openDetail()
+		}
+		if m.showHelp {
+			m.openHelp()
+		}
+		if m.showLog {
+			m.openLog()
+		}
+		if m.showStats {
+			m.openStats()
+		}
+		if m.showActivity {
+			m.openActivity()
+		}
+
+		m.resizeViewports()
+	}
+
+	if len(cmds) > 0 {
+		return m, tea.Batch(cmds...)
+	}
+	return m, nil
+}
+
+func (m model) View() string {
+	start := time.Now()
+	view := m.view()
+	if took := time.Since(start); took > slowFrame {
+		debug.Log.Debug("slow render", "took", took, "width", m.width, "height", m.height)
+	}
+	return view
+}
+
+func (m model) view() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	if m.showHelp {
+		return m.helpViewBox()
+	}
+
+	if m.showLog {
+		return m.logViewBox()
+	}
+
+	if m.showStats {
+		return m.statsViewBox()
+	}
+
+	if m.showActivity {
+		return m.activityViewBox()
+	}
+
+	if m.review != nil {
+		return m.reviewViewBox()
+	}
+
+	if m.archive != nil {
+		return m.archiveViewBox()
+	}
+
+	if m.calendar != nil {
+		return m.calendarViewBox()
+	}
+
+	if m.editor != nil {
+		return m.editorViewBox()
+	}
+
+	if m.showDetail {
+		return m.detailViewBox()
+	}
+
+	var s strings.Builder
+
+	// Title - centered based on terminal width
+	s.WriteString(m.renderTitle() + "\n\n")
+
+	// Calculate column width based on available space and number of columns
+	columnWidth := m.columnWidth()
+
+	// Render column headers separately for sticky header
+	s.WriteString(m.renderColumnHeaders(columnWidth) + "\n\n")
+	
+	// Prepare columns for rendering (only task content, not headers)
+	renderedColumns := make([]string, len(m.board.Columns))
+	for i, _ := range m.board.Columns {
+		// Apply the appropriate column style based on the column
+		var colStyle lipgloss.Style
+		switch m.columnKind(i) {
+		case 0: // To Do
+			colStyle = todoColumnStyle
+		case 1: // In Progress
+			colStyle = inProgColumnStyle
+		case 2: // Done
+			colStyle = doneColumnStyle
+		default:
+			colStyle = columnStyle
+		}
+
+		// Without color to tell them apart, the focused column gets a double border
+		if m.display.symbols && i == m.cursorColumn && !m.display.borderless {
+			colStyle = colStyle.Copy().Border(lipgloss.DoubleBorder())
+		}
+
+		// Now use the viewport for task content only
+		content := m.viewports[i].View()
+		if m.dimmed(i) {
+			colStyle = colStyle.Copy().BorderForeground(subtle)
+			content = dimText(content)
+		}
+		renderedColumns[i] = colStyle.Width(columnWidth).Render(content)
+		if above, below := m.hiddenTasks(i); above+below > 0 {
+			renderedColumns[i] = addScrollIndicators(renderedColumns[i], above, below)
+		}
+	}
+
+	// Join columns side by side
+	s.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, renderedColumns...))
+
+	// Details of the selected task beside the board
+	if m.split {
+		board := s.String()
+		s.Reset()
+		s.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, board, m.renderSplitPane(lipgloss.Height(board))))
+	}
+
+	// The bottom line shows the command or search prompt in place of hints
+	bottom := m.renderHints()
+	switch {
+	case m.commanding:
+		bottom = m.commandInput.View()
+	case m.dragStatus() != "":
+		bottom = m.dragStatus()
+	case m.searching:
+		bottom = m.searchStatus()
+	case m.searchQuery != "":
+		bottom = m.searchStatus() + "  " + bottom
+	}
+	s.WriteString("\n" + ansi.Truncate(bottom, m.width, "…") + "\n" + m.renderStatusBar())
+	view := s.String()
+
+	// Dialogs float over the middle of the board
+	switch {
+	case m.dialogType == confirmDialog:
+		dialogContent := m.confirm.prompt + "\n\n[y/n]"
+		if m.confirm.detail != "" {
+			dialogContent = m.confirm.prompt + "\n\n" + m.confirm.detail + "\n\n[y/n]"
+		}
+		view = m.overlayCenter(confirmDialogStyle.Render(dialogContent), view)
+
+	// Input field for adding tasks and tagging; titles are edited in place
+	case m.inputMode && m.dialogType != editDialog:
+		dialogTitle := "New task in " + m.board.Columns[m.cursorColumn].Title + ":"
+		if m.dialogType == tagDialog {
+			dialogTitle = "Add tags to " + m.targetSummary() + ":"
+		}
+
+		modeIndicator := lipgloss.NewStyle().Foreground(todoColor).Render("[NORMAL MODE]")
+		if m.inputState == insertMode {
+			modeIndicator = lipgloss.NewStyle().Foreground(special).Render("[INSERT MODE]")
+		}
+
+		dialog := dialogBoxStyle.Render(dialogTitle + "\n" +
+			m.textInput.View() + "\n" + modeIndicator)
+		view = m.overlayCenter(dialog, view)
+
+	case m.filtering:
+		dialog := dialogBoxStyle.Copy().Width(50).Render("Filter tasks:\n" +
+			m.filterInput.View() + "\n" + helpStyle.Render("tag:name • priority:high • text"))
+		view = m.overlayCenter(dialog, view)
+
+	// Overdue and due-today tasks on startup
+	case m.dueSummary != nil:
+		view = m.overlayCenter(m.renderDueSummary(m.now()), view)
+
+	// Archived tasks matching the search
+	case m.searchQuery != "" && len(m.archiveHits) > 0:
+		view = m.overlayArchiveHits(view)
+
+	// Floating preview of the selected task
+	case m.showPreview && !m.inputMode && !m.commanding:
+		view = m.overlayPreview(view)
+	}
+	return m.overlayToasts(view)
+}
+
+// renderTitle renders the board title centered on the terminal
+func (m model) renderTitle() string {
+	title := titleStyle.Render(" KANBAN BOARD ")
+	return lipgloss.PlaceHorizontal(m.boardWidth(), lipgloss.Center, title)
+}
+
+// renderColumnHeaders renders the sticky row of column titles
+func (m model) renderColumnHeaders(columnWidth int) string {
+	columnHeaders := make([]string, len(m.board.Columns))
+	in, out := columnFlow(&m.board, sparkDays, m.now())
+	for i, col := range m.board.Columns {
+		// Column header with color based on column type
+		var headerStyle lipgloss.Style
+		switch m.columnKind(i) {
+		case 0: // To Do
+			headerStyle = columnHeaderStyle.Copy().BorderForeground(todoColor).Foreground(todoColor)
+		case 1: // In Progress
+			headerStyle = columnHeaderStyle.Copy().BorderForeground(inProgColor).Foreground(inProgColor)
+		case 2: // Done
+			headerStyle = columnHeaderStyle.Copy().BorderForeground(doneColor).Foreground(doneColor)
+		default:
+			headerStyle = columnHeaderStyle
+		}
+		if m.dimmed(i) {
+			headerStyle = headerStyle.Copy().BorderForeground(subtle).Foreground(mutedColor)
+		}
+		title := headerSparklines(col.Title, in[i], out[i], columnWidth-headerStyle.GetHorizontalPadding())
+		columnHeaders[i] = headerStyle.Width(columnWidth).Render(title)
+	}
+
+	// Join headers side by side
+	return lipgloss.JoinHorizontal(lipgloss.Bottom, columnHeaders...)
+}
+
+// Helper method to update the content of a viewport. Every card is
+// measured, so scrolling and the mouse know where each one is, but only
+// those near the view are drawn.
+func (m *model) updateViewportContent(columnIndex int) {
+	vp := &m.viewports[columnIndex]
+	col := m.board.Columns[columnIndex]
+	
+	var spans []cardSpan
+	line := 0
+	measure := m.cardMeasure()
+	for j, task := range col.Tasks {
+		if !m.taskVisible(task) {
+			continue
+		}
+		height := m.cardHeight(columnIndex, j, measure)
+		spans = append(spans, cardSpan{task: j, top: line, height: height})
+		line += height
+	}
+	m.cardSpans[columnIndex] = spans
+	
+	if len(col.Tasks) == 0 {
+		vp.SetContent(itemStyle.Render("No tasks"))
+		return
+	} else if len(spans) == 0 {
+		vp.SetContent(itemStyle.Render("No matching tasks"))
+		return
+	}
+	
+	// Blank content of the right length lets the viewport clamp the offset
+	vp.SetContent(strings.Repeat("\n", line))
+	
+	// Update scrolling position to keep the selected task in view
+	if m.cursorColumn == columnIndex {
+		for _, span := range spans {
+			if span.task != m.cursorTask {
+				continue
+			}
+			if span.top < vp.YOffset {
+				vp.SetYOffset(span.top)
+			} else if bottom := span.top + span.height; bottom > vp.YOffset+vp.Height {
+				vp.SetYOffset(bottom - vp.Height)
+			}
+			break
+		}
+	}
+	
+	m.drawCards(columnIndex)
+}
+
+// drawCards draws the cards of a column that are in view or within a
+// screen of it, so that short scrolls don't show a gap. The others are
+// left as blank lines of the same height.
+func (m *model) drawCards(columnIndex int) {
+	vp := &m.viewports[columnIndex]
+	spans := m.cardSpans[columnIndex]
+	if len(spans) == 0 {
+		return
+	}
+	top, bottom := vp.YOffset-vp.Height, vp.YOffset+2*vp.Height
+	
+	var content strings.Builder
+	for _, span := range spans {
+		if span.top+span.height <= top || span.top >= bottom {
+			content.WriteString(strings.Repeat("\n", span.height))
+			continue
+		}
+		content.WriteString(m.renderCard(columnIndex, span.task) + "\n")
+	}
+	vp.SetContent(content.String())
+}
+
+// cardMeasure is what every card in a column shares that its height
+// depends on
+type cardMeasure struct {
+	marker    int // width of the selection marker
+	textWidth int // width inside the card's padding
+	frame     int // lines taken by the card's border and padding
+}
+
+// cardMeasure gets the card sizes the board is drawn with
+func (m *model) cardMeasure() cardMeasure {
+	return cardMeasure{
+		marker:    lipgloss.Width(selectedItemStyle.String()),
+		textWidth: m.cardWidth() - m.display.card.GetHorizontalPadding(),
+		frame:     m.display.card.GetVerticalFrameSize(),
+	}
+}
+
+// titleWrap is a title wrapped for a card, as the key of the number of
+// lines it takes
+type titleWrap struct {
+	title                   string
+	indent, width, maxLines int
+}
+
+// maxTitleWraps bounds the remembered title wraps; past it they are
+// forgotten and measured again
+const maxTitleWraps = 20000
+
+// cardHeight is the number of lines a task's card takes. Styles don't
+// change widths, so this wraps the bare title rather than drawing the
+// card, and remembers how that went for the next time.
+func (m *model) cardHeight(columnIndex, j int, measure cardMeasure) int {
+	task := m.board.Columns[columnIndex].Tasks[j]
+	switch {
+	case m.display.compact:
+		return 1
+	case m.editingInline(task):
+		text, style := m.cardContent(columnIndex, j)
+		return lipgloss.Height(text) + style.GetVerticalFrameSize()
+	}
+	done := task.CompletedAt != nil || m.board.IsDone(columnIndex)
+	key := titleWrap{
+		title:    m.display.iconPrefix(task) + task.Title,
+		indent:   measure.marker + lipgloss.Width(m.cardHead(columnIndex, j, done)),
+		width:    measure.textWidth,
+		maxLines: m.display.titleLines,
+	}
+	// A head wider than the card is wrapped again by the border
+	if key.indent >= key.width {
+		return lipgloss.Height(m.renderCard(columnIndex, j))
+	}
+	lines, ok := m.titleWraps[key]
+	if !ok {
+		if len(m.titleWraps) >= maxTitleWraps {
+			clear(m.titleWraps)
+		}
+		lines = lipgloss.Height(wrapTitle(strings.Repeat(" ", key.indent), key.title, key.width, key.maxLines))
+		m.titleWraps[key] = lines
+	}
+	height := lines + measure.frame
+	if len(m.cardBadges(task, done)) > 0 || (m.display.showTags && len(task.Tags) > 0) {
+		height++
+	}
+	return height
+}
+
+// renderCard draws a task's card
+func (m *model) renderCard(columnIndex, j int) string {
+	card, style := m.cardContent(columnIndex, j)
+	if !m.display.compact {
+		card = style.Width(m.cardWidth()).Render(card)
+	}
+	if m.focus != nil && m.board.Columns[columnIndex].Tasks[j].ID != m.focus.taskID {
+		card = dimText(card)
+	}
+	return card
+}
+
+// cardHead is what comes before a card's title: its ID, priority, and
+// marks
+func (m *model) cardHead(columnIndex, j int, done bool) string {
+	task := m.board.Columns[columnIndex].Tasks[j]
+	head := taskIDStyle.Render(fmt.Sprintf("#%d", task.ID)) + " "
+	if badge := m.display.priorityBadge(task.Priority); badge != "" {
+		head += badge + " "
+	}
+	if m.display.symbols {
+		if m.isSearchMatch(columnIndex, j) {
+			head = symbolMatch + head
+		}
+		if done {
+			head = symbolDone + head
+		}
+	}
+	if m.marked[task.ID] {
+		head = markedStyle.Render("● ") + head
+	}
+	return head
+}
+
+// cardContent lays out what a task's card says: its ID and badges, the
+// title wrapped to fit, and a line of badges and tags underneath. It
+// returns that with the style for the card's border, except that compact
+// cards are a single line with their color bar already drawn.
+func (m *model) cardContent(columnIndex, j int) (string, lipgloss.Style) {
+	cardWidth := m.cardWidth()
+	task := m.board.Columns[columnIndex].Tasks[j]
+	
+	done := task.CompletedAt != nil || m.board.IsDone(columnIndex)
+	title := m.highlightMatches(task.Title)
+	if title == task.Title && m.display.doneStyle != nil && done {
+		title = renderText(*m.display.doneStyle, title)
+	} else if title == task.Title && isStale(task, done, m.display.staleAfter, m.now()) {
+		title = renderText(lipgloss.NewStyle().Foreground(mutedColor), title)
+	}
+	title = m.display.iconPrefix(task) + title
+	head := m.cardHead(columnIndex, j, done)
+
+	// Every card reserves room for the selection marker so titles line up
+	marker := strings.Repeat(" ", lipgloss.Width(selectedItemStyle.String()))
+	if m.cursorColumn == columnIndex && m.cursorTask == j {
+		marker = selectedItemStyle.String()
+	}
+
+	// Add a border around each task for better separation with column-specific colors
+	var taskBorderColor lipgloss.TerminalColor
+	switch m.columnKind(columnIndex) {
+	case 0: // To Do
+		taskBorderColor = todoColor
+	case 1: // In Progress
+		taskBorderColor = inProgColor
+	case 2: // Done
+		taskBorderColor = doneColor
+	default:
+		taskBorderColor = subtle
+	}
+
+	// Matches of the active search stand out from the column color
+	if m.isSearchMatch(columnIndex, j) {
+		taskBorderColor = searchMatchColor
+	}
+
+	// Show where a dragged card would be dropped
+	if m.drag != nil && m.drag.moved && m.drag.toColumn == columnIndex && m.drag.toTask == j {
+		taskBorderColor = dragTargetColor
+		marker = lipgloss.NewStyle().Width(lipgloss.Width(marker)).Render("▸")
+	}
+
+	badges := m.cardBadges(task, done)
+	chips := m.display.tagChips(task)
+	if m.display.compact {
+		if m.editingInline(task) {
+			width := cardWidth + m.display.card.GetHorizontalBorderSize()
+			title, chips = m.inlineInput(width-lipgloss.Width(marker+head)-2), nil
+		}
+		if len(badges) > 0 {
+			head += strings.Join(badges, " ") + " "
+		}
+		if len(chips) > 0 {
+			title += " " + strings.Join(chips, " ")
+		}
+		return compactLine(taskBorderColor, marker+head, title, cardWidth+m.display.card.GetHorizontalBorderSize()), lipgloss.Style{}
+	}
+
+	cardStyle := m.display.cardStyle(taskBorderColor, m.cursorColumn == columnIndex && m.cursorTask == j)
+	textWidth := cardWidth - cardStyle.GetHorizontalPadding()
+	if m.editingInline(task) {
+		title = m.inlineInput(textWidth - lipgloss.Width(marker+head))
+	}
+	taskLine := wrapTitle(marker+head, title, textWidth, m.display.titleLines)
+	if len(badges)+len(chips) > 0 {
+		taskLine += "\n" + badgeLine(append(badges, chips...), textWidth)
+	}
+	return taskLine, cardStyle
+}
+
+// submitInput applies the text entered in the add, edit, or tag dialog
+func (m *model) submitInput() tea.Cmd {
+	var cmd tea.Cmd
+	value := m.textInput.Value()
+	switch {
+	case m.dialogType == editDialog && m.editingID != 0:
+		// Update the task, looked up again in case the board was reloaded
+		// while its title was being edited
+		if col, idx, ok := m.board.FindTask(m.editingID); !ok {
+			m.logError(fmt.Errorf("task #%d no longer exists", m.editingID))
+		} else {
+			task := &m.board.Columns[col].Tasks[idx]
+			if value != task.Title {
+				task.Record(board.EventEdited, task.Title, value)
+			}
+			task.Title = value
+			if err := m.saveBoard(); err != nil {
+				m.logError(err)
+			}
+		}
+
+	case m.dialogType == tagDialog:
+		m.perform("tag "+value, func(m *model) { m.tagTargets(value) })
+
+	case value != "":
+		// Submit the task if it's not empty. A pasted issue link becomes a
+		// task titled after the issue.
+		if link, ok := parseIssueLink(value); ok {
+			cmd = m.addLinkedTask(m.cursorColumn, link)
+		} else {
+			m.addTask(m.cursorColumn, value)
+		}
+	}
+
+	m.textInput.Reset()
+	m.inputMode = false
+	m.inputState = normalMode
+	m.editingID = 0
+	m.dialogType = noDialog
+	if m.searchQuery != "" {
+		m.runSearch()
+	}
+	m.refreshViewports()
+	return cmd
+}
+
+// editingInline reports whether the task's title is being edited in its card
+func (m *model) editingInline(task board.Task) bool {
+	return m.dialogType == editDialog && m.editingID != 0 && m.editingID == task.ID
+}
+
+// inlineInput renders the title input to fit inside a card
+func (m *model) inlineInput(width int) string {
+	ti := m.textInput
+	ti.Prompt = ""
+	ti.Placeholder = ""
+	ti.Width = max(1, width-1)
+	return ti.View()
+}
+
+// updateTextInput passes a key to the text input, redrawing the card when
+// its title is being edited in place
+func (m *model) updateTextInput(msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	if m.dialogType == editDialog {
+		m.updateViewportContent(m.cursorColumn)
+	}
+	return cmd
+}
+
+// addTask appends a new task to a column and saves the board
+func (m *model) addTask(column int, title string) {
+	m.lastID++
+	m.board.AddTask(column, board.Task{ID: m.lastID, Title: title})
+	m.save()
+}
+
+// save writes the board to disk, keeping any error for display
+func (m *model) save() {
+	if err := m.saveBoard(); err != nil {
+		m.logError(err)
+	}
+}
+
+// resizeViewports fits the column viewports to the space the board has
+func (m *model) resizeViewports() {
+	// Update the fixed header height
+	m.headerHeight = 5 // Title (1) + padding (2) + column headers (1) + padding (1)
+
+	// Calculate column width based on available space and number of columns
+	columnWidth := m.columnWidth()
+
+	// Update the viewports with new dimensions
+	// The height is what's left below the headers, inside the column
+	// borders, above the hint line and status bar
+	viewportHeight := m.height - m.layout().columnTop - columnStyle.GetVerticalFrameSize() - 2
+
+	// Make sure viewport height has a reasonable minimum
+	viewportHeight = max(3, viewportHeight)
+
+	// Resize all viewports
+	for i := range m.viewports {
+		// Set viewport size
+		m.viewports[i].Width = columnWidth
+		m.viewports[i].Height = viewportHeight
+
+		// Update content for each viewport
+		m.updateViewportContent(i)
+	}
+}
+
+// newColumnViewport makes the viewport a column's tasks scroll in
+func newColumnViewport() viewport.Model {
+	vp := viewport.New(0, 0)
+	vp.MouseWheelEnabled = true
+	// Scrolling follows the cursor; the viewport's own keys would scroll
+	// every column at once
+	vp.KeyMap = viewport.KeyMap{}
+	return vp
+}
+
+// fitColumns gives each of the board's columns a viewport
+func (m *model) fitColumns() {
+	for len(m.viewports) < len(m.board.Columns) {
+		m.viewports = append(m.viewports, newColumnViewport())
+	}
+	m.viewports = m.viewports[:len(m.board.Columns)]
+	m.cardSpans = make([][]cardSpan, len(m.board.Columns))
+}
+
+// columnKind places a column for coloring: 0 for the first, where work
+// waits, 2 for the last, where it is done, and 1 for those between
+func (m model) columnKind(col int) int {
+	switch {
+	case col == 0:
+		return 0
+	case m.board.IsDone(col):
+		return 2
+	}
+	return 1
+}
+
+// Helper method to re-render every column, e.g. after the cursor jumps columns
+func (m *model) refreshViewports() {
+	for i := range m.viewports {
+		m.updateViewportContent(i)
+	}
+}
+
+// Run opens the board in the terminal, on the board file the settings
+// name, until it is quit
+func Run() error {
+	return runBoard(initialModel())
+}
+
+// runBoard runs the interactive board until it is quit
+func runBoard(m model) error {
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	final, err := p.Run()
+	if err != nil {
+		return fmt.Errorf("running program: %w", err)
+	}
+
+	// Write anything still queued when the program stopped
+	if m, ok := final.(model); ok {
+		if err := m.flushSave(); err != nil {
+			return fmt.Errorf("saving board: %w", err)
+		}
+		// Tasks still cut were deleted
+		m.releaseCut()
+		if err := m.hooks.Run(m.pendingHooks); err != nil {
+			return fmt.Errorf("running hooks: %w", err)
+		}
+	}
+	return nil
+}
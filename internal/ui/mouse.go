@@ -0,0 +1,201 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// cardSpan records the lines a rendered task card occupies in its viewport
+type cardSpan struct {
+	task   int // index into the column's tasks
+	top    int // first line of the card in the viewport content
+	height int
+}
+
+// dragState tracks a card being dragged with the mouse
+type dragState struct {
+	column   int  // column the card was picked up from
+	task     int  // index of the card in that column
+	moved    bool // whether the pointer moved since the press
+	toColumn int  // column currently under the pointer
+	toTask   int  // index the card would be dropped at, -1 for the end
+}
+
+// boardLayout describes where the board is drawn on screen so that mouse
+// coordinates can be mapped back onto columns and tasks
+type boardLayout struct {
+	columnWidth  int // width passed to the column and header styles
+	headerTop    int
+	headerHeight int
+	columnTop    int // first line of the column borders
+	contentTop   int // first line of viewport content
+}
+
+func (m model) layout() boardLayout {
+	var l boardLayout
+	l.columnWidth = m.columnWidth()
+	l.headerTop = lipgloss.Height(m.renderTitle()) + 1
+	l.headerHeight = lipgloss.Height(m.renderColumnHeaders(l.columnWidth))
+	l.columnTop = l.headerTop + l.headerHeight + 1
+	l.contentTop = l.columnTop + columnStyle.GetBorderTopSize() + columnStyle.GetPaddingTop()
+	return l
+}
+
+// columnAt returns the index of the column drawn at screen column x, or -1
+func (m model) columnAt(x int) int {
+	if m.width == 0 || x < 0 {
+		return -1
+	}
+	l := m.layout()
+	outer := l.columnWidth + columnStyle.GetHorizontalBorderSize()
+	if outer <= 0 {
+		return -1
+	}
+	if i := x / outer; i < len(m.board.Columns) {
+		return i
+	}
+	return -1
+}
+
+// headerAt returns the index of the column header drawn at screen column x,
+// or -1. Headers are narrower than columns since they have no side borders.
+func (m model) headerAt(x int) int {
+	l := m.layout()
+	if l.columnWidth <= 0 || x < 0 {
+		return -1
+	}
+	if i := x / l.columnWidth; i < len(m.board.Columns) {
+		return i
+	}
+	return -1
+}
+
+// taskAt returns the index of the task drawn at screen row y in the given
+// column, or -1 if the row is outside every card
+func (m model) taskAt(column, y int) int {
+	l := m.layout()
+	vp := m.viewports[column]
+	if y < l.contentTop || y >= l.contentTop+vp.Height {
+		return -1
+	}
+	line := y - l.contentTop + vp.YOffset
+	for _, span := range m.cardSpans[column] {
+		if line >= span.top && line < span.top+span.height {
+			return span.task
+		}
+	}
+	return -1
+}
+
+// dropTarget returns the column and insertion index for a drop at (x, y).
+// Dropping below the last card appends to the column.
+func (m model) dropTarget(x, y int) (int, int) {
+	column := m.columnAt(x)
+	if column < 0 {
+		return -1, -1
+	}
+	return column, m.taskAt(column, y)
+}
+
+// boardCovered reports whether a full-screen view or a panel is open over
+// the board
+func (m model) boardCovered() bool {
+	return m.showHelp || m.showLog || m.showStats || m.showActivity || m.showDetail ||
+		m.review != nil || m.archive != nil || m.calendar != nil || m.editor != nil || m.dueSummary != nil
+}
+
+// updateMouse handles clicks on column headers and task cards, as well as
+// dragging cards between columns
+func (m model) updateMouse(msg tea.MouseMsg, cmds []tea.Cmd) (tea.Model, tea.Cmd) {
+	// Ignore the mouse while a dialog or prompt owns the keyboard, or the
+	// board is hidden
+	if m.inputMode || m.dialogType != noDialog || m.searching || m.filtering || m.commanding || m.boardCovered() {
+		return m, tea.Batch(cmds...)
+	}
+
+	switch msg.Action {
+	case tea.MouseActionMotion:
+		if m.drag != nil && msg.Button == tea.MouseButtonLeft {
+			m.drag.moved = true
+			m.drag.toColumn, m.drag.toTask = m.dropTarget(msg.X, msg.Y)
+			m.refreshViewports()
+		}
+		return m, tea.Batch(cmds...)
+
+	case tea.MouseActionRelease:
+		if m.drag != nil {
+			m.dropCard(msg.X, msg.Y)
+		}
+		return m, tea.Batch(cmds...)
+	}
+
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return m, tea.Batch(cmds...)
+	}
+
+	l := m.layout()
+	switch {
+	case msg.Y >= l.headerTop && msg.Y < l.headerTop+l.headerHeight:
+		if column := m.headerAt(msg.X); column >= 0 {
+			m.switchColumn(column)
+		}
+
+	case msg.Y >= l.columnTop:
+		column := m.columnAt(msg.X)
+		if column < 0 {
+			break
+		}
+		m.switchColumn(column)
+		if task := m.taskAt(column, msg.Y); task >= 0 {
+			m.cursorTask = task
+			m.updateViewportContent(column)
+
+			// Pressing on a card may start a drag
+			m.drag = &dragState{column: column, task: task, toColumn: column, toTask: task}
+		}
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// dropCard finishes a drag by moving the card to where the pointer was released
+func (m *model) dropCard(x, y int) {
+	drag := m.drag
+	m.drag = nil
+	if !drag.moved {
+		return
+	}
+
+	column, task := m.dropTarget(x, y)
+	if column < 0 || column == drag.column && (task == drag.task || task == drag.task+1) {
+		m.refreshViewports()
+		return
+	}
+
+	m.rememberCursor()
+	m.cursorColumn = column
+	m.cursorTask = m.board.MoveTask(drag.column, drag.task, column, task)
+	m.refreshViewports()
+	if m.searchQuery != "" {
+		m.runSearch()
+	}
+	if err := m.saveBoard(); err != nil {
+		m.logError(err)
+	}
+	if column != drag.column {
+		m.notify("Task moved to %s", m.board.Columns[column].Title)
+	}
+}
+
+// dragStatus describes the card being dragged and where it would land
+func (m model) dragStatus() string {
+	if m.drag == nil || !m.drag.moved {
+		return ""
+	}
+	task := m.board.Columns[m.drag.column].Tasks[m.drag.task]
+	target := "nowhere"
+	if m.drag.toColumn >= 0 {
+		target = m.board.Columns[m.drag.toColumn].Title
+	}
+	return searchPromptStyle.Render("Moving: ") + task.Title + helpStyle.Render(" → "+target)
+}
@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// notifyErrMsg reports a notification that couldn't be sent
+type notifyErrMsg struct{ err error }
+
+// checkAlerts returns a command sending notifications for tasks that fell
+// due. The record of sent alerts is shared between copies of the model.
+func (m model) checkAlerts(now time.Time) tea.Cmd {
+	if len(m.notifier.Sinks) == 0 {
+		return nil
+	}
+	alerts := m.notifier.PendingAlerts(&m.board, now, m.alerted)
+	if len(alerts) == 0 {
+		return nil
+	}
+	notifier := m.notifier
+	return func() tea.Msg {
+		if err := notifier.Deliver(alerts); err != nil {
+			return notifyErrMsg{err}
+		}
+		return nil
+	}
+}
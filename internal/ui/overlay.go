@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// overlayCenter draws fg in the middle of the screen on top of view
+func (m model) overlayCenter(fg, view string) string {
+	x := (m.width - lipgloss.Width(fg)) / 2
+	y := (m.height - lipgloss.Height(fg)) / 2
+	return placeOverlay(x, y, fg, view)
+}
+
+// placeOverlay draws fg on top of bg with its top-left corner at column x,
+// row y, keeping whatever of bg is visible on either side
+func placeOverlay(x, y int, fg, bg string) string {
+	bgLines := strings.Split(bg, "\n")
+	fgLines := strings.Split(fg, "\n")
+	fgWidth := lipgloss.Width(fg)
+	x = max(0, x)
+
+	for i, line := range fgLines {
+		row := y + i
+		if row < 0 {
+			continue
+		}
+		for row >= len(bgLines) {
+			bgLines = append(bgLines, "")
+		}
+
+		bgLine := bgLines[row]
+		if w := ansi.StringWidth(bgLine); w < x {
+			bgLine += strings.Repeat(" ", x-w)
+		}
+		left := ansi.Truncate(bgLine, x, "")
+		right := ansi.TruncateLeft(bgLine, x+fgWidth, "")
+		padding := strings.Repeat(" ", max(0, fgWidth-ansi.StringWidth(line)))
+
+		// Reset styles at the seams so colors don't bleed across them
+		bgLines[row] = left + ansi.ResetStyle + line + padding + ansi.ResetStyle + right
+	}
+	return strings.Join(bgLines, "\n")
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"gotask/internal/board"
+	"gotask/internal/plugin"
+	"gotask/internal/storage"
+)
+
+// pluginDoneMsg carries the board printed by a plugin action
+type pluginDoneMsg struct {
+	name   string
+	before []byte // board the plugin was given
+	output []byte
+	err    error
+}
+
+// runPluginAction runs a plugin on the board in the background
+func (m *model) runPluginAction(name string, args []string) tea.Cmd {
+	path, err := exec.LookPath(plugin.Prefix + name)
+	if err != nil {
+		m.logError(fmt.Errorf("no plugin %q: %w", name, err))
+		return nil
+	}
+	data, err := storage.Encode(m.board)
+	if err != nil {
+		m.logError(err)
+		return nil
+	}
+	env := plugin.Env()
+	if task := m.selectedTask(); task != nil {
+		env = append(env, "GOTASK_TASK_ID="+strconv.Itoa(task.ID))
+	}
+	return func() tea.Msg {
+		cmd := exec.Command(path, args...)
+		cmd.Stdin = bytes.NewReader(data)
+		cmd.Env = env
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				err = fmt.Errorf("%w: %s", err, msg)
+			}
+		}
+		return pluginDoneMsg{name: name, before: data, output: output, err: err}
+	}
+}
+
+// applyPluginAction replaces the board with the one a plugin printed
+func (m *model) applyPluginAction(msg pluginDoneMsg) {
+	if msg.err != nil {
+		m.logError(fmt.Errorf("plugin %s: %w", msg.name, msg.err))
+		return
+	}
+	if len(bytes.TrimSpace(msg.output)) == 0 {
+		m.notify("Plugin %s finished", msg.name)
+		return
+	}
+	if !bytes.Equal(m.snapshot(), msg.before) {
+		m.logError(fmt.Errorf("plugin %s: the board changed while it ran; its changes were dropped", msg.name))
+		return
+	}
+	var board board.Board
+	if err := json.Unmarshal(msg.output, &board); err != nil {
+		m.logError(fmt.Errorf("plugin %s printed an invalid board: %w", msg.name, err))
+		return
+	}
+	if len(board.Columns) != len(m.board.Columns) {
+		m.logError(fmt.Errorf("plugin %s printed a board with %d columns instead of %d", msg.name, len(board.Columns), len(m.board.Columns)))
+		return
+	}
+	m.board = board
+	m.lastID = max(m.lastID, m.board.MaxID())
+	m.clearMarks()
+	m.clampCursor()
+	if err := m.saveBoard(); err != nil {
+		m.logError(err)
+	}
+	if m.searchQuery != "" {
+		m.runSearch()
+	}
+	m.refreshViewports()
+	m.notify("Plugin %s changed the board", msg.name)
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"gotask/internal/board"
+	"gotask/internal/notify"
+	"gotask/internal/stats"
+)
+
+// pomodoro is a focus timer running for a task
+type pomodoro struct {
+	taskID int
+	end    time.Time
+	seq    int // tells this timer's ticks apart from a stopped one's
+}
+
+// pomodoroTickMsg updates the countdown once a second
+type pomodoroTickMsg struct {
+	seq int
+	at  time.Time
+}
+
+func pomodoroTick(seq int) tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return pomodoroTickMsg{seq: seq, at: t} })
+}
+
+// togglePomodoro starts a pomodoro for the selected task, or stops the one
+// that is running
+func (m *model) togglePomodoro() tea.Cmd {
+	if p := m.pomodoro; p != nil {
+		m.pomodoro = nil
+		m.notify("Pomodoro for #%d stopped", p.taskID)
+		return nil
+	}
+	task := m.selectedTask()
+	if task == nil {
+		return nil
+	}
+	m.pomodoroSeq++
+	m.pomodoro = &pomodoro{taskID: task.ID, end: time.Now().Add(stats.PomodoroLength), seq: m.pomodoroSeq}
+	m.notify("Pomodoro started for #%d", task.ID)
+	return pomodoroTick(m.pomodoroSeq)
+}
+
+// updatePomodoro counts down the running pomodoro. When it ends, it is
+// logged against the task and a notification goes out.
+func (m *model) updatePomodoro(msg pomodoroTickMsg) tea.Cmd {
+	p := m.pomodoro
+	if p == nil || msg.seq != p.seq {
+		return nil
+	}
+	if msg.at.Before(p.end) {
+		return pomodoroTick(p.seq)
+	}
+
+	m.pomodoro = nil
+	col, idx, ok := m.board.FindTask(p.taskID)
+	if !ok {
+		return nil
+	}
+	task := &m.board.Columns[col].Tasks[idx]
+	task.Pomodoros++
+	task.Record(board.EventPomodoro, "", stats.PomodoroLength.String())
+	m.refreshViewports()
+	m.save()
+	m.notify("Pomodoro for #%d done", task.ID)
+
+	notifier := m.notifier.DesktopOnly()
+	if len(notifier.Sinks) == 0 {
+		return nil
+	}
+	done := notify.Alert{Title: "Pomodoro done", Body: fmt.Sprintf("#%d %s%s", task.ID, board.IconPrefix(*task), task.Title), Tasks: []board.Task{*task}}
+	return func() tea.Msg {
+		if err := notifier.Deliver([]notify.Alert{done}); err != nil {
+			return notifyErrMsg{err}
+		}
+		return nil
+	}
+}
+
+// pomodoroStatus shows the running pomodoro's task and time left, e.g.
+// "🍅 #3 24:13"
+func (m model) pomodoroStatus() string {
+	p := m.pomodoro
+	if p == nil {
+		return ""
+	}
+	left := time.Until(p.end).Round(time.Second)
+	if left < 0 {
+		left = 0
+	}
+	mark := "🍅"
+	if !m.display.showIcons {
+		mark = "pomodoro"
+	}
+	return fmt.Sprintf("%s #%d %02d:%02d", mark, p.taskID, int(left.Minutes()), int(left.Seconds())%60)
+}
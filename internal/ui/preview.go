@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"gotask/internal/board"
+)
+
+// previewWidth is the widest the preview popup gets
+const previewWidth = 50
+
+// hasHiddenContent reports whether a task shows more in the preview than
+// fits on its card
+func (m model) hasHiddenContent(task board.Task) bool {
+	cardText := m.cardWidth() - m.display.card.GetHorizontalPadding() - 6
+	return task.Description != "" || len(task.Subtasks) > 0 ||
+		lipgloss.Width(fmt.Sprintf("#%d %s%s", task.ID, m.display.iconPrefix(task), task.Title)) > cardText
+}
+
+// renderPreview draws the popup with a task's full title and description
+func (m model) renderPreview(task board.Task, width int) string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Width(width).Render(fmt.Sprintf("#%d %s%s", task.ID, m.display.iconPrefix(task), task.Title)))
+
+	if task.Description != "" {
+		desc := lipgloss.NewStyle().Width(width).Render(task.Description)
+		lines := strings.Split(desc, "\n")
+		if len(lines) > 10 {
+			lines = append(lines[:10], helpStyle.Render("…"))
+		}
+		b.WriteString("\n\n" + strings.Join(lines, "\n"))
+	}
+
+	if len(task.Subtasks) > 0 {
+		done := 0
+		for _, st := range task.Subtasks {
+			if st.Done {
+				done++
+			}
+		}
+		b.WriteString("\n\n" + helpStyle.Render(fmt.Sprintf("%d/%d subtasks done", done, len(task.Subtasks))))
+	}
+
+	return previewStyle.Render(b.String())
+}
+
+// overlayPreview floats the preview for the selected task beside its card
+func (m model) overlayPreview(view string) string {
+	task := m.selectedTask()
+	if task == nil || !m.hasHiddenContent(*task) {
+		return view
+	}
+
+	var top int
+	found := false
+	for _, span := range m.cardSpans[m.cursorColumn] {
+		if span.task == m.cursorTask {
+			top, found = span.top, true
+			break
+		}
+	}
+	if !found {
+		return view
+	}
+
+	width := min(previewWidth, m.width/2)
+	popup := m.renderPreview(*task, width)
+	popupWidth, popupHeight := lipgloss.Width(popup), lipgloss.Height(popup)
+
+	// Prefer the right of the selected column, falling back to its left
+	l := m.layout()
+	outer := l.columnWidth + columnStyle.GetHorizontalBorderSize()
+	x := (m.cursorColumn + 1) * outer
+	if x+popupWidth > m.boardWidth() {
+		x = max(0, m.cursorColumn*outer-popupWidth)
+	}
+	y := l.contentTop + top - m.viewports[m.cursorColumn].YOffset
+	y = max(0, min(y, m.height-popupHeight-1))
+
+	return placeOverlay(x, y, popup, view)
+}
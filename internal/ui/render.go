@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"gotask/internal/config"
+)
+
+// now is the time the board is drawn at: the clock's, unless it was pinned
+// for a render
+func (m model) now() time.Time {
+	if !m.pinnedNow.IsZero() {
+		return m.pinnedNow
+	}
+	return time.Now()
+}
+
+// Render writes a single frame of the board cfg names to w, as it would
+// open in a terminal width by height at the given time. The board file
+// and its log are only read.
+func Render(w io.Writer, cfg config.Config, width, height int, now time.Time) error {
+	cfg.ReadOnly = true
+	// Whatever the terminal is, or whether there is one
+	if cfg.Background == "" || cfg.Background == "auto" {
+		cfg.Background = "dark"
+	}
+	lipgloss.SetColorProfile(termenv.TrueColor)
+
+	m := configuredModel(cfg, nil, nil)
+	if err := m.latestError(); err != nil {
+		return err
+	}
+	m.pinnedNow = now
+	m.dueSummary = nil
+	frame, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	_, err := fmt.Fprintln(w, frame.View())
+	return err
+}
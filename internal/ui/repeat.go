@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"errors"
+)
+
+// action is a mutating command that "." can apply again to the current
+// target, such as moving right or adding a tag
+type action struct {
+	name string
+	run  func(m *model)
+}
+
+// perform runs a repeatable action and remembers it for "."
+func (m *model) perform(name string, run func(m *model)) {
+	m.lastAction = &action{name: name, run: run}
+	run(m)
+}
+
+// repeatLast applies the last repeatable action to the current target
+func (m *model) repeatLast() {
+	if m.lastAction == nil {
+		m.logError(errors.New("nothing to repeat"))
+		return
+	}
+	m.lastAction.run(m)
+}
@@ -0,0 +1,267 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"gotask/internal/board"
+)
+
+// reviewChoice is what the weekly review decided to do with a task
+type reviewChoice int
+
+const (
+	reviewKeep reviewChoice = iota
+	reviewReschedule
+	reviewArchive
+	reviewMove
+)
+
+// reviewDecision is the choice made for a task, with the new due date or
+// column it needs
+type reviewDecision struct {
+	choice reviewChoice
+	due    *time.Time
+	column int
+}
+
+// weeklyReview walks through the open tasks one at a time, GTD style. The
+// decisions are only applied to the board when the review ends.
+type weeklyReview struct {
+	ids          []int // open tasks in board order
+	index        int   // task under review; len(ids) once all are decided
+	decisions    map[int]reviewDecision
+	rescheduling bool // whether the due date prompt is open
+	dueInput     textinput.Model
+	err          error // from the last due date entered
+}
+
+// openReview starts a weekly review of the open tasks
+func (m *model) openReview() {
+	r := &weeklyReview{decisions: make(map[int]reviewDecision)}
+	for i, col := range m.board.Columns {
+		if m.board.IsDone(i) {
+			continue
+		}
+		for _, task := range col.Tasks {
+			r.ids = append(r.ids, task.ID)
+		}
+	}
+	if len(r.ids) == 0 {
+		m.notify("No open tasks to review")
+		return
+	}
+	r.dueInput = textinput.New()
+	r.dueInput.Placeholder = "tomorrow, +1w, 2024-05-01, or empty to clear"
+	m.review = r
+}
+
+// decide records the choice for the task under review and moves on
+func (r *weeklyReview) decide(d reviewDecision) {
+	r.decisions[r.ids[r.index]] = d
+	r.index++
+}
+
+// tally counts the decisions made for each choice
+func (r *weeklyReview) tally() map[reviewChoice]int {
+	counts := make(map[reviewChoice]int)
+	for _, d := range r.decisions {
+		counts[d.choice]++
+	}
+	return counts
+}
+
+// summary describes the decisions made so far, e.g. "5 kept, 1 archived"
+func (r *weeklyReview) summary() string {
+	counts := r.tally()
+	var parts []string
+	for _, c := range []struct {
+		choice reviewChoice
+		verb   string
+	}{{reviewKeep, "kept"}, {reviewReschedule, "rescheduled"}, {reviewArchive, "archived"}, {reviewMove, "moved"}} {
+		if n := counts[c.choice]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, c.verb))
+		}
+	}
+	if len(parts) == 0 {
+		return "nothing decided"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// finishReview applies the review's decisions to the board and saves it
+// once. Tasks that went away in the meantime are skipped.
+func (m *model) finishReview() {
+	r := m.review
+	m.review = nil
+	now := time.Now()
+	changed := false
+	for _, id := range r.ids {
+		d, ok := r.decisions[id]
+		if !ok {
+			continue
+		}
+		col, idx, found := m.board.FindTask(id)
+		if !found {
+			continue
+		}
+		switch d.choice {
+		case reviewReschedule:
+			task := &m.board.Columns[col].Tasks[idx]
+			if board.FormatDue(task.Due) != board.FormatDue(d.due) {
+				task.Record(board.EventUpdated, "", "due date")
+				task.Due = d.due
+				changed = true
+			}
+		case reviewArchive:
+			m.board.ArchiveTask(id, now)
+			changed = true
+		case reviewMove:
+			if col != d.column {
+				m.board.MoveTask(col, idx, d.column, -1)
+				changed = true
+			}
+		}
+	}
+	if len(r.decisions) > 0 {
+		m.notify("Review done: %s", r.summary())
+	}
+	if changed {
+		m.clampCursor()
+		m.refreshViewports()
+		m.save()
+	}
+}
+
+// updateReview handles key presses during the weekly review
+func (m model) updateReview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	r := m.review
+	if r.rescheduling {
+		switch {
+		case key.Matches(msg, m.keys.Submit):
+			due, err := board.ParseDue(r.dueInput.Value(), time.Now())
+			if err != nil {
+				r.err = err
+				return m, nil
+			}
+			r.rescheduling, r.err = false, nil
+			r.dueInput.Blur()
+			r.decide(reviewDecision{choice: reviewReschedule, due: due})
+		case key.Matches(msg, m.keys.Cancel):
+			r.rescheduling, r.err = false, nil
+			r.dueInput.Blur()
+		default:
+			var cmd tea.Cmd
+			r.dueInput, cmd = r.dueInput.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Review, m.keys.Cancel, m.keys.Quit):
+		m.finishReview()
+	case key.Matches(msg, m.keys.Left):
+		r.index = max(0, r.index-1)
+	case r.index == len(r.ids):
+		if key.Matches(msg, m.keys.Keep) {
+			m.finishReview()
+		}
+	case key.Matches(msg, m.keys.Right):
+		r.index++
+	case key.Matches(msg, m.keys.Keep):
+		r.decide(reviewDecision{choice: reviewKeep})
+	case key.Matches(msg, m.keys.Reschedule):
+		r.rescheduling = true
+		r.dueInput.SetValue("")
+		if col, idx, ok := m.board.FindTask(r.ids[r.index]); ok {
+			r.dueInput.SetValue(board.FormatDue(m.board.Columns[col].Tasks[idx].Due))
+		}
+		r.dueInput.CursorEnd()
+		return m, r.dueInput.Focus()
+	case key.Matches(msg, m.keys.Archive):
+		r.decide(reviewDecision{choice: reviewArchive})
+	case key.Matches(msg, m.keys.FocusColumn):
+		if i := bindingIndex(msg, m.keys.FocusColumn); i < len(m.board.Columns) {
+			r.decide(reviewDecision{choice: reviewMove, column: i})
+		}
+	}
+	return m, nil
+}
+
+// describeDecision says what will happen to a task when the review ends
+func (m model) describeDecision(d reviewDecision) string {
+	switch d.choice {
+	case reviewReschedule:
+		if d.due == nil {
+			return "clear the due date"
+		}
+		return "reschedule to " + board.FormatDue(d.due)
+	case reviewArchive:
+		return "archive"
+	case reviewMove:
+		return "move to " + m.board.Columns[d.column].Title
+	}
+	return "keep"
+}
+
+// reviewViewBox renders the weekly review as a full-screen panel: the
+// progress through the tasks, then the task under review or, once every
+// task is decided, what will be saved
+func (m model) reviewViewBox() string {
+	r := m.review
+	width := max(20, m.width-6)
+	height := max(5, m.height-4)
+	label := lipgloss.NewStyle().Foreground(highlight).Bold(true)
+
+	done := min(r.index, len(r.ids))
+	progress := fmt.Sprintf("Weekly review · %d of %d · %s", done, len(r.ids), r.summary())
+	lines := []string{
+		label.Render(progress),
+		bar(float64(done), float64(len(r.ids)), width, special),
+		"",
+	}
+
+	if r.index == len(r.ids) {
+		lines = append(lines, "Every open task has been reviewed.", "",
+			helpStyle.Render(fmt.Sprintf("Press %s to save, %s to go back.", m.keys.Keep.Help().Key, m.keys.Left.Help().Key)))
+	} else if col, idx, ok := m.board.FindTask(r.ids[r.index]); !ok {
+		lines = append(lines, helpStyle.Render("This task is no longer on the board."))
+	} else {
+		task := m.board.Columns[col].Tasks[idx]
+		if d, ok := r.decisions[task.ID]; ok {
+			lines = append(lines, helpStyle.Render("Decided: "+m.describeDecision(d)), "")
+		}
+		prompt := 0
+		if r.rescheduling {
+			prompt = 2
+		}
+		detail := strings.Split(m.renderDetail(task, m.board.Columns[col].Title, width), "\n")
+		lines = append(lines, detail[:min(len(detail), max(1, height-len(lines)-prompt))]...)
+		if r.rescheduling {
+			due := "Due: " + r.dueInput.View()
+			if r.err != nil {
+				due += "  " + errorStyle.Render(r.err.Error())
+			}
+			lines = append(lines, "", due)
+		}
+	}
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(highlight).
+		Padding(0, 1).
+		Width(width + 2).
+		Height(height).
+		Render(strings.Join(lines, "\n"))
+	return box + "\n" + m.renderHints()
+}
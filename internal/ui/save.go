@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"gotask/internal/debug"
+	"gotask/internal/storage"
+)
+
+// autosavePolicy is when changes are written without being asked for
+type autosavePolicy struct {
+	off   bool          // only on :w and when quitting
+	every time.Duration // at most this often; 0 writes every change
+}
+
+// newAutosavePolicy reads the config's autosave setting: on, off, or a
+// duration. An invalid setting falls back to on, so no change is lost.
+func newAutosavePolicy(s string) (autosavePolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "on":
+		return autosavePolicy{}, nil
+	case "off":
+		return autosavePolicy{off: true}, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return autosavePolicy{}, fmt.Errorf("autosave: use on, off, or a duration such as 30s, not %q", s)
+	}
+	return autosavePolicy{every: d}, nil
+}
+
+// autosaveMsg fires when a change held back by the autosave interval is
+// due to be written
+type autosaveMsg struct{}
+
+// saveDoneMsg reports the outcome of a background save
+type saveDoneMsg struct {
+	err    error
+	at     time.Time
+	merged []byte        // board as saved, if changes made elsewhere were merged in
+	stamp  storage.Stamp // board file as written
+}
+
+// queueSave schedules a snapshot to be written. Snapshots queued while a
+// write is in flight collapse into the latest one.
+func (m *model) queueSave(data []byte) {
+	m.saveSeq++
+	m.pendingSave = data
+}
+
+// startSave writes the queued snapshot in the background unless a write
+// is already running or the autosave policy holds it back
+func (m *model) startSave() tea.Cmd {
+	if m.saving || m.pendingSave == nil {
+		return nil
+	}
+	if !m.saveRequested {
+		if m.autosave.off {
+			return nil
+		}
+		if wait := m.autosave.every - time.Since(m.lastSave); wait > 0 {
+			if m.autosaveDue {
+				return nil
+			}
+			m.autosaveDue = true
+			return tea.Tick(wait, func(time.Time) tea.Msg { return autosaveMsg{} })
+		}
+	}
+	m.saveRequested = false
+	saver, path, data, seq := m.saver, m.savePath, m.pendingSave, m.saveSeq
+	remote, known := m.remote, m.fileStamp
+	m.pendingSave = nil
+	m.saving = true
+	write := func() tea.Msg {
+		start := time.Now()
+		if remote != nil {
+			merged, err := remote.write(data)
+			debug.Log.Debug("save", "remote", true, "bytes", len(data), "seq", seq, "merged", merged != nil, "took", time.Since(start), "err", err)
+			return saveDoneMsg{err: err, at: time.Now(), merged: merged}
+		}
+		merged, stamp, err := writeMerging(saver, path, data, seq, known)
+		debug.Log.Debug("save", "path", path, "bytes", len(data), "seq", seq, "merged", merged != nil, "took", time.Since(start), "err", err)
+		return saveDoneMsg{err: err, at: time.Now(), merged: merged, stamp: stamp}
+	}
+	return tea.Batch(write, m.saveSpinner.Tick)
+}
+
+// finishSave records the outcome of a background save, showing changes
+// made elsewhere that it merged in. It fetches the attached board if it
+// changed while saving.
+func (m *model) finishSave(msg saveDoneMsg) tea.Cmd {
+	m.saving = false
+	m.saveErr = msg.err
+	if msg.err != nil {
+		m.logError(msg.err)
+		return nil
+	}
+	m.lastSave = msg.at
+	if m.remote == nil {
+		m.fileStamp = msg.stamp
+	}
+	if msg.merged != nil {
+		m.applyExternal(msg.merged)
+	}
+	if m.remoteStale && !m.busy() {
+		m.remoteStale = false
+		return m.fetchRemote()
+	}
+	return nil
+}
+
+// flushSave writes the queued snapshot right away, e.g. before quitting
+func (m *model) flushSave() error {
+	if m.pendingSave == nil {
+		return nil
+	}
+	data := m.pendingSave
+	m.pendingSave = nil
+	debug.Log.Debug("flush save", "bytes", len(data), "seq", m.saveSeq)
+	if m.remote != nil {
+		_, m.saveErr = m.remote.write(data)
+	} else {
+		_, m.fileStamp, m.saveErr = writeMerging(m.saver, m.savePath, data, m.saveSeq, m.fileStamp)
+	}
+	if m.saveErr == nil {
+		m.lastSave = time.Now()
+	}
+	return m.saveErr
+}
+
+// saveNow saves the board and waits for the write to finish
+func (m *model) saveNow() error {
+	if m.readOnly {
+		// Changes are undone as they are made, so there is nothing to save
+		return nil
+	}
+	if err := m.saveBoard(); err != nil {
+		return err
+	}
+	return m.flushSave()
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// jumpToEdge moves the cursor to the first (dir < 0) or last visible task
+// in the column
+func (m *model) jumpToEdge(dir int) {
+	tasks := m.board.Columns[m.cursorColumn].Tasks
+	start := len(tasks)
+	if dir < 0 {
+		start = -1
+	}
+	// Search inwards from just past the edge for the first visible task
+	if i := m.nextVisible(m.cursorColumn, start, -dir); i >= 0 {
+		m.cursorTask = i
+		m.updateViewportContent(m.cursorColumn)
+	}
+}
+
+// halfPage moves the cursor half a screen down (dir > 0) or up and scrolls
+// the column by the same amount, so the cursor keeps its place on screen
+func (m *model) halfPage(dir int) {
+	col := m.cursorColumn
+	vp := &m.viewports[col]
+	spans := m.cardSpans[col]
+	half := max(1, vp.Height/2)
+
+	current := -1
+	for i, span := range spans {
+		if span.task == m.cursorTask {
+			current = i
+			break
+		}
+	}
+	if current < 0 {
+		return
+	}
+
+	// Walk over cards until we have covered half a page of lines
+	target := current
+	for target+dir >= 0 && target+dir < len(spans) {
+		if abs(spans[target+dir].top-spans[current].top) > half {
+			break
+		}
+		target += dir
+	}
+	if target == current {
+		target = max(0, min(len(spans)-1, current+dir))
+	}
+
+	vp.SetYOffset(vp.YOffset + dir*half)
+	m.cursorTask = spans[target].task
+	m.updateViewportContent(col)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// hiddenTasks counts the cards in a column that are scrolled out of view
+// above and below its viewport. Partly visible cards don't count.
+func (m model) hiddenTasks(col int) (above, below int) {
+	vp := m.viewports[col]
+	for _, span := range m.cardSpans[col] {
+		switch {
+		case span.top+span.height <= vp.YOffset:
+			above++
+		case span.top >= vp.YOffset+vp.Height:
+			below++
+		}
+	}
+	return above, below
+}
+
+// addScrollIndicators writes "▲ 3 more" into the top border of a rendered
+// column and "▼ 5 more" into its bottom border
+func addScrollIndicators(column string, above, below int) string {
+	style := lipgloss.NewStyle().Foreground(mutedColor)
+	if above > 0 {
+		column = placeOverlay(2, 0, style.Render(fmt.Sprintf(" ▲ %d more ", above)), column)
+	}
+	if below > 0 {
+		column = placeOverlay(2, lipgloss.Height(column)-1, style.Render(fmt.Sprintf(" ▼ %d more ", below)), column)
+	}
+	return column
+}
@@ -0,0 +1,197 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rivo/uniseg"
+
+	"gotask/internal/board"
+)
+
+// searchMatch points at a task that matched the active search query
+type searchMatch struct {
+	column int
+	task   int
+}
+
+func newSearchInput() textinput.Model {
+	si := textinput.New()
+	si.Prompt = "/"
+	si.Placeholder = "search tasks..."
+	return si
+}
+
+// runSearch recomputes the list of matching tasks in board order, and the
+// archived ones if the archive is searched too
+func (m *model) runSearch() {
+	m.searchMatches = nil
+	m.archiveHits = nil
+	if m.searchQuery == "" {
+		m.searchIndex = 0
+		return
+	}
+
+	for i, col := range m.board.Columns {
+		for j, task := range col.Tasks {
+			if m.taskVisible(task) && board.MatchTask(m.searchQuery, task) {
+				m.searchMatches = append(m.searchMatches, searchMatch{column: i, task: j})
+			}
+		}
+	}
+
+	if m.searchArchive {
+		m.archiveHits = searchArchive(&m.board, m.searchQuery, time.Time{})
+	}
+
+	if m.searchIndex >= len(m.searchMatches) {
+		m.searchIndex = 0
+	}
+}
+
+// isSearchMatch reports whether the given task matched the active search
+func (m model) isSearchMatch(column, task int) bool {
+	for _, match := range m.searchMatches {
+		if match.column == column && match.task == task {
+			return true
+		}
+	}
+	return false
+}
+
+// highlightMatches styles the characters of text matched by the active
+// search query
+func (m model) highlightMatches(text string) string {
+	if m.searchQuery == "" {
+		return text
+	}
+	_, positions, ok := board.FuzzyMatch(m.searchQuery, text)
+	if !ok {
+		return text
+	}
+
+	// Style whole grapheme clusters, so that accents and emoji sequences
+	// aren't split apart by escape codes
+	var b strings.Builder
+	next, index := 0, 0
+	g := uniseg.NewGraphemes(text)
+	for g.Next() {
+		cluster := g.Str()
+		end := index + len(g.Runes())
+		matched := false
+		for next < len(positions) && positions[next] < end {
+			matched = true
+			next++
+		}
+		if matched {
+			b.WriteString(renderText(searchHighlightStyle, cluster))
+		} else {
+			b.WriteString(cluster)
+		}
+		index = end
+	}
+	return b.String()
+}
+
+// jumpToMatch moves the cursor to the match at index i, wrapping around
+func (m *model) jumpToMatch(i int) {
+	if len(m.searchMatches) == 0 {
+		return
+	}
+	i = (i%len(m.searchMatches) + len(m.searchMatches)) % len(m.searchMatches)
+	m.searchIndex = i
+
+	match := m.searchMatches[i]
+	m.rememberCursor()
+	m.cursorColumn = match.column
+	m.cursorTask = match.task
+	m.refreshViewports()
+}
+
+// clearSearch drops the active query and its highlights
+func (m *model) clearSearch() {
+	m.searchQuery = ""
+	m.searchMatches = nil
+	m.archiveHits = nil
+	m.searchIndex = 0
+	m.searchInput.Reset()
+	m.refreshViewports()
+}
+
+// updateSearch handles key presses while the search prompt is open
+func (m model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.searching = false
+		m.searchInput.Blur()
+		m.cursorColumn = m.searchOrigin.column
+		m.cursorTask = m.searchOrigin.task
+		m.clampCursor()
+		m.clearSearch()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Submit):
+		m.searching = false
+		m.searchInput.Blur()
+		if m.searchQuery == "" {
+			m.clearSearch()
+			return m, nil
+		}
+		m.jumpToMatch(0)
+		return m, nil
+
+	case key.Matches(msg, m.keys.AlsoArchive):
+		m.searchArchive = !m.searchArchive
+		m.runSearch()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+
+	// Search as you type, moving to the first match like vim's incsearch
+	if query := m.searchInput.Value(); query != m.searchQuery {
+		m.searchQuery = query
+		m.searchIndex = 0
+		m.runSearch()
+		if len(m.searchMatches) > 0 {
+			m.jumpToMatch(0)
+		} else {
+			m.cursorColumn = m.searchOrigin.column
+			m.cursorTask = m.searchOrigin.task
+			m.clampCursor()
+			m.refreshViewports()
+		}
+	}
+	return m, cmd
+}
+
+// searchStatus renders the search prompt or the result counter
+func (m model) searchStatus() string {
+	if m.searching {
+		return m.searchInput.View() + "  " + helpStyle.Render(m.searchCount())
+	}
+	if m.searchQuery == "" {
+		return ""
+	}
+	return searchPromptStyle.Render("/"+m.searchQuery) + "  " + helpStyle.Render(m.searchCount())
+}
+
+func (m model) searchCount() string {
+	count := "no matches"
+	switch {
+	case len(m.searchMatches) == 0:
+	case m.searching:
+		count = fmt.Sprintf("%d matches", len(m.searchMatches))
+	default:
+		count = fmt.Sprintf("%d/%d", m.searchIndex+1, len(m.searchMatches))
+	}
+	if m.searchArchive {
+		count += fmt.Sprintf(" · %d archived", len(m.archiveHits))
+	}
+	return count
+}
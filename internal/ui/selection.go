@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"gotask/internal/board"
+)
+
+// toggleMark adds or removes a task from the bulk selection
+func (m *model) toggleMark(id int) {
+	if m.marked[id] {
+		delete(m.marked, id)
+	} else {
+		m.marked[id] = true
+	}
+}
+
+// clearMarks empties the bulk selection
+func (m *model) clearMarks() {
+	for id := range m.marked {
+		delete(m.marked, id)
+	}
+}
+
+// targetIDs returns the tasks a command should act on: every marked task in
+// board order, or just the selected task when nothing is marked
+func (m *model) targetIDs() []int {
+	if len(m.marked) == 0 {
+		if task := m.selectedTask(); task != nil {
+			return []int{task.ID}
+		}
+		return nil
+	}
+
+	var ids []int
+	for _, col := range m.board.Columns {
+		for _, task := range col.Tasks {
+			if m.marked[task.ID] {
+				ids = append(ids, task.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// targetSummary describes the targets of a command, e.g. "3 tasks"
+func (m *model) targetSummary() string {
+	if n := len(m.targetIDs()); n != 1 {
+		return fmt.Sprintf("%d tasks", n)
+	}
+	return "task"
+}
+
+// moveMarked moves every marked task one column in the given direction.
+// Tasks already in the first or last column stay where they are.
+func (m *model) moveMarked(dir int) {
+	moved := 0
+	for _, id := range m.targetIDs() {
+		col, idx, ok := m.board.FindTask(id)
+		if !ok || col+dir < 0 || col+dir >= len(m.board.Columns) {
+			continue
+		}
+		m.board.MoveTask(col, idx, col+dir, -1)
+		moved++
+	}
+	if moved > 0 {
+		m.notify("%s moved", tasksPhrase(moved))
+	}
+	m.clampCursor()
+	m.refreshViewports()
+	if err := m.saveBoard(); err != nil {
+		m.logError(err)
+	}
+}
+
+// tagTargets adds the space or comma separated tags to every target task.
+// Tags prefixed with "-" are removed instead.
+func (m *model) tagTargets(input string) {
+	tags := strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' })
+	if len(tags) == 0 {
+		return
+	}
+
+	for _, id := range m.targetIDs() {
+		col, idx, ok := m.board.FindTask(id)
+		if !ok {
+			continue
+		}
+		task := &m.board.Columns[col].Tasks[idx]
+		before := strings.Join(task.Tags, ", ")
+		for _, tag := range tags {
+			if strings.HasPrefix(tag, "-") {
+				task.RemoveTag(strings.TrimPrefix(tag, "-"))
+			} else {
+				task.AddTag(strings.TrimPrefix(tag, "#"))
+			}
+		}
+		if after := strings.Join(task.Tags, ", "); after != before {
+			task.Record(board.EventTagged, before, after)
+		}
+	}
+	m.notify("Tags updated")
+	if err := m.saveBoard(); err != nil {
+		m.logError(err)
+	}
+}
+
+// moveTargets moves the marked tasks, or the selected task and the cursor
+// with it, one column in the given direction
+func (m *model) moveTargets(dir int) {
+	if len(m.marked) > 0 {
+		m.requestMoveMarked(dir)
+		return
+	}
+
+	dest := m.cursorColumn + dir
+	if dest < 0 || dest >= len(m.board.Columns) || m.selectedTask() == nil {
+		return
+	}
+	m.rememberCursor()
+	m.cursorTask = m.board.MoveTask(m.cursorColumn, m.cursorTask, dest, -1)
+	m.cursorColumn = dest
+	m.refreshViewports()
+	m.save()
+	m.notify("Task moved to %s", m.board.Columns[dest].Title)
+}
+
+// setTargetPriority gives every target task the same priority
+func (m *model) setTargetPriority(p board.Priority) {
+	m.updateTargetPriority(func(board.Priority) board.Priority { return p })
+}
+
+// shiftTargetPriority raises (delta > 0) or lowers each target's priority,
+// stopping at none and high
+func (m *model) shiftTargetPriority(delta int) {
+	m.updateTargetPriority(func(p board.Priority) board.Priority {
+		return board.Priority(min(int(board.PriorityHigh), max(int(board.PriorityNone), int(p)+delta)))
+	})
+}
+
+// setTargetPoints sets each target's estimate, 0 to clear it
+func (m *model) setTargetPoints(points float64) {
+	changed := false
+	for _, id := range m.targetIDs() {
+		col, idx, _ := m.board.FindTask(id)
+		task := &m.board.Columns[col].Tasks[idx]
+		if task.Points != points {
+			task.Record(board.EventUpdated, "", "points")
+			task.Points = points
+			changed = true
+		}
+	}
+	if changed {
+		m.refreshViewports()
+		m.save()
+	}
+}
+
+func (m *model) updateTargetPriority(next func(board.Priority) board.Priority) {
+	changed := false
+	for _, id := range m.targetIDs() {
+		col, idx, _ := m.board.FindTask(id)
+		task := &m.board.Columns[col].Tasks[idx]
+		if p := next(task.Priority); p != task.Priority {
+			task.Record(board.EventPrioritized, task.Priority.String(), p.String())
+			task.Priority = p
+			changed = true
+		}
+	}
+	if changed {
+		m.refreshViewports()
+		m.save()
+	}
+}
+
+// sendTargets moves the marked tasks, or the selected task and the cursor
+// with it, to the end of another column
+func (m *model) sendTargets(dest int) {
+	ids := m.targetIDs()
+	if len(ids) == 0 || dest < 0 || dest >= len(m.board.Columns) {
+		return
+	}
+
+	send := func(m *model) {
+		moved := 0
+		for _, id := range ids {
+			col, idx, ok := m.board.FindTask(id)
+			if !ok || col == dest {
+				continue
+			}
+			moved++
+			if len(ids) == 1 && col == m.cursorColumn {
+				m.rememberCursor()
+			}
+			pos := m.board.MoveTask(col, idx, dest, -1)
+			if len(ids) == 1 {
+				m.cursorColumn, m.cursorTask = dest, pos
+			}
+		}
+		m.clampCursor()
+		m.refreshViewports()
+		m.save()
+		if moved > 0 {
+			m.notify("%s moved to %s", tasksPhrase(moved), m.board.Columns[dest].Title)
+		}
+	}
+	if len(ids) == 1 {
+		send(m)
+		return
+	}
+	m.confirmOrRun(m.policies.bulk, ids, confirmation{
+		prompt: fmt.Sprintf("Move %d tasks to %s?", len(ids), m.board.Columns[dest].Title),
+		run:    send,
+	})
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"gotask/internal/board"
+)
+
+// sparkDays is how many days the column header sparklines cover
+const sparkDays = 14
+
+// sparkLevels draw a day's count from none to the busiest day's
+var sparkLevels = []string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+
+// columnFlow counts the tasks that entered and left each column on each
+// of the last days days, today last, archived tasks included
+func columnFlow(b *board.Board, days int, now time.Time) (in, out [][]int) {
+	index := make(map[string]int, len(b.Columns))
+	in, out = make([][]int, len(b.Columns)), make([][]int, len(b.Columns))
+	for i, col := range b.Columns {
+		index[col.Title] = i
+		in[i], out[i] = make([]int, days), make([]int, days)
+	}
+	first := board.DayOf(now).AddDate(0, 0, 1-days)
+	day := func(t time.Time) int {
+		if t.Before(first) {
+			return -1
+		}
+		return int(board.DayOf(t).Sub(first).Hours() / 24)
+	}
+	count := func(task board.Task) {
+		for _, s := range task.ColumnSpells() {
+			col, ok := index[s.Column]
+			if !ok {
+				continue
+			}
+			if d := day(s.Entered); d >= 0 && d < days {
+				in[col][d]++
+			}
+			if s.Left != nil {
+				if d := day(*s.Left); d >= 0 && d < days {
+					out[col][d]++
+				}
+			}
+		}
+	}
+	for _, col := range b.Columns {
+		for _, task := range col.Tasks {
+			count(task)
+		}
+	}
+	for _, task := range b.Archive {
+		count(task.Task)
+	}
+	return in, out
+}
+
+// sparkline draws counts as a row of bars scaled to the largest, or
+// nothing if they are all zero
+func sparkline(counts []int) string {
+	most := 0
+	for _, n := range counts {
+		most = max(most, n)
+	}
+	if most == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, n := range counts {
+		b.WriteString(sparkLevels[n*(len(sparkLevels)-1)/most])
+	}
+	return b.String()
+}
+
+// headerSparklines lays out a column's arrivals, and its departures if
+// there is room, after its title within width
+func headerSparklines(title string, in, out []int, width int) string {
+	if sparkline(in) == "" && sparkline(out) == "" {
+		return title
+	}
+	flat := func(counts []int) string {
+		if s := sparkline(counts); s != "" {
+			return s
+		}
+		return strings.Repeat(sparkLevels[0], len(counts))
+	}
+	room := width - lipgloss.Width(title)
+	for _, spark := range []string{"+" + flat(in) + " −" + flat(out), "+" + flat(in)} {
+		if w := lipgloss.Width(spark); w < room {
+			return title + strings.Repeat(" ", room-w) + spark
+		}
+	}
+	return title
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// minSplitWidth is the narrowest terminal the split view fits in
+const minSplitWidth = 80
+
+// splitPaneWidth is the width of the detail pane in split view, or 0 when
+// the split view is off
+func (m model) splitPaneWidth() int {
+	if !m.split {
+		return 0
+	}
+	return max(30, min(70, m.width*2/5))
+}
+
+// boardWidth is the width left for the board's columns
+func (m model) boardWidth() int {
+	return m.width - m.splitPaneWidth()
+}
+
+// toggleSplit shows or hides the detail pane beside the board
+func (m *model) toggleSplit() {
+	if !m.split && m.width < minSplitWidth {
+		m.logError(errors.New("the terminal is too narrow for split view"))
+		return
+	}
+	m.split = !m.split
+	m.resizeViewports()
+}
+
+// renderSplitPane draws the selected task's details to fit beside the board
+func (m model) renderSplitPane(height int) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(highlight).
+		Padding(0, 1)
+	width := m.splitPaneWidth() - style.GetHorizontalFrameSize()
+	inner := max(1, height-style.GetVerticalFrameSize())
+
+	content := helpStyle.Render("No task selected")
+	if task := m.selectedTask(); task != nil {
+		content = m.renderDetail(*task, m.board.Columns[m.cursorColumn].Title, width)
+	}
+	lines := strings.Split(content, "\n")
+	if len(lines) > inner {
+		lines = append(lines[:inner-1], helpStyle.Render("…"))
+	}
+	return style.Width(width + style.GetHorizontalPadding()).Height(inner).Render(strings.Join(lines, "\n"))
+}
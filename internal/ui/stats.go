@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"gotask/internal/board"
+	"gotask/internal/stats"
+)
+
+// barBlocks draw the fraction of a cell at the end of a bar, in eighths
+var barBlocks = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// maxBarWidth keeps bar charts readable on wide terminals
+const maxBarWidth = 50
+
+// bar draws value as a horizontal bar, scaled so that most fills width
+func bar(value, most float64, width int, color lipgloss.TerminalColor) string {
+	if value <= 0 || most <= 0 || width <= 0 {
+		return ""
+	}
+	eighths := max(1, int(value*float64(width*8)/most))
+	s := strings.Repeat("█", eighths/8) + barBlocks[eighths%8]
+	return lipgloss.NewStyle().Foreground(color).Render(s)
+}
+
+// barChart draws a labelled bar for each value, with the value after it
+func barChart[T int | float64](labels []string, values []T, colors []lipgloss.TerminalColor, width int) string {
+	labelWidth, countWidth := 0, 0
+	var most T
+	for i, label := range labels {
+		labelWidth = max(labelWidth, lipgloss.Width(label))
+		countWidth = max(countWidth, len(fmt.Sprint(values[i])))
+		if values[i] > most {
+			most = values[i]
+		}
+	}
+	labelWidth = min(labelWidth, width/3)
+	barWidth := max(1, min(maxBarWidth, width-labelWidth-countWidth-4))
+
+	lines := make([]string, len(labels))
+	for i, label := range labels {
+		label = ansi.Truncate(label, labelWidth, "…")
+		lines[i] = fmt.Sprintf("  %s%s %s %s", label, strings.Repeat(" ", labelWidth-lipgloss.Width(label)),
+			bar(float64(values[i]), float64(most), barWidth, colors[i%len(colors)]), helpStyle.Render(fmt.Sprint(values[i])))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// columnColors picks a color for each of n columns: the board's colors
+// for the first and last, and others in between
+func columnColors(n int) []lipgloss.TerminalColor {
+	middle := []lipgloss.TerminalColor{inProgColor, searchMatchColor, special, highlight}
+	colors := make([]lipgloss.TerminalColor, n)
+	for i := range colors {
+		switch i {
+		case 0:
+			colors[i] = todoColor
+		case n - 1:
+			colors[i] = doneColor
+		default:
+			colors[i] = middle[(i-1)%len(middle)]
+		}
+	}
+	return colors
+}
+
+// openStats shows the statistics screen
+func (m *model) openStats() {
+	m.showStats = true
+	m.statsView = viewport.New(max(20, m.width-6), max(5, m.height-4))
+	m.statsView.SetContent(m.renderStats(m.statsView.Width, time.Now()))
+}
+
+// renderStats lays out the statistics screen
+func (m model) renderStats(width int, now time.Time) string {
+	s := stats.Of(&m.board, now)
+	heading := lipgloss.NewStyle().Foreground(highlight).Bold(true).Underline(true)
+
+	var b strings.Builder
+	b.WriteString(heading.Render("Tasks by column") + "\n")
+	labels := make([]string, len(s.Columns))
+	counts := make([]int, len(s.Columns))
+	for i, col := range s.Columns {
+		labels[i], counts[i] = col.Title, col.Count
+	}
+	b.WriteString(barChart(labels, counts, columnColors(len(s.Columns)), width))
+
+	b.WriteString("\n\n" + heading.Render(fmt.Sprintf("Completed this week: %d", s.CompletedThisWeek())) + "\n")
+	monday := stats.StartOfWeek(now)
+	days := make([]string, 7)
+	for i := range days {
+		days[i] = monday.AddDate(0, 0, i).Format("Mon")
+	}
+	b.WriteString(barChart(days, s.CompletedByDay[:], []lipgloss.TerminalColor{doneColor}, width))
+
+	b.WriteString("\n\n" + heading.Render(fmt.Sprintf("Open tasks: %d", s.Open)))
+	if s.Open > 0 {
+		b.WriteString("\n  Average age " + stats.AgeDays(s.AverageAge))
+		oldest := fmt.Sprintf("  Oldest %s %s%s %s", taskIDStyle.Render(fmt.Sprintf("#%d", s.Oldest.ID)),
+			board.IconPrefix(*s.Oldest), s.Oldest.Title,
+			helpStyle.Render(fmt.Sprintf("· %s · %s old", s.OldestIn, stats.AgeDays(now.Sub(s.Oldest.CreatedAt)))))
+		b.WriteString("\n" + ansi.Truncate(oldest, width, "…"))
+	}
+
+	window := burnWindows[m.burnWindow]
+	b.WriteString("\n\n" + heading.Render(fmt.Sprintf("Burnup, last %d days", window)) + "\n")
+	b.WriteString(renderBurndown(stats.Burndown(&m.board, window, now), width))
+
+	b.WriteString("\n\n" + heading.Render(fmt.Sprintf("Cumulative flow, last %d days", window)) + "\n")
+	b.WriteString(renderFlow(labels, m.board.FlowDays(window, now), now, width))
+
+	b.WriteString("\n\n" + heading.Render(fmt.Sprintf("Time in column, last %d days", window)) + "\n")
+	b.WriteString(renderCycleTimes(stats.NewCycleTimes(&m.board, now.AddDate(0, 0, -window)), width))
+
+	b.WriteString("\n\n" + heading.Render(fmt.Sprintf("By tag, last %d days", window)) + "\n")
+	b.WriteString(renderTagStats(stats.ByTag(&m.board, now.AddDate(0, 0, -window)), width))
+
+	b.WriteString("\n\n" + heading.Render(fmt.Sprintf("Velocity, last %d weeks", stats.VelocityWeeks)) + "\n")
+	b.WriteString(renderVelocity(stats.Velocity(&m.board, stats.VelocityWeeks, now), width))
+
+	f, ok := stats.NewForecast(&m.board, window, now)
+	b.WriteString("\n\n" + heading.Render(fmt.Sprintf("Forecast, from the last %d days", window)) + "\n")
+	b.WriteString(renderForecast(f, ok, now, width))
+
+	b.WriteString("\n\n" + heading.Render("Focus, last 7 days") + "\n")
+	focus, sessions := stats.FocusDays(&m.board, 7, now)
+	b.WriteString(renderFocus(focus, sessions, now, width))
+
+	weeks := heatWeeks(width)
+	b.WriteString("\n\n" + heading.Render(fmt.Sprintf("Completions, last %d weeks", weeks)) + "\n")
+	b.WriteString(RenderHeatmap(stats.CompletionsByDay(&m.board), weeks, now))
+	return b.String()
+}
+
+// updateStats handles key presses while the statistics screen is open
+func (m model) updateStats(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Stats, m.keys.Cancel, m.keys.Quit):
+		m.showStats = false
+	case key.Matches(msg, m.keys.Up):
+		m.statsView.LineUp(1)
+	case key.Matches(msg, m.keys.Down):
+		m.statsView.LineDown(1)
+	case key.Matches(msg, m.keys.PrevPeriod):
+		m.burnWindow = max(0, m.burnWindow-1)
+		m.statsView.SetContent(m.renderStats(m.statsView.Width, time.Now()))
+	case key.Matches(msg, m.keys.NextPeriod):
+		m.burnWindow = min(len(burnWindows)-1, m.burnWindow+1)
+		m.statsView.SetContent(m.renderStats(m.statsView.Width, time.Now()))
+	}
+	return m, nil
+}
+
+// statsViewBox renders the statistics screen as a full-screen panel
+func (m model) statsViewBox() string {
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(highlight).
+		Padding(0, 1).
+		Render(m.statsView.View())
+	return box + "\n" + m.renderHints()
+}
@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// mode names the interaction mode shown at the left of the status bar
+func (m model) mode() string {
+	switch {
+	case m.editor != nil:
+		return "EDIT"
+	case m.showDetail:
+		return "DETAIL"
+	case m.showLog:
+		return "LOG"
+	case m.showStats:
+		return "STATS"
+	case m.showActivity:
+		return "ACTIVITY"
+	case m.review != nil:
+		return "REVIEW"
+	case m.archive != nil:
+		return "ARCHIVE"
+	case m.calendar != nil:
+		return "CALENDAR"
+	case m.dialogType == confirmDialog:
+		return "CONFIRM"
+	case m.dueSummary != nil:
+		return "DUE"
+	case m.inputMode && m.inputState == insertMode:
+		return "INSERT"
+	case m.inputMode:
+		return "INPUT"
+	case m.commanding:
+		return "COMMAND"
+	case m.searching:
+		return "SEARCH"
+	case m.filtering:
+		return "FILTER"
+	case m.drag != nil && m.drag.moved:
+		return "DRAG"
+	case m.visual:
+		return "VISUAL"
+	case m.pendingKey != "":
+		return "NORMAL " + m.pendingKey
+	case len(m.marked) > 0:
+		return fmt.Sprintf("SELECT %d", len(m.marked))
+	case m.focus != nil:
+		return "FOCUS"
+	}
+	return "NORMAL"
+}
+
+// boardName derives a display name from the board file, e.g. "kanban"
+// for ~/.kanban.json, or the server's address for an attached board
+func (m model) boardName() string {
+	if m.remote != nil {
+		if u, err := url.Parse(m.remote.url); err == nil {
+			return u.Host
+		}
+	}
+	name := filepath.Base(m.savePath)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	return strings.TrimPrefix(name, ".")
+}
+
+// saveStatus reports when the board was last written, or why it wasn't
+func (m model) saveStatus() string {
+	switch {
+	case m.readOnly:
+		return "read-only"
+	case m.saving:
+		return m.saveSpinner.View() + " saving"
+	case m.pendingSave != nil:
+		return "● unsaved"
+	case m.saveErr != nil:
+		return errorStyle.Copy().Inherit(statusBarStyle).Render("save failed")
+	case m.lastSave.IsZero():
+		return "not saved yet"
+	}
+	return "✓ saved " + m.lastSave.Format("15:04:05")
+}
+
+// progressWidth is the number of cells in the status bar's progress meter
+const progressWidth = 10
+
+// renderProgress draws a meter and percentage of finished tasks, e.g.
+// "▰▰▰▰▱▱▱▱▱▱ 40%", or nothing for an empty board
+func (m model) renderProgress() string {
+	done, total := m.board.Progress()
+	if total == 0 {
+		return ""
+	}
+	filled := done * progressWidth / total
+	meter := lipgloss.NewStyle().Foreground(doneColor).Inherit(statusBarStyle).Render(strings.Repeat("▰", filled)) +
+		statusBarStyle.Render(strings.Repeat("▱", progressWidth-filled))
+	return meter + statusBarStyle.Render(fmt.Sprintf(" %d%% ", done*100/total))
+}
+
+// renderStatusBar draws the single-line bar at the bottom of the board
+func (m model) renderStatusBar() string {
+	mode := m.mode()
+	if m.display.symbols {
+		mode = "[" + mode + "]"
+	}
+	left := statusModeStyle.Render(mode) + statusBarStyle.Render(" "+m.boardName()+" ")
+	if progress := m.renderProgress(); progress != "" {
+		left += statusBarStyle.Render(" ") + progress
+	}
+
+	counts := make([]string, len(m.board.Columns))
+	for i, col := range m.board.Columns {
+		counts[i] = fmt.Sprintf("%s %d", col.Title, len(col.Tasks))
+	}
+	middle := strings.Join(counts, " · ")
+	if m.filter.active() {
+		shown, total := 0, 0
+		for _, col := range m.board.Columns {
+			for _, task := range col.Tasks {
+				total++
+				if m.filter.matches(task) {
+					shown++
+				}
+			}
+		}
+		middle += fmt.Sprintf("  filter %q %d/%d", m.filter.expr, shown, total)
+	}
+	// Broken limits go first so they survive truncation
+	if broken := m.limits.violations(&m.board, m.now()); len(broken) > 0 {
+		warn := lipgloss.NewStyle().Foreground(inProgColor).Bold(true).Inherit(statusBarStyle)
+		middle = warn.Render("⚠ "+strings.Join(broken, " · ")) + "  " + middle
+	}
+	if err := m.latestError(); err != nil {
+		summary, _, _ := strings.Cut(err.Error(), "\n")
+		middle += "  " + errorStyle.Copy().Inherit(statusBarStyle).Render("error: "+summary)
+	}
+
+	right := fmt.Sprintf(" %s  %s help ", m.saveStatus(), m.keys.Help.Help().Key)
+	if timer := m.pomodoroStatus(); timer != "" {
+		right = " " + timer + " " + right
+	}
+	if focus := m.focusStatus(); focus != "" {
+		right = " " + focus + " " + right
+	}
+
+	// Truncate the middle section first so the mode and save status stay put
+	room := m.width - lipgloss.Width(left) - lipgloss.Width(right) - 2
+	middle = ansi.Truncate(middle, max(0, room), "…")
+	gap := max(0, room-lipgloss.Width(middle))
+
+	return left + statusBarStyle.Render(" "+middle+strings.Repeat(" ", gap)+" ") +
+		statusBarStyle.Render(right)
+}
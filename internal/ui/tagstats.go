@@ -0,0 +1,17 @@
+package ui
+
+import (
+	"strings"
+
+	"gotask/internal/stats"
+)
+
+// renderTagStats lays out the breakdown by tag for the statistics screen
+func renderTagStats(tags []stats.Tag, width int) string {
+	if len(tags) == 0 {
+		return helpStyle.Render("  No tasks")
+	}
+	var b strings.Builder
+	stats.WriteTags(&b, tags)
+	return renderTable(b.String(), width)
+}
@@ -0,0 +1,359 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/lipgloss"
+
+	"gotask/internal/board"
+)
+
+// theme is a named set of colors used to build every style in the app
+type theme struct {
+	Subtle     lipgloss.TerminalColor // unfocused borders
+	Highlight  lipgloss.TerminalColor // title, dialogs, prompts
+	Special    lipgloss.TerminalColor // selection marker, insert mode
+	Todo       lipgloss.TerminalColor // first column
+	InProgress lipgloss.TerminalColor // middle column
+	Done       lipgloss.TerminalColor // last column
+	TitleText  lipgloss.TerminalColor // text drawn on the highlight color
+	Muted      lipgloss.TerminalColor // help text
+	Error      lipgloss.TerminalColor // errors and destructive dialogs
+	Match      lipgloss.TerminalColor // search matches and drop targets
+}
+
+// builtinThemes are the themes that can be selected by name in the config
+var builtinThemes = map[string]theme{
+	// default adapts to the terminal background (see Config.Background)
+	"default": {
+		Subtle:     lipgloss.AdaptiveColor{Light: "#BDBFB5", Dark: "#383838"},
+		Highlight:  lipgloss.AdaptiveColor{Light: "#5A3FD1", Dark: "#7D56F4"}, // Purple
+		Special:    lipgloss.AdaptiveColor{Light: "#1E8449", Dark: "#73F59F"}, // Green
+		Todo:       lipgloss.AdaptiveColor{Light: "#C0392B", Dark: "#E06C75"}, // Red
+		InProgress: lipgloss.AdaptiveColor{Light: "#A66A00", Dark: "#E5C07B"}, // Yellow
+		Done:       lipgloss.AdaptiveColor{Light: "#2F7D32", Dark: "#98C379"}, // Green
+		TitleText:  lipgloss.Color("#FFFFFF"),
+		Muted:      lipgloss.AdaptiveColor{Light: "#6B6B6B", Dark: "#626262"},
+		Error:      lipgloss.AdaptiveColor{Light: "#C0392B", Dark: "#E06C75"},
+		Match:      lipgloss.AdaptiveColor{Light: "#7D3CC8", Dark: "#AD8CFF"}, // Light purple
+	},
+	// light is tuned for light backgrounds regardless of detection
+	"light": {
+		Subtle:     lipgloss.Color("#BDBFB5"),
+		Highlight:  lipgloss.Color("#5A3FD1"),
+		Special:    lipgloss.Color("#1E8449"),
+		Todo:       lipgloss.Color("#C0392B"),
+		InProgress: lipgloss.Color("#A66A00"),
+		Done:       lipgloss.Color("#2F7D32"),
+		TitleText:  lipgloss.Color("#FFFFFF"),
+		Muted:      lipgloss.Color("#6B6B6B"),
+		Error:      lipgloss.Color("#C0392B"),
+		Match:      lipgloss.Color("#7D3CC8"),
+	},
+	"gruvbox": {
+		Subtle:     lipgloss.AdaptiveColor{Light: "#D5C4A1", Dark: "#504945"},
+		Highlight:  lipgloss.AdaptiveColor{Light: "#AF3A03", Dark: "#FE8019"}, // Orange
+		Special:    lipgloss.AdaptiveColor{Light: "#427B58", Dark: "#8EC07C"}, // Aqua
+		Todo:       lipgloss.AdaptiveColor{Light: "#9D0006", Dark: "#FB4934"}, // Red
+		InProgress: lipgloss.AdaptiveColor{Light: "#B57614", Dark: "#FABD2F"}, // Yellow
+		Done:       lipgloss.AdaptiveColor{Light: "#79740E", Dark: "#B8BB26"}, // Green
+		TitleText:  lipgloss.AdaptiveColor{Light: "#FBF1C7", Dark: "#282828"},
+		Muted:      lipgloss.AdaptiveColor{Light: "#7C6F64", Dark: "#928374"},
+		Error:      lipgloss.AdaptiveColor{Light: "#9D0006", Dark: "#FB4934"},
+		Match:      lipgloss.AdaptiveColor{Light: "#8F3F71", Dark: "#D3869B"}, // Purple
+	},
+	"nord": {
+		Subtle:     lipgloss.AdaptiveColor{Light: "#D8DEE9", Dark: "#4C566A"},
+		Highlight:  lipgloss.AdaptiveColor{Light: "#5E81AC", Dark: "#88C0D0"}, // Frost
+		Special:    lipgloss.AdaptiveColor{Light: "#4C7A5A", Dark: "#A3BE8C"}, // Green
+		Todo:       lipgloss.AdaptiveColor{Light: "#BF616A", Dark: "#BF616A"}, // Red
+		InProgress: lipgloss.AdaptiveColor{Light: "#B48A3C", Dark: "#EBCB8B"}, // Yellow
+		Done:       lipgloss.AdaptiveColor{Light: "#4C7A5A", Dark: "#A3BE8C"}, // Green
+		TitleText:  lipgloss.AdaptiveColor{Light: "#ECEFF4", Dark: "#2E3440"},
+		Muted:      lipgloss.AdaptiveColor{Light: "#4C566A", Dark: "#616E88"},
+		Error:      lipgloss.Color("#BF616A"),
+		Match:      lipgloss.AdaptiveColor{Light: "#B48EAD", Dark: "#B48EAD"}, // Purple
+	},
+	"monochrome": {
+		Subtle:     lipgloss.NoColor{},
+		Highlight:  lipgloss.NoColor{},
+		Special:    lipgloss.NoColor{},
+		Todo:       lipgloss.NoColor{},
+		InProgress: lipgloss.NoColor{},
+		Done:       lipgloss.NoColor{},
+		TitleText:  lipgloss.NoColor{},
+		Muted:      lipgloss.NoColor{},
+		Error:      lipgloss.NoColor{},
+		Match:      lipgloss.NoColor{},
+	},
+	// high-contrast sticks to the basic 16 colors at their brightest
+	"high-contrast": {
+		Subtle:     lipgloss.Color("15"),
+		Highlight:  lipgloss.Color("11"),
+		Special:    lipgloss.Color("14"),
+		Todo:       lipgloss.Color("15"),
+		InProgress: lipgloss.Color("11"),
+		Done:       lipgloss.Color("10"),
+		TitleText:  lipgloss.Color("0"),
+		Muted:      lipgloss.Color("7"),
+		Error:      lipgloss.Color("9"),
+		Match:      lipgloss.Color("13"),
+	},
+}
+
+// colorSlots maps the color names accepted in the config to theme fields
+func (t *theme) colorSlots() map[string]*lipgloss.TerminalColor {
+	return map[string]*lipgloss.TerminalColor{
+		"subtle":      &t.Subtle,
+		"highlight":   &t.Highlight,
+		"special":     &t.Special,
+		"todo":        &t.Todo,
+		"in_progress": &t.InProgress,
+		"done":        &t.Done,
+		"title_text":  &t.TitleText,
+		"muted":       &t.Muted,
+		"error":       &t.Error,
+		"match":       &t.Match,
+	}
+}
+
+// Styles
+var (
+	// Terminal colors
+	subtle      lipgloss.TerminalColor
+	highlight   lipgloss.TerminalColor
+	special     lipgloss.TerminalColor
+	todoColor   lipgloss.TerminalColor
+	inProgColor lipgloss.TerminalColor
+	doneColor   lipgloss.TerminalColor
+	mutedColor  lipgloss.TerminalColor
+	errorColor  lipgloss.TerminalColor
+
+	searchMatchColor lipgloss.TerminalColor
+	dragTargetColor  lipgloss.TerminalColor
+
+	titleStyle           lipgloss.Style
+	columnHeaderStyle    lipgloss.Style
+	columnStyle          lipgloss.Style
+	todoColumnStyle      lipgloss.Style
+	inProgColumnStyle    lipgloss.Style
+	doneColumnStyle      lipgloss.Style
+	itemStyle            lipgloss.Style
+	selectedItemStyle    lipgloss.Style
+	helpStyle            lipgloss.Style
+	dialogBoxStyle       lipgloss.Style
+	confirmDialogStyle   lipgloss.Style
+	errorStyle           lipgloss.Style
+	searchPromptStyle    lipgloss.Style
+	filterStyle          lipgloss.Style
+	markedStyle          lipgloss.Style
+	taskIDStyle          lipgloss.Style
+	searchHighlightStyle lipgloss.Style
+	previewStyle         lipgloss.Style
+	statusBarStyle       lipgloss.Style
+	statusModeStyle      lipgloss.Style
+	dueStyle             lipgloss.Style
+	dueSoonStyle         lipgloss.Style
+	dueTodayStyle        lipgloss.Style
+	overdueStyle         lipgloss.Style
+	priorityStyles       []lipgloss.Style // indexed by Priority
+	toastStyle           lipgloss.Style
+)
+
+func init() {
+	setTheme(builtinThemes["default"])
+}
+
+// applyTheme selects a built-in theme by name and applies color overrides
+// from the config. Unknown names fall back to the default theme.
+// setBackground tells adaptive colors whether the terminal is dark or
+// light. "auto" (or "") asks the terminal, which has to happen before the
+// program takes over its input.
+func setBackground(background string) error {
+	switch background {
+	case "", "auto":
+		lipgloss.SetHasDarkBackground(lipgloss.HasDarkBackground())
+	case "dark":
+		lipgloss.SetHasDarkBackground(true)
+	case "light":
+		lipgloss.SetHasDarkBackground(false)
+	default:
+		return fmt.Errorf("unknown background %q (want auto, dark, or light)", background)
+	}
+	return nil
+}
+
+func applyTheme(name string, colors map[string]string) error {
+	if name == "" {
+		name = "default"
+	}
+	theme, ok := builtinThemes[name]
+	if !ok {
+		setTheme(builtinThemes["default"])
+		return fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(themeNames(), ", "))
+	}
+
+	slots := theme.colorSlots()
+	var unknown []string
+	for slot, value := range colors {
+		color, ok := slots[slot]
+		if !ok {
+			unknown = append(unknown, slot)
+			continue
+		}
+		*color = lipgloss.Color(value)
+	}
+	setTheme(theme)
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown theme colors in config: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+func themeNames() []string {
+	names := make([]string, 0, len(builtinThemes))
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// setTheme rebuilds every style from the theme's colors
+func setTheme(t theme) {
+	subtle = t.Subtle
+	highlight = t.Highlight
+	special = t.Special
+	todoColor = t.Todo
+	inProgColor = t.InProgress
+	doneColor = t.Done
+	mutedColor = t.Muted
+	errorColor = t.Error
+	searchMatchColor = t.Match
+	dragTargetColor = t.Highlight
+
+	titleStyle = lipgloss.NewStyle().
+		MarginLeft(1).
+		Bold(true).
+		Foreground(t.TitleText).
+		Background(highlight).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(highlight).
+		Padding(0, 2)
+
+	columnHeaderStyle = lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderBottom(true).
+		BorderForeground(highlight).
+		Foreground(highlight).
+		Bold(true).
+		Padding(0, 1)
+
+	columnStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(subtle).
+		Padding(1, 2)
+
+	todoColumnStyle = columnStyle.Copy().BorderForeground(todoColor)
+	inProgColumnStyle = columnStyle.Copy().BorderForeground(inProgColor)
+	doneColumnStyle = columnStyle.Copy().BorderForeground(doneColor)
+
+	itemStyle = lipgloss.NewStyle().
+		PaddingLeft(4).
+		PaddingBottom(1)
+
+	selectedItemStyle = lipgloss.NewStyle().
+		PaddingLeft(2).
+		Foreground(special).
+		SetString("❯ ")
+
+	helpStyle = lipgloss.NewStyle().
+		Foreground(mutedColor)
+
+	dialogBoxStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(highlight).
+		Padding(1, 0).
+		Width(30).
+		Height(3)
+
+	confirmDialogStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(errorColor).
+		Padding(1, 0).
+		Width(40).
+		Height(5)
+
+	errorStyle = lipgloss.NewStyle().Foreground(errorColor)
+
+	searchPromptStyle = lipgloss.NewStyle().
+		Foreground(highlight).
+		Bold(true)
+
+	filterStyle = lipgloss.NewStyle().
+		Foreground(inProgColor).
+		Bold(true)
+
+	markedStyle = lipgloss.NewStyle().Foreground(special).Bold(true)
+
+	taskIDStyle = lipgloss.NewStyle().Foreground(mutedColor)
+
+	previewStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(special).
+		Padding(0, 1)
+
+	searchHighlightStyle = lipgloss.NewStyle().
+		Foreground(searchMatchColor).
+		Bold(true).
+		Underline(true)
+
+	statusBarStyle = lipgloss.NewStyle().
+		Foreground(mutedColor).
+		Background(subtle)
+
+	statusModeStyle = lipgloss.NewStyle().
+		Foreground(t.TitleText).
+		Background(highlight).
+		Bold(true).
+		Padding(0, 1)
+
+	dueStyle = lipgloss.NewStyle().Foreground(mutedColor)
+	dueSoonStyle = lipgloss.NewStyle().Foreground(inProgColor)
+	dueTodayStyle = lipgloss.NewStyle().Foreground(errorColor).Bold(true)
+	overdueStyle = lipgloss.NewStyle().
+		Foreground(t.TitleText).
+		Background(errorColor).
+		Bold(true).
+		Padding(0, 1)
+
+	toastStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(special).
+		Padding(0, 1)
+
+	priorityStyles = []lipgloss.Style{
+		board.PriorityNone:   lipgloss.NewStyle(),
+		board.PriorityLow:    lipgloss.NewStyle().Foreground(mutedColor),
+		board.PriorityMedium: lipgloss.NewStyle().Foreground(inProgColor).Bold(true),
+		board.PriorityHigh:   lipgloss.NewStyle().Foreground(errorColor).Bold(true),
+	}
+}
+
+// helpStyles styles the generated key help to match the theme
+func helpStyles() help.Styles {
+	styles := help.New().Styles
+	styles.ShortKey = lipgloss.NewStyle().Foreground(highlight)
+	styles.FullKey = styles.ShortKey
+	styles.ShortDesc = lipgloss.NewStyle().Foreground(mutedColor)
+	styles.FullDesc = styles.ShortDesc
+	styles.ShortSeparator = lipgloss.NewStyle().Foreground(subtle)
+	styles.FullSeparator = styles.ShortSeparator
+	styles.Ellipsis = styles.ShortSeparator
+	return styles
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// toastDuration is how long a toast stays up
+	toastDuration = 3 * time.Second
+	// maxToasts is how many toasts are shown at once; older ones drop off
+	maxToasts = 3
+)
+
+// toast is a short message confirming something happened
+type toast struct {
+	id        int
+	text      string
+	scheduled bool // whether its dismissal tick has been started
+}
+
+// toastExpiredMsg dismisses the toast with the given id
+type toastExpiredMsg int
+
+// notify queues a toast, e.g. m.notify("%s moved to %s", ...)
+func (m *model) notify(format string, args ...any) {
+	m.nextToast++
+	m.toasts = append(m.toasts, toast{id: m.nextToast, text: fmt.Sprintf(format, args...)})
+	if len(m.toasts) > maxToasts {
+		m.toasts = m.toasts[len(m.toasts)-maxToasts:]
+	}
+}
+
+// scheduleToasts starts the dismissal timer for every new toast
+func (m *model) scheduleToasts() tea.Cmd {
+	var cmds []tea.Cmd
+	for i := range m.toasts {
+		if m.toasts[i].scheduled {
+			continue
+		}
+		m.toasts[i].scheduled = true
+		id := m.toasts[i].id
+		cmds = append(cmds, tea.Tick(toastDuration, func(time.Time) tea.Msg { return toastExpiredMsg(id) }))
+	}
+	return tea.Batch(cmds...)
+}
+
+// dismissToast removes an expired toast
+func (m *model) dismissToast(id int) {
+	for i, t := range m.toasts {
+		if t.id == id {
+			m.toasts = append(m.toasts[:i], m.toasts[i+1:]...)
+			return
+		}
+	}
+}
+
+// tasksPhrase starts a toast about n tasks: "Task" or "3 tasks"
+func tasksPhrase(n int) string {
+	if n == 1 {
+		return "Task"
+	}
+	return fmt.Sprintf("%d tasks", n)
+}
+
+// overlayToasts stacks the toasts in the bottom right corner of the view,
+// just above the hint line and status bar
+func (m model) overlayToasts(view string) string {
+	if len(m.toasts) == 0 {
+		return view
+	}
+	texts := make([]string, len(m.toasts))
+	for i, t := range m.toasts {
+		texts[i] = t.text
+	}
+	box := toastStyle.Render(strings.Join(texts, "\n"))
+	x := m.width - lipgloss.Width(box) - 1
+	y := strings.Count(view, "\n") - 1 - lipgloss.Height(box)
+	return placeOverlay(x, y, box, view)
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/storage"
+)
+
+// snapshot serializes the board so it can be restored later
+func (m *model) snapshot() []byte {
+	data, err := storage.Encode(m.board)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// openEvents opens the board's change log, which the board is loaded
+// from. Attached boards keep theirs in memory, for undo within the
+// session, and read-only boards keep what they see in memory.
+func (m *model) openEvents() {
+	path := storage.EventLogPath(m.savePath)
+	if m.remote != nil {
+		path = ""
+	}
+	var err error
+	if m.events, err = storage.OpenEventLog(path); err != nil {
+		m.logError(err)
+	}
+	if m.readOnly {
+		m.events.Path = ""
+	}
+}
+
+// recordExternal logs changes to the board made elsewhere, so that they
+// aren't taken for ours when undoing
+func (m *model) recordExternal() {
+	if err := m.events.Record(storage.EventExternal, m.board, time.Now()); err != nil {
+		m.logError(err)
+	}
+}
+
+// undo reverts the last change in the change log, even one made in an
+// earlier session
+func (m *model) undo() {
+	m.step(storage.EventUndo, "Undid")
+}
+
+// redo makes the last undone change again
+func (m *model) redo() {
+	m.step(storage.EventRedo, "Redid")
+}
+
+// step undoes or redoes a change and shows the board as it is after
+func (m *model) step(kind, verb string) {
+	if m.readOnly {
+		m.logError(storage.ErrReadOnly)
+		return
+	}
+	board, change, err := m.events.Step(kind, time.Now())
+	if errors.Is(err, storage.ErrNoStep) {
+		return
+	} else if err != nil {
+		m.logError(err)
+		return
+	}
+	data, err := storage.Encode(board)
+	if err != nil {
+		m.logError(err)
+		return
+	}
+	m.restore(data)
+	m.notify("%s: %s", verb, change.Summary)
+}
+
+// restore replaces the board with a snapshot and writes it to disk
+func (m *model) restore(data []byte) {
+	if m.reset(data) {
+		m.queueSave(data)
+	}
+}
+
+// reset replaces the board with a snapshot, reporting whether it could
+func (m *model) reset(data []byte) bool {
+	var board board.Board
+	if err := json.Unmarshal(data, &board); err != nil {
+		m.logError(err)
+		return false
+	}
+	m.board = board
+	m.savedData = data
+	m.clearMarks()
+	m.clampCursor()
+	if m.searchQuery != "" {
+		m.runSearch()
+	}
+	m.refreshViewports()
+	return true
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"gotask/internal/stats"
+)
+
+// renderVelocity draws the points finished each week, with the average
+// and whether the latest full week was above or below it
+func renderVelocity(v []stats.WeekVelocity, width int) string {
+	labels := make([]string, len(v))
+	points := make([]float64, len(v))
+	estimated := false
+	for i, week := range v {
+		labels[i] = week.Start.Format("Jan 2")
+		points[i] = stats.RoundTenth(week.Points)
+		estimated = estimated || week.Points > 0
+	}
+	if !estimated {
+		return helpStyle.Render("  No finished tasks with points yet; estimate tasks with :points")
+	}
+	chart := barChart(labels, points, []lipgloss.TerminalColor{special}, width)
+	avg := stats.AverageVelocity(v)
+	trend := ""
+	if len(v) >= 2 && avg > 0 {
+		switch last := v[len(v)-2].Points; {
+		case last > avg*1.1:
+			trend = ", last week above it"
+		case last < avg*0.9:
+			trend = ", last week below it"
+		default:
+			trend = ", last week about even"
+		}
+	}
+	return chart + "\n" + helpStyle.Render(fmt.Sprintf("  Average %s pt a week over %d full weeks%s",
+		strconv.FormatFloat(stats.RoundTenth(avg), 'f', -1, 64), len(v)-1, trend))
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startVisual begins selecting a range of tasks from the cursor
+func (m *model) startVisual() {
+	task := m.selectedTask()
+	if task == nil {
+		return
+	}
+	m.visual = true
+	m.visualAnchor = task.ID
+	m.syncVisual()
+}
+
+// syncVisual marks every visible task between the anchor and the cursor.
+// The range lives in the marks so every bulk action works on it as is.
+func (m *model) syncVisual() {
+	m.clearMarks()
+	col, anchor, ok := m.board.FindTask(m.visualAnchor)
+	if !ok || col != m.cursorColumn {
+		m.visual = false
+		m.refreshViewports()
+		return
+	}
+
+	lo, hi := min(anchor, m.cursorTask), max(anchor, m.cursorTask)
+	for i := lo; i <= hi; i++ {
+		if task := m.board.Columns[col].Tasks[i]; m.taskVisible(task) {
+			m.marked[task.ID] = true
+		}
+	}
+	m.updateViewportContent(col)
+}
+
+// updateVisual handles the keys that behave differently in visual mode.
+// Any key other than cursor movement ends visual mode, leaving the range marked
+// for the action that follows. It reports whether the key was consumed.
+func (m *model) updateVisual(msg tea.KeyMsg) bool {
+	if !m.visual {
+		if key.Matches(msg, m.keys.Visual) {
+			m.startVisual()
+			return true
+		}
+		return false
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Up, m.keys.Down, m.keys.Top, m.keys.Bottom, m.keys.HalfPageDown, m.keys.HalfPageUp):
+		return false
+
+	case key.Matches(msg, m.keys.Visual, m.keys.Cancel):
+		m.visual = false
+		m.clearMarks()
+		m.refreshViewports()
+		return true
+
+	// Like vim, a single y or d acts on the range straight away
+	case key.Matches(msg, m.keys.Yank):
+		m.visual = false
+		m.yank()
+		return true
+
+	case key.Matches(msg, m.keys.Cut):
+		m.visual = false
+		m.cut()
+		return true
+	}
+
+	m.visual = false
+	return false
+}
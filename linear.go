@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+	"gotask/internal/httpjson"
+	"gotask/internal/storage"
+)
+
+// linearAPI is Linear's GraphQL endpoint
+const linearAPI = "https://api.linear.app/graphql"
+
+// linearIssue holds the fields of a Linear issue that make up a task
+type linearIssue struct {
+	Identifier  string   `json:"identifier"` // e.g. "ENG-123"
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	URL         string   `json:"url"`
+	Priority    int      `json:"priority"` // 0 none, 1 urgent, 2 high, 3 medium, 4 low
+	Estimate    *float64 `json:"estimate"`
+	DueDate     string   `json:"dueDate"`
+	State       struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"state"`
+	Labels struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+}
+
+const linearIssuesQuery = `query($filter: IssueFilter, $after: String) {
+  issues(filter: $filter, first: 100, after: $after) {
+    nodes {
+      identifier title description url priority estimate dueDate
+      state { name type }
+      labels { nodes { name } }
+    }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+// importLinear implements "gotask import linear", which adds the issues of
+// a Linear team to the board, each in the column matching its workflow
+// state. Issues imported before are skipped.
+func importLinear(args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	flags := flag.NewFlagSet("import linear", flag.ContinueOnError)
+	team := flags.String("team", cfg.Linear.Team, "team key, e.g. ENG (default all teams)")
+	completed := flags.Bool("completed", false, "import completed issues as well")
+	path := flags.String("file", config.BoardPath(), "board file")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	token := firstNonEmpty(os.Getenv("LINEAR_API_KEY"), cfg.Linear.Token)
+	if token == "" {
+		return errors.New("set LINEAR_API_KEY or linear.token in the config")
+	}
+
+	skip := []string{"canceled"}
+	if !*completed {
+		skip = append(skip, "completed")
+	}
+	filter := map[string]any{"state": map[string]any{"type": map[string]any{"nin": skip}}}
+	if *team != "" {
+		filter["team"] = map[string]any{"key": map[string]any{"eq": *team}}
+	}
+	issues, err := linearIssues(linearAPI, token, filter)
+	if err != nil {
+		return err
+	}
+
+	added := 0
+	err = storage.NewBoardFile(*path).Change(func(b *KanbanBoard) error {
+		tasks := make([]Task, len(issues))
+		columns := make([]int, len(issues))
+		for i, issue := range issues {
+			tasks[i] = issue.task()
+			columns[i] = linearColumn(b, issue, cfg.Linear.States)
+		}
+		added = importTasks(b, tasks, columns)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Imported %d of %d issues from Linear\n", added, len(issues))
+	return nil
+}
+
+// linearIssues fetches every issue matching filter, a page at a time
+func linearIssues(endpoint, token string, filter map[string]any) ([]linearIssue, error) {
+	var all []linearIssue
+	var after *string
+	for {
+		body, err := json.Marshal(map[string]any{
+			"query":     linearIssuesQuery,
+			"variables": map[string]any{"filter": filter, "after": after},
+		})
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", token)
+		req.Header.Set("Content-Type", "application/json")
+
+		var resp struct {
+			Data struct {
+				Issues struct {
+					Nodes    []linearIssue `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"issues"`
+			} `json:"data"`
+			Errors []struct {
+				Message string `json:"message"`
+			} `json:"errors"`
+		}
+		if _, err := httpjson.Fetch(req, &resp); err != nil {
+			return nil, err
+		}
+		if len(resp.Errors) > 0 {
+			return nil, fmt.Errorf("linear: %s", resp.Errors[0].Message)
+		}
+		all = append(all, resp.Data.Issues.Nodes...)
+		page := resp.Data.Issues.PageInfo
+		if !page.HasNextPage {
+			return all, nil
+		}
+		after = &page.EndCursor
+	}
+}
+
+// task turns an issue into a task. Labels become tags and the estimate
+// becomes the task's points.
+func (issue linearIssue) task() Task {
+	task := Task{
+		Title:       issue.Title,
+		Description: issue.Description,
+		Source:      &TaskSource{Kind: "linear", ID: issue.Identifier, URL: issue.URL},
+	}
+	switch issue.Priority {
+	case 1, 2:
+		task.Priority = PriorityHigh
+	case 3:
+		task.Priority = PriorityMedium
+	case 4:
+		task.Priority = PriorityLow
+	}
+	for _, label := range issue.Labels.Nodes {
+		task.AddTag(label.Name)
+	}
+	if issue.Estimate != nil {
+		task.Points = *issue.Estimate
+	}
+	if due, err := time.ParseInLocation(board.DueLayout, issue.DueDate, time.Local); err == nil {
+		task.Due = &due
+	}
+	return task
+}
+
+// linearColumn picks the column for an issue: the one its state is mapped
+// to, or else one chosen by the state's type
+func linearColumn(b *KanbanBoard, issue linearIssue, states map[string]string) int {
+	for name, column := range states {
+		if strings.EqualFold(name, issue.State.Name) {
+			if col, ok := b.ColumnIndex(column); ok {
+				return col
+			}
+		}
+	}
+	switch issue.State.Type {
+	case "started":
+		return min(1, len(b.Columns)-1)
+	case "completed":
+		return len(b.Columns) - 1
+	}
+	return 0
+}
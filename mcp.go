@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+
+	"gotask/internal/config"
+	"gotask/internal/storage"
+)
+
+// mcpProtocolVersions are the Model Context Protocol revisions the server
+// speaks, newest first. 2025-03-26 is left out since it has clients send
+// batches, which the server doesn't read.
+var mcpProtocolVersions = []string{"2025-06-18", "2024-11-05"}
+
+// JSON-RPC error codes
+const (
+	rpcParseError     = -32700
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// runMCP implements "gotask mcp", a Model Context Protocol server on stdin
+// and stdout that lets AI assistants list, search, add, and move tasks
+func runMCP(args []string) error {
+	flags := flag.NewFlagSet("mcp", flag.ContinueOnError)
+	path := flags.String("file", config.BoardPath(), "board file to use")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	return newMCPServer(*path).serve(os.Stdin, os.Stdout)
+}
+
+// mcpServer answers MCP requests about the board
+type mcpServer struct {
+	*storage.BoardFile
+}
+
+func newMCPServer(path string) *mcpServer {
+	return &mcpServer{storage.NewBoardFile(path)}
+}
+
+// rpcRequest is a JSON-RPC 2.0 request, or a notification if ID is nil
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+// serve reads one JSON-RPC message per line until the input ends
+func (s *mcpServer) serve(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxRequestSize)
+	enc := json.NewEncoder(w)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var req rpcRequest
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			resp := rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"),
+				Error: &rpcError{rpcParseError, err.Error()}}
+			if err := enc.Encode(resp); err != nil {
+				return err
+			}
+			continue
+		}
+
+		result, err := s.handle(req)
+		if req.ID == nil {
+			continue // notifications get no response
+		}
+		resp := rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: result}
+		if err != nil {
+			var rerr *rpcError
+			if !errors.As(err, &rerr) {
+				rerr = &rpcError{rpcInvalidParams, err.Error()}
+			}
+			resp.Result, resp.Error = nil, rerr
+		}
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// handle answers a single request
+func (s *mcpServer) handle(req rpcRequest) (any, error) {
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		json.Unmarshal(req.Params, &params)
+		// A revision the server doesn't speak gets the newest it does,
+		// which the client may take or hang up on
+		protocolVersion := mcpProtocolVersions[0]
+		if slices.Contains(mcpProtocolVersions, params.ProtocolVersion) {
+			protocolVersion = params.ProtocolVersion
+		}
+		return map[string]any{
+			"protocolVersion": protocolVersion,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]string{"name": "gotask", "version": version},
+		}, nil
+
+	case "ping", "notifications/initialized", "notifications/cancelled":
+		return map[string]any{}, nil
+
+	case "tools/list":
+		return map[string]any{"tools": mcpTools}, nil
+
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.callTool(params.Name, params.Arguments)
+	}
+	return nil, &rpcError{rpcMethodNotFound, fmt.Sprintf("unknown method %q", req.Method)}
+}
+
+// mcpTool describes a tool to the client
+type mcpTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+}
+
+// schema builds a JSON schema for an object with the given properties
+func schema(required []string, properties map[string]any) map[string]any {
+	s := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func stringProp(desc string) map[string]any {
+	return map[string]any{"type": "string", "description": desc}
+}
+
+func intProp(desc string) map[string]any {
+	return map[string]any{"type": "integer", "description": desc}
+}
+
+var columnProp = stringProp("Column title (may be abbreviated) or 1-based number")
+
+var mcpTools = []mcpTool{
+	{
+		Name:        "list_tasks",
+		Description: "List the tasks on the kanban board, optionally only those in one column",
+		InputSchema: schema(nil, map[string]any{"column": columnProp}),
+	},
+	{
+		Name:        "search_tasks",
+		Description: "Fuzzy search task titles and descriptions",
+		InputSchema: schema([]string{"query"}, map[string]any{
+			"query":  stringProp("Text to search for"),
+			"column": columnProp,
+		}),
+	},
+	{
+		Name:        "add_task",
+		Description: "Add a task to the board, in the first column unless another is given",
+		InputSchema: schema([]string{"title"}, map[string]any{
+			"title":       stringProp("Task title"),
+			"description": stringProp("Longer description"),
+			"column":      columnProp,
+			"priority":    map[string]any{"type": "string", "enum": []string{"none", "low", "medium", "high"}},
+			"tags":        map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"due":         stringProp("Due date: YYYY-MM-DD, today, tomorrow, or an offset such as +3d"),
+		}),
+	},
+	{
+		Name:        "move_task",
+		Description: "Move a task to another column or position",
+		InputSchema: schema([]string{"id", "column"}, map[string]any{
+			"id":       intProp("Task ID"),
+			"column":   columnProp,
+			"position": intProp("0-based position in the column; the end if left out"),
+		}),
+	},
+}
+
+// callTool runs a tool and reports its result as JSON text. Failures of
+// the tool itself are results with isError set, as MCP asks.
+func (s *mcpServer) callTool(name string, args json.RawMessage) (any, error) {
+	if len(args) == 0 {
+		args = json.RawMessage("{}")
+	}
+	var out any
+	var err error
+	switch name {
+	case "list_tasks", "search_tasks":
+		var in struct {
+			Column string `json:"column"`
+			Query  string `json:"query"`
+		}
+		if err := json.Unmarshal(args, &in); err != nil {
+			return nil, err
+		}
+		var board KanbanBoard
+		if board, err = s.Read(); err == nil {
+			out, err = apiTasks(&board, in.Column, in.Query)
+		}
+
+	case "add_task":
+		var in taskInput
+		if err := json.Unmarshal(args, &in); err != nil {
+			return nil, err
+		}
+		err = s.Change(func(b *KanbanBoard) (err error) {
+			out, err = in.create(b)
+			return err
+		})
+
+	case "move_task":
+		var in struct {
+			ID int `json:"id"`
+			moveInput
+		}
+		if err := json.Unmarshal(args, &in); err != nil {
+			return nil, err
+		}
+		err = s.Change(func(b *KanbanBoard) (err error) {
+			out, err = in.move(b, strconv.Itoa(in.ID))
+			return err
+		})
+
+	default:
+		return nil, &rpcError{rpcInvalidParams, fmt.Sprintf("unknown tool %q", name)}
+	}
+
+	if err != nil {
+		return toolResult(err.Error(), true), nil
+	}
+	text, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return toolResult(string(text), false), nil
+}
+
+func toolResult(text string, isError bool) map[string]any {
+	return map[string]any{
+		"content": []map[string]string{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
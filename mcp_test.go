@@ -0,0 +1,29 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestMCPInitializeVersion(t *testing.T) {
+	s := newMCPServer(filepath.Join(t.TempDir(), "board.json"))
+	tests := []struct {
+		asked, want string
+	}{
+		{"2024-11-05", "2024-11-05"},
+		{"2025-06-18", "2025-06-18"},
+		{"2099-01-01", mcpProtocolVersions[0]},
+		{"", mcpProtocolVersions[0]},
+	}
+	for _, tt := range tests {
+		params, _ := json.Marshal(map[string]string{"protocolVersion": tt.asked})
+		result, err := s.handle(rpcRequest{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize", Params: params})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := result.(map[string]any)["protocolVersion"]; got != tt.want {
+			t.Errorf("asked for %q, got %q, want %q", tt.asked, got, tt.want)
+		}
+	}
+}
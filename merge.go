@@ -0,0 +1,35 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"gotask/internal/config"
+	"gotask/internal/storage"
+)
+
+// runMerge implements "gotask merge", which merges other copies of the
+// board into it: the files given, or the conflicted copies found beside it
+func runMerge(args []string) error {
+	flags := flag.NewFlagSet("merge", flag.ContinueOnError)
+	path := flags.String("file", config.BoardPath(), "board file to merge into")
+	keep := flags.Bool("keep", false, "keep the copies after merging them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	copies := flags.Args()
+	if len(copies) == 0 {
+		copies = storage.ConflictCopies(*path)
+		if len(copies) == 0 {
+			fmt.Println("No conflicted copies found")
+			return nil
+		}
+	}
+	stats, err := storage.MergeFiles(*path, copies, *keep)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Merged %s: %s\n", strings.Join(copies, ", "), stats)
+	return nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"gotask/internal/stats"
+)
+
+// metric is a Prometheus metric family and its samples
+type metric struct {
+	name, help, kind string
+	samples          []sample
+}
+
+type sample struct {
+	labels string // e.g. `column="To Do"`, or "" for none
+	value  float64
+}
+
+// boardMetrics measures the board for Prometheus
+func boardMetrics(b *KanbanBoard, now time.Time) []metric {
+	s := stats.Summarize(b, now)
+	tasks := metric{name: "gotask_tasks", help: "Tasks on the board by column.", kind: "gauge"}
+	for _, col := range s.Columns {
+		tasks.samples = append(tasks.samples, sample{fmt.Sprintf("column=%q", escapeLabel(col.Title)), float64(col.Count)})
+	}
+
+	// Finished tasks stay counted once archived, so this only drops when
+	// tasks are deleted or reopened
+	completed := 0
+	for _, col := range b.Columns {
+		for _, task := range col.Tasks {
+			if task.CompletedAt != nil {
+				completed++
+			}
+		}
+	}
+	for _, task := range b.Archive {
+		if task.CompletedAt != nil {
+			completed++
+		}
+	}
+
+	gauge := func(name, help string, value int) metric {
+		return metric{name: name, help: help, kind: "gauge", samples: []sample{{"", float64(value)}}}
+	}
+	return []metric{
+		tasks,
+		gauge("gotask_tasks_open", "Tasks not in the last column.", s.Open),
+		gauge("gotask_tasks_overdue", "Open tasks due before today.", s.Overdue),
+		gauge("gotask_tasks_due_today", "Open tasks due today.", s.DueToday),
+		gauge("gotask_tasks_due_soon", "Open tasks due within a week, after today.", s.DueSoon),
+		gauge("gotask_tasks_archived", "Tasks in the archive.", len(b.Archive)),
+		{name: "gotask_tasks_completed_total", help: "Tasks completed, including archived ones.", kind: "counter",
+			samples: []sample{{"", float64(completed)}}},
+	}
+}
+
+// escapeLabel escapes a label value for the text exposition format. %q
+// does the quoting; this leaves only characters it would escape differently.
+func escapeLabel(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < ' ' {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// writeMetrics writes metrics in the Prometheus text exposition format
+func writeMetrics(w io.Writer, metrics []metric) error {
+	for _, m := range metrics {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.kind)
+		for _, s := range m.samples {
+			name := m.name
+			if s.labels != "" {
+				name += "{" + s.labels + "}"
+			}
+			if _, err := fmt.Fprintf(w, "%s %g\n", name, s.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// metrics serves the board's metrics for Prometheus to scrape
+func (s *boardServer) metrics(w http.ResponseWriter, r *http.Request) {
+	board, err := s.Read()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	writeMetrics(w, boardMetrics(&board, time.Now()))
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+	"gotask/internal/httpjson"
+	"gotask/internal/storage"
+)
+
+// notionAPI is the root of Notion's REST API, and notionVersion the
+// revision of it that is spoken
+const (
+	notionAPI     = "https://api.notion.com/v1"
+	notionVersion = "2022-06-28"
+)
+
+// notionPage is a row of a database
+type notionPage struct {
+	ID         string                    `json:"id"`
+	URL        string                    `json:"url"`
+	Properties map[string]notionProperty `json:"properties"`
+}
+
+// notionProperty holds a property value; which field is set depends on
+// its type
+type notionProperty struct {
+	Type        string         `json:"type"`
+	Title       []notionText   `json:"title"`
+	RichText    []notionText   `json:"rich_text"`
+	Status      *notionOption  `json:"status"`
+	Select      *notionOption  `json:"select"`
+	MultiSelect []notionOption `json:"multi_select"`
+	Date        *struct {
+		Start string `json:"start"`
+	} `json:"date"`
+}
+
+type notionText struct {
+	PlainText string `json:"plain_text"`
+}
+
+type notionOption struct {
+	Name string `json:"name"`
+}
+
+// plainText joins the pieces of a rich text value
+func plainText(texts []notionText) string {
+	var b strings.Builder
+	for _, t := range texts {
+		b.WriteString(t.PlainText)
+	}
+	return b.String()
+}
+
+// importNotion implements "gotask import notion", which adds the pages of a
+// Notion database to the board. The status property picks each page's
+// column, select and multi-select properties become tags, and the first
+// date property becomes the due date.
+func importNotion(args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	flags := flag.NewFlagSet("import notion", flag.ContinueOnError)
+	database := flags.String("database", cfg.Notion.Database, "database ID")
+	status := flags.String("status", firstNonEmpty(cfg.Notion.Status, "Status"), "property that picks the column")
+	path := flags.String("file", config.BoardPath(), "board file")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	token := firstNonEmpty(os.Getenv("NOTION_TOKEN"), cfg.Notion.Token)
+	if token == "" {
+		return errors.New("set NOTION_TOKEN or notion.token in the config")
+	}
+	if *database == "" {
+		return errors.New("set -database or notion.database in the config")
+	}
+
+	pages, err := notionPages(notionAPI, token, *database)
+	if err != nil {
+		return err
+	}
+
+	added := 0
+	err = storage.NewBoardFile(*path).Change(func(b *KanbanBoard) error {
+		tasks := make([]Task, len(pages))
+		columns := make([]int, len(pages))
+		for i, page := range pages {
+			tasks[i] = page.task(*status)
+			columns[i] = notionColumn(b, page.statusValue(*status), cfg.Notion.Columns)
+		}
+		added = importTasks(b, tasks, columns)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Imported %d of %d pages from Notion\n", added, len(pages))
+	return nil
+}
+
+// notionPages fetches every page of a database, a page of results at a time
+func notionPages(api, token, database string) ([]notionPage, error) {
+	var all []notionPage
+	query := map[string]any{"page_size": 100}
+	for {
+		body, err := json.Marshal(query)
+		if err != nil {
+			return nil, err
+		}
+		url := fmt.Sprintf("%s/databases/%s/query", api, database)
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Notion-Version", notionVersion)
+		req.Header.Set("Content-Type", "application/json")
+
+		var resp struct {
+			Results    []notionPage `json:"results"`
+			HasMore    bool         `json:"has_more"`
+			NextCursor string       `json:"next_cursor"`
+		}
+		if _, err := httpjson.Fetch(req, &resp); err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Results...)
+		if !resp.HasMore {
+			return all, nil
+		}
+		query["start_cursor"] = resp.NextCursor
+	}
+}
+
+// statusValue is the value of the page's status property, which may be a
+// status or a select
+func (p notionPage) statusValue(name string) string {
+	prop, ok := p.Properties[name]
+	if !ok {
+		return ""
+	}
+	switch {
+	case prop.Status != nil:
+		return prop.Status.Name
+	case prop.Select != nil:
+		return prop.Select.Name
+	}
+	return ""
+}
+
+// task turns a page into a task
+func (p notionPage) task(status string) Task {
+	task := Task{
+		Source: &TaskSource{Kind: "notion", ID: p.ID, URL: p.URL},
+	}
+	// Go through the properties in order so tags come out the same each time
+	names := make([]string, 0, len(p.Properties))
+	for name := range p.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop := p.Properties[name]
+		switch prop.Type {
+		case "title":
+			task.Title = plainText(prop.Title)
+		case "rich_text":
+			if task.Description == "" {
+				task.Description = plainText(prop.RichText)
+			}
+		case "select":
+			if name != status && prop.Select != nil {
+				task.AddTag(prop.Select.Name)
+			}
+		case "multi_select":
+			for _, option := range prop.MultiSelect {
+				task.AddTag(option.Name)
+			}
+		case "date":
+			if task.Due == nil && prop.Date != nil {
+				task.Due = parseNotionDate(prop.Date.Start)
+			}
+		}
+	}
+	if task.Title == "" {
+		task.Title = "Untitled"
+	}
+	return task
+}
+
+// parseNotionDate reads a date, which may carry a time, or returns nil
+func parseNotionDate(s string) *time.Time {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return &t
+	}
+	if t, err := time.ParseInLocation(board.DueLayout, s, time.Local); err == nil {
+		return &t
+	}
+	return nil
+}
+
+// notionColumn picks the column for a status: the one it is mapped to, or
+// the one with a matching title, or else the first
+func notionColumn(b *KanbanBoard, status string, columns map[string]string) int {
+	if status == "" {
+		return 0
+	}
+	for from, to := range columns {
+		if strings.EqualFold(from, status) {
+			status = to
+			break
+		}
+	}
+	if col, ok := b.ColumnIndex(status); ok {
+		return col
+	}
+	return 0
+}
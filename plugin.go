@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"gotask/internal/plugin"
+)
+
+// runPlugin runs a plugin as a subcommand. A plugin that fails has already
+// said why, so gotask exits with its status rather than adding to it.
+func runPlugin(path string, args []string) error {
+	cmd := exec.Command(path, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = plugin.Env()
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		return exitStatus(exitErr.ExitCode())
+	}
+	return err
+}
+
+// pluginUsage lists the plugins for the usage message
+func pluginUsage() string {
+	found := plugin.Installed()
+	if len(found) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("\nPlugins:\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "  %-10s %s\n", name, found[name])
+	}
+	return b.String()
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"gotask/internal/plugin"
+)
+
+func TestFailingPluginSetsExitStatus(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the plugin is a shell script")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, plugin.Prefix+"fail"), []byte("#!/bin/sh\nexit 3\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	if got := run([]string{"fail"}); got != 3 {
+		t.Errorf("run returned %d, want the plugin's status 3", got)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"gotask/internal/config"
+	"gotask/internal/stats"
+	"gotask/internal/storage"
+)
+
+// ANSI colors for prompt segments. Raw codes are used rather than styles
+// so the prompt doesn't have to query the terminal.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiCyan   = "\x1b[36m"
+)
+
+// promptSegment renders the summary for a shell prompt, e.g.
+// "▲3 ●2 today ◆1 overdue". Empty parts are left out, so a clear board
+// prints nothing at all.
+func promptSegment(s stats.Status, color bool) string {
+	var parts []string
+	add := func(code, text string, n int) {
+		if n == 0 {
+			return
+		}
+		part := fmt.Sprintf(text, n)
+		if color {
+			part = code + part + ansiReset
+		}
+		parts = append(parts, part)
+	}
+	add(ansiCyan, "▲%d", s.InProgressCount)
+	add(ansiYellow, "●%d today", s.DueToday)
+	add(ansiRed, "◆%d overdue", s.Overdue)
+	return strings.Join(parts, " ")
+}
+
+// runPrompt implements "gotask prompt", which prints a short summary for
+// starship, powerlevel10k, or a hand-written PS1: tasks in progress,
+// tasks due today, and overdue tasks
+func runPrompt(args []string) error {
+	flags := flag.NewFlagSet("prompt", flag.ContinueOnError)
+	color := flags.Bool("color", false, "color the output with ANSI escapes")
+	path := flags.String("file", config.BoardPath(), "board file to read")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	board, err := storage.Load(*path)
+	if err != nil {
+		return err
+	}
+	if segment := promptSegment(stats.Summarize(&board, time.Now()), *color); segment != "" {
+		fmt.Println(segment)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+	"gotask/internal/ui"
+)
+
+// parseRenderTime reads the -now flag, a date or an RFC 3339 time
+func parseRenderTime(value string) (time.Time, error) {
+	if t, err := time.ParseInLocation(board.DueLayout, value, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("-now: want YYYY-MM-DD or an RFC 3339 time, got %q", value)
+	}
+	return t, nil
+}
+
+// runRender implements "gotask render", which prints a single frame of
+// the board as it would open. With the size, colors, and time fixed the
+// output is the same on every run, for golden files and screenshots in
+// scripts. The board file and its log are only read.
+func runRender(args []string) error {
+	return render(os.Stdout, args)
+}
+
+// render draws the frame "gotask render" prints to w
+func render(w io.Writer, args []string) error {
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	path := flags.String("file", config.BoardPath(), "board file to render")
+	width := flags.Int("width", 120, "width in columns")
+	height := flags.Int("height", 40, "height in lines")
+	noColor := flags.Bool("no-color", false, "render without color, as GOTASK_NO_COLOR does")
+	at := flags.String("now", "", "draw the board as of this date or RFC 3339 time instead of now")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *width < 20 || *height < 10 {
+		return errors.New("-width must be at least 20 and -height at least 10")
+	}
+	now := time.Now()
+	if *at != "" {
+		var err error
+		if now, err = parseRenderTime(*at); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	cfg.Board = *path
+	cfg.NoColor = cfg.NoColor || *noColor
+	return ui.Render(w, cfg, *width, *height, now)
+}
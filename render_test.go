@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func TestRenderGolden(t *testing.T) {
+	// Nothing from the machine running the test: no config, no overrides,
+	// and dates in UTC
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	for _, name := range []string{"GOTASK_BOARD", "GOTASK_CONFIG", "GOTASK_THEME", "GOTASK_NO_COLOR", "GOTASK_READ_ONLY", "NO_COLOR"} {
+		t.Setenv(name, "")
+	}
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	// A copy, so that nothing is ever left beside the one in testdata
+	data, err := os.ReadFile(filepath.Join("testdata", "board.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(home, "board.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	args := []string{"-file", path, "-now", "2026-01-10", "-width", "100", "-height", "30", "-no-color"}
+	if err := render(&out, args); err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("testdata", "render.golden")
+	if *update {
+		if err := os.WriteFile(golden, out.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), want) {
+		t.Errorf("render differs from %s; run go test -run TestRenderGolden -update if the change is intended\ngot:\n%s", golden, out.Bytes())
+	}
+}
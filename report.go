@@ -0,0 +1,188 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+	"gotask/internal/stats"
+	"gotask/internal/storage"
+)
+
+// groupCompleted groups tasks by column, or by tag. A task with several
+// tags goes under its first, so nothing is counted twice. Groups come in
+// the order their first task was finished.
+func groupCompleted(tasks []stats.Completed, byTag bool) ([]string, map[string][]stats.Completed) {
+	var names []string
+	groups := make(map[string][]stats.Completed)
+	for _, task := range tasks {
+		name := task.Column
+		if byTag {
+			name = stats.Untagged
+			if len(task.Tags) > 0 {
+				name = task.Tags[0]
+			}
+		}
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+		groups[name] = append(groups[name], task)
+	}
+	return names, groups
+}
+
+// writeReport writes the completed tasks as Markdown, ready to paste into
+// a status update or an invoice
+func writeReport(w io.Writer, tasks []stats.Completed, byTag bool, since, now time.Time) {
+	fmt.Fprintf(w, "Completed %s – %s: %d %s\n", since.Format("Jan 2"), now.Format("Jan 2"), len(tasks), board.Plural(len(tasks), "task"))
+	names, groups := groupCompleted(tasks, byTag)
+	for _, name := range names {
+		fmt.Fprintf(w, "\n## %s\n\n", name)
+		for _, task := range groups[name] {
+			var extra []string
+			if task.Points > 0 {
+				extra = append(extra, board.FormatPoints(task.Points))
+			}
+			if task.Pomodoros > 0 {
+				extra = append(extra, fmt.Sprintf("%d %s", task.Pomodoros, board.Plural(task.Pomodoros, "pomodoro")))
+			}
+			extra = append(extra, task.CompletedAt.Format("Jan 2"))
+			fmt.Fprintf(w, "- #%d %s (%s)\n", task.ID, task.Title, strings.Join(extra, ", "))
+		}
+	}
+}
+
+// runReport implements "gotask report", which lists the tasks completed
+// lately, grouped by tag or by column, and "gotask report time", which
+// totals the time tracked
+func runReport(args []string) error {
+	if len(args) > 0 && args[0] == "time" {
+		return runTimeReport(args[1:])
+	}
+	flags := flag.NewFlagSet("report", flag.ContinueOnError)
+	sinceFlag := flags.String("since", "7d", "how far back to go, e.g. 7d or 2w")
+	by := flags.String("by", "tag", "group by tag or column")
+	path := flags.String("file", config.BoardPath(), "board file to read")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	days, err := stats.ParseDays(*sinceFlag)
+	if err != nil {
+		return fmt.Errorf("-since: %w", err)
+	}
+	if *by != "tag" && *by != "column" {
+		return fmt.Errorf("-by: use tag or column, not %q", *by)
+	}
+	b, err := storage.Load(*path)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	since := board.DayOf(now).AddDate(0, 0, 1-days)
+	writeReport(os.Stdout, stats.CompletedSince(&b, since), *by == "tag", since, now)
+	return nil
+}
+
+// timeGroup is the time tracked under a tag, a column, or a task
+type timeGroup struct {
+	name  string
+	tasks int
+	spent time.Duration
+}
+
+// groupTracked totals tracked time by tag, column, or task, most time
+// first. As in the completed-work report, a task counts under its first
+// tag.
+func groupTracked(tracked []stats.Tracked, by string) []timeGroup {
+	index := make(map[string]int)
+	seen := make(map[string]bool)
+	var groups []timeGroup
+	for _, t := range tracked {
+		var name string
+		switch by {
+		case "tag":
+			name = stats.Untagged
+			if len(t.Task.Tags) > 0 {
+				name = t.Task.Tags[0]
+			}
+		case "column":
+			name = t.Column
+		default:
+			name = fmt.Sprintf("#%d %s", t.Task.ID, t.Task.Title)
+		}
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, timeGroup{name: name})
+		}
+		if key := fmt.Sprint(name, "\x00", t.Task.ID); !seen[key] {
+			seen[key] = true
+			groups[i].tasks++
+		}
+		groups[i].spent += t.Spent
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].spent > groups[j].spent })
+	return groups
+}
+
+// writeTimeReport writes the tracked hours as CSV or as a table with a
+// total
+func writeTimeReport(w io.Writer, groups []timeGroup, by string, asCSV bool) error {
+	hours := func(d time.Duration) string {
+		return strconv.FormatFloat(d.Hours(), 'f', 2, 64)
+	}
+	if asCSV {
+		out := csv.NewWriter(w)
+		out.Write([]string{by, "tasks", "hours"})
+		for _, g := range groups {
+			out.Write([]string{g.name, strconv.Itoa(g.tasks), hours(g.spent)})
+		}
+		out.Flush()
+		return out.Error()
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s\ttasks\thours\n", by)
+	var total time.Duration
+	for _, g := range groups {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", g.name, g.tasks, hours(g.spent))
+		total += g.spent
+	}
+	fmt.Fprintf(tw, "Total\t\t%s\n", hours(total))
+	return tw.Flush()
+}
+
+// runTimeReport implements "gotask report time", which totals the time
+// tracked with pomodoros and focus sessions, for billing
+func runTimeReport(args []string) error {
+	flags := flag.NewFlagSet("report time", flag.ContinueOnError)
+	sinceFlag := flags.String("since", "30d", "how far back to go, e.g. 30d or 4w")
+	by := flags.String("group-by", "tag", "group by tag, column, or task")
+	asCSV := flags.Bool("csv", false, "print CSV instead of a table")
+	path := flags.String("file", config.BoardPath(), "board file to read")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	days, err := stats.ParseDays(*sinceFlag)
+	if err != nil {
+		return fmt.Errorf("-since: %w", err)
+	}
+	if *by != "tag" && *by != "column" && *by != "task" {
+		return fmt.Errorf("-group-by: use tag, column, or task, not %q", *by)
+	}
+	b, err := storage.Load(*path)
+	if err != nil {
+		return err
+	}
+	since := board.DayOf(time.Now()).AddDate(0, 0, 1-days)
+	return writeTimeReport(os.Stdout, groupTracked(stats.TrackedSince(&b, since), *by), *by, *asCSV)
+}
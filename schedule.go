@@ -0,0 +1,156 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+	"gotask/internal/notify"
+	"gotask/internal/stats"
+	"gotask/internal/storage"
+)
+
+// scheduledReport is a report ready for the daemon to check on
+type scheduledReport struct {
+	kind  string
+	every string
+	at    time.Duration // time of day
+	sinks []notify.Sink
+}
+
+// fileSink writes a report to a file
+type fileSink struct{ path string }
+
+func (s fileSink) Send(a notify.Alert) error {
+	return os.WriteFile(s.path, []byte(a.Title+"\n\n"+a.Body), 0644)
+}
+
+// mailSink emails a report
+type mailSink struct {
+	cfg config.Email
+	to  string
+}
+
+func (s mailSink) Send(a notify.Alert) error {
+	return sendMail(s.cfg, s.to, a.Title, a.Body)
+}
+
+// weekdays names the days a report can be scheduled on
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+// newScheduledReports checks the scheduled reports in the config
+func newScheduledReports(cfg []config.Report, email config.Email) ([]scheduledReport, error) {
+	var reports []scheduledReport
+	for i, rc := range cfg {
+		r := scheduledReport{kind: rc.Report, every: strings.ToLower(cmp.Or(rc.Every, "day"))}
+		name := fmt.Sprintf("reports[%d]", i)
+		switch r.kind {
+		case "digest", "standup", "stats":
+		default:
+			return nil, fmt.Errorf("%s: unknown report %q (want digest, standup, or stats)", name, rc.Report)
+		}
+		if _, ok := weekdays[r.every]; !ok && r.every != "day" && r.every != "weekday" {
+			return nil, fmt.Errorf("%s: can't read every %q, use day, weekday, or a day such as monday", name, rc.Every)
+		}
+		at, err := notify.ParseTimeOfDay(rc.At)
+		if err != nil {
+			return nil, fmt.Errorf("%s: at: %w", name, err)
+		}
+		r.at = at
+		if rc.File != "" {
+			r.sinks = append(r.sinks, fileSink{rc.File})
+		}
+		if rc.Email != "" {
+			r.sinks = append(r.sinks, mailSink{email, rc.Email})
+		}
+		if rc.Webhook != nil {
+			s, err := notify.NewWebhookSink(*rc.Webhook)
+			if err != nil {
+				return nil, fmt.Errorf("%s: webhook: %w", name, err)
+			}
+			r.sinks = append(r.sinks, s)
+		}
+		if len(r.sinks) == 0 {
+			return nil, fmt.Errorf("%s: set file, email, or webhook to deliver it", name)
+		}
+		reports = append(reports, r)
+	}
+	return reports, nil
+}
+
+// due reports whether the report should go out on now's day
+func (r scheduledReport) due(now time.Time) bool {
+	switch day := now.Weekday(); r.every {
+	case "day":
+		return true
+	case "weekday":
+		return day != time.Saturday && day != time.Sunday
+	default:
+		return weekdays[r.every] == day
+	}
+}
+
+// render writes the report about the board as it is now
+func (r scheduledReport) render(b *KanbanBoard, now time.Time) (notify.Alert, error) {
+	var body strings.Builder
+	switch r.kind {
+	case "digest":
+		d := notify.NewDigest(b, now)
+		return notify.Alert{Title: d.Subject(), Body: d.Text(now)}, nil
+	case "standup":
+		since := lastWorkday(now)
+		newStandup(b, since, blockedTag).write(&body, since, now)
+		return notify.Alert{Title: "Standup " + now.Format("Mon Jan 2"), Body: body.String()}, nil
+	case "stats":
+		if err := writeStats(&body, b, 7, now); err != nil {
+			return notify.Alert{}, err
+		}
+		return notify.Alert{Title: "Week of " + stats.StartOfWeek(now).Format("Jan 2") + " in numbers", Body: body.String()}, nil
+	}
+	return notify.Alert{}, fmt.Errorf("unknown report %q", r.kind)
+}
+
+// checkReports delivers the scheduled reports whose time has come today,
+// for the daemon, logging rather than stopping on errors
+func checkReports(path string, reports []scheduledReport, now time.Time, sent map[string]bool) {
+	today := board.DayOf(now)
+	var loaded *KanbanBoard
+	for i, r := range reports {
+		key := fmt.Sprintf("report %d %s %s", i, r.kind, today.Format(board.DueLayout))
+		if !r.due(now) || now.Before(today.Add(r.at)) || sent[key] {
+			continue
+		}
+		sent[key] = true
+		if loaded == nil {
+			b, err := storage.Load(path)
+			if err != nil {
+				log.Print(err)
+				return
+			}
+			loaded = &b
+		}
+		a, err := r.render(loaded, now)
+		if err != nil {
+			log.Print(err)
+			continue
+		}
+		var errs []error
+		for _, s := range r.sinks {
+			errs = append(errs, s.Send(a))
+		}
+		if err := errors.Join(errs...); err != nil {
+			log.Printf("%s report: %v", r.kind, err)
+			continue
+		}
+		log.Printf("sent %s report", r.kind)
+	}
+}
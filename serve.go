@@ -0,0 +1,404 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+	"gotask/internal/storage"
+)
+
+// maxRequestSize limits the body of API requests
+const maxRequestSize = 1 << 20
+
+// runServe implements "gotask serve", which exposes the board over HTTP:
+//
+//	GET   /board             the whole board, with its version as ETag
+//	PUT   /board             replace the whole board; If-Match must name
+//	                         the version being replaced
+//	GET   /events            the board's version whenever it changes, as
+//	                         server-sent events
+//	GET   /tasks             every task, or one column's with ?column=,
+//	                         or those matching a search with ?q=
+//	POST  /tasks             add a task
+//	GET   /tasks/{id}        one task
+//	PATCH /tasks/{id}        change some of a task's fields
+//	POST  /tasks/{id}/move   move a task to another column
+//	GET   /metrics           task counts for Prometheus
+//
+// Request bodies must be application/json, and requests that web pages on
+// other sites send are turned away. With -grpc it also serves the Board
+// service of gotaskpb/gotask.proto. When GOTASK_TOKEN is set, every
+// request must carry it as a bearer token; without it, only addresses on
+// this machine may be served, under the name localhost or its IP.
+func runServe(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	grpcAddr := flags.String("grpc", "", "also serve the gRPC API on this address, e.g. localhost:9090")
+	path := flags.String("file", config.BoardPath(), "board file to serve")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	token := serveToken()
+	if err := checkExposure(*addr, token); err != nil {
+		return err
+	}
+	if *grpcAddr != "" {
+		if err := checkExposure(*grpcAddr, token); err != nil {
+			return err
+		}
+	}
+
+	errs := make(chan error, 2)
+	if *grpcAddr != "" {
+		fmt.Fprintf(os.Stderr, "Serving %s over gRPC on %s\n", *path, *grpcAddr)
+		go func() { errs <- serveGRPC(*grpcAddr, *path, token) }()
+	}
+	srv := newBoardServer(*path, token)
+	fmt.Fprintf(os.Stderr, "Serving %s on http://%s\n", *path, *addr)
+	go func() { errs <- http.ListenAndServe(*addr, srv.handler()) }()
+	return <-errs
+}
+
+// boardServer answers API requests
+type boardServer struct {
+	*storage.BoardFile
+	token string // required of clients, if set
+}
+
+func newBoardServer(path, token string) *boardServer {
+	return &boardServer{storage.NewBoardFile(path), token}
+}
+
+// handler routes API requests
+func (s *boardServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /board", s.getBoard)
+	mux.HandleFunc("PUT /board", s.putBoard)
+	mux.HandleFunc("GET /events", s.events)
+	mux.HandleFunc("GET /tasks", s.listTasks)
+	mux.HandleFunc("POST /tasks", s.createTask)
+	mux.HandleFunc("GET /tasks/{id}", s.getTask)
+	mux.HandleFunc("PATCH /tasks/{id}", s.patchTask)
+	mux.HandleFunc("POST /tasks/{id}/move", s.moveTask)
+	mux.HandleFunc("GET /metrics", s.metrics)
+	return requireLocal(s.token, requireToken(s.token, mux))
+}
+
+// taskInput holds the fields a client may set on a task. Fields left out
+// of a PATCH are not changed.
+type taskInput struct {
+	Title       *string   `json:"title"`
+	Description *string   `json:"description"`
+	Icon        *string   `json:"icon"`
+	Priority    *Priority `json:"priority"`
+	Tags        *[]string `json:"tags"`
+	Due         *string   `json:"due"`    // anything board.ParseDue reads; "" clears it
+	Column      string    `json:"column"` // for new tasks, defaults to the first column
+}
+
+// moveInput is the body of a move request
+type moveInput struct {
+	Column   string `json:"column"`
+	Position *int   `json:"position"` // index in the column, defaults to the end
+}
+
+// httpError is an error with the status code it should be reported with
+type httpError struct {
+	status int
+	err    error
+}
+
+func (e *httpError) Error() string { return e.err.Error() }
+
+func badRequest(format string, args ...any) error {
+	return &httpError{http.StatusBadRequest, fmt.Errorf(format, args...)}
+}
+
+func notFound(format string, args ...any) error {
+	return &httpError{http.StatusNotFound, fmt.Errorf(format, args...)}
+}
+
+func (s *boardServer) getBoard(w http.ResponseWriter, r *http.Request) {
+	board, err := s.Read()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	data, err := storage.Encode(board)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("ETag", `"`+boardVersion(data)+`"`)
+	writeJSON(w, http.StatusOK, board)
+}
+
+func (s *boardServer) listTasks(w http.ResponseWriter, r *http.Request) {
+	board, err := s.Read()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	tasks, err := apiTasks(&board, r.URL.Query().Get("column"), r.URL.Query().Get("q"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, tasks)
+}
+
+func (s *boardServer) getTask(w http.ResponseWriter, r *http.Request) {
+	board, err := s.Read()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	task, err := lookupTask(&board, r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, task)
+}
+
+func (s *boardServer) createTask(w http.ResponseWriter, r *http.Request) {
+	var in taskInput
+	if err := readJSON(w, r, &in); err != nil {
+		writeError(w, err)
+		return
+	}
+	var created board.ColumnTask
+	err := s.Change(func(b *KanbanBoard) (err error) {
+		created, err = in.create(b)
+		return err
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (s *boardServer) patchTask(w http.ResponseWriter, r *http.Request) {
+	var in taskInput
+	if err := readJSON(w, r, &in); err != nil {
+		writeError(w, err)
+		return
+	}
+	if in.Column != "" {
+		writeError(w, badRequest("use POST /tasks/{id}/move to change a task's column"))
+		return
+	}
+
+	var updated board.ColumnTask
+	err := s.Change(func(b *KanbanBoard) (err error) {
+		updated, err = in.update(b, r.PathValue("id"))
+		return err
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (s *boardServer) moveTask(w http.ResponseWriter, r *http.Request) {
+	var in moveInput
+	if err := readJSON(w, r, &in); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var moved board.ColumnTask
+	err := s.Change(func(b *KanbanBoard) (err error) {
+		moved, err = in.move(b, r.PathValue("id"))
+		return err
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, moved)
+}
+
+// apiTasks lists the tasks in the named column, or in every column if it
+// is "", keeping those that match query if it isn't ""
+func apiTasks(b *KanbanBoard, column, query string) ([]board.ColumnTask, error) {
+	only := -1
+	if column != "" {
+		var ok bool
+		if only, ok = b.ColumnIndex(column); !ok {
+			return nil, notFound("no column matches %q", column)
+		}
+	}
+
+	tasks := []board.ColumnTask{}
+	for i, col := range b.Columns {
+		if only >= 0 && i != only {
+			continue
+		}
+		for _, task := range col.Tasks {
+			if query == "" || board.MatchTask(query, task) {
+				tasks = append(tasks, board.ColumnTask{Task: task, Column: col.Title})
+			}
+		}
+	}
+	return tasks, nil
+}
+
+// create adds a new task made from the input to the board
+func (in taskInput) create(b *KanbanBoard) (board.ColumnTask, error) {
+	if in.Title == nil {
+		return board.ColumnTask{}, badRequest("title is required")
+	}
+	column := 0
+	if in.Column != "" {
+		var ok bool
+		if column, ok = b.ColumnIndex(in.Column); !ok {
+			return board.ColumnTask{}, badRequest("no column matches %q", in.Column)
+		}
+	}
+	task := Task{ID: b.MaxID() + 1}
+	if err := in.apply(&task); err != nil {
+		return board.ColumnTask{}, err
+	}
+	return board.ColumnTask{Task: b.AddTask(column, task), Column: b.Columns[column].Title}, nil
+}
+
+// update applies the input to the task with the given ID
+func (in taskInput) update(b *KanbanBoard, id string) (board.ColumnTask, error) {
+	found, err := lookupTask(b, id)
+	if err != nil {
+		return board.ColumnTask{}, err
+	}
+	col, idx, _ := b.FindTask(found.ID)
+	task := &b.Columns[col].Tasks[idx]
+	next := *task
+	if err := in.apply(&next); err != nil {
+		return board.ColumnTask{}, err
+	}
+	task.Update(next)
+	return board.ColumnTask{Task: *task, Column: b.Columns[col].Title}, nil
+}
+
+// move moves the task with the given ID where the input says
+func (in moveInput) move(b *KanbanBoard, id string) (board.ColumnTask, error) {
+	found, err := lookupTask(b, id)
+	if err != nil {
+		return board.ColumnTask{}, err
+	}
+	col, idx, _ := b.FindTask(found.ID)
+	dest := col
+	if in.Column != "" {
+		var ok bool
+		if dest, ok = b.ColumnIndex(in.Column); !ok {
+			return board.ColumnTask{}, badRequest("no column matches %q", in.Column)
+		}
+	}
+	pos := -1
+	if in.Position != nil {
+		pos = *in.Position
+	}
+	pos = b.MoveTask(col, idx, dest, pos)
+	return board.ColumnTask{Task: b.Columns[dest].Tasks[pos], Column: b.Columns[dest].Title}, nil
+}
+
+// apply copies the fields that were given onto task
+func (in taskInput) apply(task *Task) error {
+	if in.Title != nil {
+		title := strings.TrimSpace(*in.Title)
+		if title == "" {
+			return badRequest("title can't be empty")
+		}
+		task.Title = title
+	}
+	if in.Description != nil {
+		task.Description = *in.Description
+	}
+	if in.Icon != nil {
+		task.Icon = strings.TrimSpace(*in.Icon)
+	}
+	if in.Priority != nil {
+		task.Priority = *in.Priority
+	}
+	if in.Tags != nil {
+		task.Tags = nil
+		for _, tag := range *in.Tags {
+			task.AddTag(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		}
+	}
+	if in.Due != nil {
+		due, err := board.ParseDue(*in.Due, time.Now())
+		if err != nil {
+			return badRequest("%v", err)
+		}
+		task.Due = due
+	}
+	return nil
+}
+
+// lookupTask finds the task with the ID given in a request path
+func lookupTask(b *KanbanBoard, arg string) (board.ColumnTask, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+	if err != nil {
+		return board.ColumnTask{}, badRequest("%q is not a task ID", arg)
+	}
+	col, idx, ok := b.FindTask(id)
+	if !ok {
+		return board.ColumnTask{}, notFound("no task #%d", id)
+	}
+	return board.ColumnTask{Task: b.Columns[col].Tasks[idx], Column: b.Columns[col].Title}, nil
+}
+
+// requireJSON turns away request bodies that aren't JSON. A web page can
+// send a form or text/plain to any address without asking first, but not
+// application/json.
+func requireJSON(r *http.Request) error {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return &httpError{http.StatusUnsupportedMediaType, errors.New("Content-Type must be application/json")}
+	}
+	return nil
+}
+
+// readJSON decodes a request body, rejecting fields the API doesn't know
+func readJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	if err := requireJSON(r); err != nil {
+		return err
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return badRequest("invalid request body: %v", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+// writeError reports an error as {"error": "..."}. Errors without a status
+// of their own are server errors.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var herr *httpError
+	if errors.As(err, &herr) {
+		status = herr.status
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
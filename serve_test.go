@@ -0,0 +1,64 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestServeTurnsAwayOtherSites(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	handler := newBoardServer(filepath.Join(t.TempDir(), "board.json"), "").handler()
+
+	tests := []struct {
+		name        string
+		host        string
+		origin      string
+		contentType string
+		want        int
+	}{
+		{"json from this machine", "localhost:8080", "", "application/json", http.StatusCreated},
+		{"json from a local page", "127.0.0.1:8080", "http://localhost:3000", "application/json; charset=utf-8", http.StatusCreated},
+		{"text from a web page", "localhost:8080", "https://evil.example", "text/plain", http.StatusForbidden},
+		{"text without an origin", "localhost:8080", "", "text/plain", http.StatusUnsupportedMediaType},
+		{"json from a web page", "localhost:8080", "https://evil.example", "application/json", http.StatusForbidden},
+		{"another host", "evil.example:8080", "", "application/json", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"title": "Buy milk"}`))
+			req.Host = tt.host
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("POST /tasks = %d %s, want %d", rec.Code, strings.TrimSpace(rec.Body.String()), tt.want)
+			}
+		})
+	}
+}
+
+func TestServeWithTokenAllowsOtherHosts(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	handler := newBoardServer(filepath.Join(t.TempDir(), "board.json"), "secret").handler()
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+	req.Host = "tasks.example:8080"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("without the token GET /tasks = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("with the token GET /tasks = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
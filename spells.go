@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"gotask/internal/config"
+	"gotask/internal/storage"
+)
+
+// exportedTask is a task as "gotask export" writes it
+type exportedTask struct {
+	ID          int           `json:"id"`
+	Title       string        `json:"title"`
+	Tags        []string      `json:"tags,omitempty"`
+	Points      float64       `json:"points,omitempty"`
+	Column      string        `json:"column"`
+	Archived    bool          `json:"archived,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+	CompletedAt *time.Time    `json:"completed_at,omitempty"`
+	Spells      []ColumnSpell `json:"spells"`
+}
+
+// exportTasks lists every task on the board and in the archive with the
+// spells it spent in each column
+func exportTasks(b *KanbanBoard) []exportedTask {
+	var tasks []exportedTask
+	add := func(task Task, column string, archived bool) {
+		tasks = append(tasks, exportedTask{
+			ID:          task.ID,
+			Title:       task.Title,
+			Tags:        task.Tags,
+			Points:      task.Points,
+			Column:      column,
+			Archived:    archived,
+			CreatedAt:   task.CreatedAt,
+			CompletedAt: task.CompletedAt,
+			Spells:      task.ColumnSpells(),
+		})
+	}
+	for _, col := range b.Columns {
+		for _, task := range col.Tasks {
+			add(task, col.Title, false)
+		}
+	}
+	for _, task := range b.Archive {
+		add(task.Task, task.Column, true)
+	}
+	return tasks
+}
+
+// writeSpellsCSV writes a row for each spell a task spent in a column,
+// with the hours it lasted, or lasted until now for open ones
+func writeSpellsCSV(w io.Writer, tasks []exportedTask, now time.Time) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"id", "title", "column", "entered", "left", "hours"})
+	for _, task := range tasks {
+		for _, s := range task.Spells {
+			left, end := "", now
+			if s.Left != nil {
+				left, end = s.Left.Format(time.RFC3339), *s.Left
+			}
+			out.Write([]string{strconv.Itoa(task.ID), task.Title, s.Column,
+				s.Entered.Format(time.RFC3339), left, strconv.FormatFloat(end.Sub(s.Entered).Hours(), 'f', 2, 64)})
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// runExport implements "gotask export", which writes every task with the
+// time it spent in each column, as JSON or as CSV with a row per spell,
+// and "gotask export changelog"
+func runExport(args []string) error {
+	if len(args) > 0 && args[0] == "changelog" {
+		return runChangelog(args[1:])
+	}
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flags.String("format", "json", "json or csv")
+	path := flags.String("file", config.BoardPath(), "board file to read")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("-format: use json or csv, not %q", *format)
+	}
+	board, err := storage.Load(*path)
+	if err != nil {
+		return err
+	}
+	tasks := exportTasks(&board)
+	if *format == "csv" {
+		return writeSpellsCSV(os.Stdout, tasks, time.Now())
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(tasks)
+}
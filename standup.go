@@ -0,0 +1,109 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/config"
+	"gotask/internal/stats"
+	"gotask/internal/storage"
+)
+
+// blockedTag is the tag that marks a task as blocked, unless -blocked-tag
+// says otherwise
+const blockedTag = "blocked"
+
+// standup is what to say at a daily standup
+type standup struct {
+	Completed []stats.Completed // finished since the last working day began
+	Working   []Task            // in progress and not blocked
+	Blocked   []Task            // open and tagged as blocked
+}
+
+// lastWorkday returns midnight at the start of the working day before
+// now's, skipping back over weekends to Friday
+func lastWorkday(now time.Time) time.Time {
+	day := board.DayOf(now).AddDate(0, 0, -1)
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// newStandup gathers what was finished since the given time, what is in
+// progress, and what is blocked
+func newStandup(b *KanbanBoard, since time.Time, blocked string) standup {
+	s := standup{Completed: stats.CompletedSince(b, since)}
+	for i, col := range b.Columns {
+		if b.IsDone(i) {
+			continue
+		}
+		for _, task := range col.Tasks {
+			switch {
+			case board.HasTag(task, blocked):
+				s.Blocked = append(s.Blocked, task)
+			case i > 0:
+				s.Working = append(s.Working, task)
+			}
+		}
+	}
+	return s
+}
+
+// write prints the standup ready to paste into chat
+func (s standup) write(w io.Writer, since, now time.Time) {
+	list := func(tasks []Task) {
+		if len(tasks) == 0 {
+			fmt.Fprintln(w, "- nothing")
+		}
+		for _, task := range tasks {
+			fmt.Fprintf(w, "- %s (#%d)\n", task.Title, task.ID)
+		}
+	}
+	completed := make([]Task, len(s.Completed))
+	for i, task := range s.Completed {
+		completed[i] = task.Task
+	}
+	when := "Yesterday"
+	if !since.Equal(board.DayOf(now).AddDate(0, 0, -1)) {
+		when = "Since " + since.Format("Monday")
+	}
+	fmt.Fprintf(w, "%s I completed:\n", when)
+	list(completed)
+	fmt.Fprintln(w, "\nToday I'm working on:")
+	list(s.Working)
+	fmt.Fprintln(w, "\nBlocked:")
+	list(s.Blocked)
+}
+
+// runStandup implements "gotask standup", which prints what was finished
+// since the last working day, what is in progress, and what is blocked
+func runStandup(args []string) error {
+	flags := flag.NewFlagSet("standup", flag.ContinueOnError)
+	sinceFlag := flags.String("since", "", "how far back to look for completed tasks, e.g. 2d (default since the last working day)")
+	blocked := flags.String("blocked-tag", blockedTag, "tag that marks tasks as blocked")
+	path := flags.String("file", config.BoardPath(), "board file to read")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	now := time.Now()
+	since := lastWorkday(now)
+	if *sinceFlag != "" {
+		days, err := stats.ParseDays(*sinceFlag)
+		if err != nil {
+			return fmt.Errorf("-since: %w", err)
+		}
+		since = board.DayOf(now).AddDate(0, 0, -days)
+	}
+	board, err := storage.Load(*path)
+	if err != nil {
+		return err
+	}
+	newStandup(&board, since, strings.TrimPrefix(*blocked, "#")).write(os.Stdout, since, now)
+	return nil
+}
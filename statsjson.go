@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"time"
+
+	"gotask/internal/board"
+	"gotask/internal/stats"
+	"gotask/internal/ui"
+)
+
+// hours gives a duration in hours to two decimal places, as the JSON
+// statistics have them
+func hours(d time.Duration) float64 {
+	return math.Round(d.Hours()*100) / 100
+}
+
+// durationsJSON is a stats.Durations in hours
+type durationsJSON struct {
+	Count     int     `json:"count"`
+	MeanHours float64 `json:"mean_hours"`
+	P50Hours  float64 `json:"p50_hours"`
+	P85Hours  float64 `json:"p85_hours"`
+	P95Hours  float64 `json:"p95_hours"`
+}
+
+// inHours converts the durations to hours for JSON
+func inHours(d stats.Durations) durationsJSON {
+	return durationsJSON{d.Count, hours(d.Mean), hours(d.P50), hours(d.P85), hours(d.P95)}
+}
+
+// statsJSON is every statistic "gotask stats -json" writes. Times are
+// measured over the last WindowDays days unless the name says otherwise.
+type statsJSON struct {
+	GeneratedAt       time.Time       `json:"generated_at"`
+	WindowDays        int             `json:"window_days"`
+	Columns           []columnJSON    `json:"columns"`
+	Open              int             `json:"open"`
+	AverageAgeHours   float64         `json:"average_age_hours"`
+	Oldest            *oldestJSON     `json:"oldest"`
+	CompletedThisWeek int             `json:"completed_this_week"`
+	CompletedByDay    [7]int          `json:"completed_by_day"` // Monday first
+	Burnup            []burnPointJSON `json:"burnup"`
+	CycleTimes        cycleTimesJSON  `json:"cycle_times"`
+	Tags              []tagStatsJSON  `json:"tags"`
+	Velocity          velocityJSON    `json:"velocity"`
+	Forecast          *forecastJSON   `json:"forecast"`                  // null without enough throughput to go on
+	FocusMinutes      []int           `json:"focus_minutes_last_7_days"` // today last
+	FocusSessions     int             `json:"focus_sessions_last_7_days"`
+	Heatmap           heatmapJSON     `json:"heatmap"`
+}
+
+type columnJSON struct {
+	Title string `json:"title"`
+	Count int    `json:"count"`
+}
+
+type oldestJSON struct {
+	ID       int     `json:"id"`
+	Title    string  `json:"title"`
+	Column   string  `json:"column"`
+	AgeHours float64 `json:"age_hours"`
+}
+
+type burnPointJSON struct {
+	Day   string `json:"day"`
+	Scope int    `json:"scope"`
+	Done  int    `json:"done"`
+}
+
+type cycleTimesJSON struct {
+	Lead    durationsJSON    `json:"lead"`
+	Cycle   durationsJSON    `json:"cycle"`
+	Columns []columnTimeJSON `json:"columns"`
+}
+
+type columnTimeJSON struct {
+	Column string `json:"column"`
+	durationsJSON
+}
+
+type tagStatsJSON struct {
+	Tag          string        `json:"tag"`
+	Open         int           `json:"open"`
+	Completed    int           `json:"completed"`
+	Cycle        durationsJSON `json:"cycle"`
+	TrackedHours float64       `json:"tracked_hours"`
+}
+
+type velocityJSON struct {
+	Weeks   []weekJSON `json:"weeks"`
+	Average float64    `json:"average"`
+}
+
+type weekJSON struct {
+	Start  string  `json:"start"`
+	Points float64 `json:"points"`
+	Tasks  int     `json:"tasks"`
+}
+
+type forecastJSON struct {
+	Throughput float64            `json:"throughput"` // tasks a day
+	Tasks      []taskForecastJSON `json:"tasks"`      // the last is all of them
+}
+
+type taskForecastJSON struct {
+	ID     int    `json:"id"`
+	Column string `json:"column"`
+	durationsJSON
+}
+
+type heatmapJSON struct {
+	Days          map[string]int `json:"days"` // YYYY-MM-DD, days with completions only
+	CurrentStreak int            `json:"current_streak"`
+	LongestStreak int            `json:"longest_streak"`
+}
+
+// newStatsJSON gathers the statistics for "gotask stats -json"
+func newStatsJSON(b *KanbanBoard, days int, now time.Time) statsJSON {
+	since := now.AddDate(0, 0, -days)
+	s := stats.Of(b, now)
+	out := statsJSON{
+		GeneratedAt:       now,
+		WindowDays:        days,
+		Open:              s.Open,
+		AverageAgeHours:   hours(s.AverageAge),
+		CompletedThisWeek: s.CompletedThisWeek(),
+		CompletedByDay:    s.CompletedByDay,
+	}
+	for _, col := range s.Columns {
+		out.Columns = append(out.Columns, columnJSON{col.Title, col.Count})
+	}
+	if s.Oldest != nil {
+		out.Oldest = &oldestJSON{s.Oldest.ID, s.Oldest.Title, s.OldestIn, hours(now.Sub(s.Oldest.CreatedAt))}
+	}
+	for _, p := range stats.Burndown(b, days, now) {
+		out.Burnup = append(out.Burnup, burnPointJSON{p.Day.Format(board.DueLayout), p.Scope, p.Done})
+	}
+
+	c := stats.NewCycleTimes(b, since)
+	out.CycleTimes = cycleTimesJSON{Lead: inHours(c.Lead), Cycle: inHours(c.Cycle)}
+	for _, col := range c.Columns {
+		out.CycleTimes.Columns = append(out.CycleTimes.Columns, columnTimeJSON{col.Title, inHours(col.Durations)})
+	}
+	for _, t := range stats.ByTag(b, since) {
+		out.Tags = append(out.Tags, tagStatsJSON{t.Tag, t.Open, t.Completed, inHours(t.Cycle), hours(t.Tracked)})
+	}
+
+	v := stats.Velocity(b, stats.VelocityWeeks, now)
+	for _, week := range v {
+		out.Velocity.Weeks = append(out.Velocity.Weeks, weekJSON{week.Start.Format(board.DueLayout), stats.RoundTenth(week.Points), week.Tasks})
+	}
+	out.Velocity.Average = stats.RoundTenth(stats.AverageVelocity(v))
+
+	if f, ok := stats.NewForecast(b, days, now); ok {
+		out.Forecast = &forecastJSON{Throughput: f.Throughput}
+		for _, t := range f.Tasks {
+			out.Forecast.Tasks = append(out.Forecast.Tasks, taskForecastJSON{t.Task.ID, t.Column, inHours(t.Durations)})
+		}
+	}
+
+	focus, sessions := stats.FocusDays(b, 7, now)
+	out.FocusMinutes, out.FocusSessions = make([]int, len(focus)), sessions
+	for i, d := range focus {
+		out.FocusMinutes[i] = int(d.Minutes())
+	}
+
+	out.Heatmap.Days = stats.CompletionsByDay(b)
+	first := stats.StartOfWeek(now).AddDate(0, 0, -7*(ui.MaxHeatWeeks-1))
+	out.Heatmap.CurrentStreak, out.Heatmap.LongestStreak = stats.Streaks(out.Heatmap.Days, first, now)
+	return out
+}
+
+// writeStatsJSON writes the statistics as indented JSON
+func writeStatsJSON(w io.Writer, board *KanbanBoard, days int, now time.Time) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(newStatsJSON(board, days, now))
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/template"
+	"time"
+
+	"gotask/internal/config"
+	"gotask/internal/stats"
+	"gotask/internal/storage"
+)
+
+// defaultStatusFormat is what "gotask status" prints without -format
+const defaultStatusFormat = "{{.TodoCount}} todo {{.InProgressCount}} doing{{if .DueToday}} {{.DueToday}} due{{end}}{{if .Overdue}} {{.Overdue}} overdue{{end}}"
+
+// runStatus implements "gotask status", which prints a one-line summary
+// of the board for tmux, i3bar, waybar, and the like. It only reads the
+// board file, so it is cheap enough to poll every few seconds.
+func runStatus(args []string) error {
+	flags := flag.NewFlagSet("status", flag.ContinueOnError)
+	format := flags.String("format", defaultStatusFormat, "text/template for the output, e.g. '{{.InProgressCount}}|{{.DueToday}}'")
+	path := flags.String("file", config.BoardPath(), "board file to read")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("status").Parse(*format)
+	if err != nil {
+		return fmt.Errorf("-format: %w", err)
+	}
+	board, err := storage.Load(*path)
+	if err != nil {
+		return err
+	}
+	if err := tmpl.Execute(os.Stdout, stats.Summarize(&board, time.Now())); err != nil {
+		return err
+	}
+	fmt.Println()
+	return nil
+}
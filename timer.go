@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"gotask/internal/config"
+	"gotask/internal/notify"
+)
+
+// sentMaxAge is how long "gotask daemon -once" remembers an alert it sent
+const sentMaxAge = 7 * 24 * time.Hour
+
+// sentPath is where "gotask daemon -once" keeps the alerts it sent
+func sentPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "gotask", "sent.json")
+}
+
+// checkOnce sends the notifications, digest, and reports that are due,
+// remembering what was sent so the next run doesn't send it again
+func checkOnce(path string, notifier notify.Settings, email config.Email, digestAt time.Duration, reports []scheduledReport, now time.Time) error {
+	record := make(map[string]time.Time)
+	if data, err := os.ReadFile(sentPath()); err == nil {
+		if err := json.Unmarshal(data, &record); err != nil {
+			return fmt.Errorf("%s: %w", sentPath(), err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	sent := make(map[string]bool)
+	for key, at := range record {
+		if now.Sub(at) < sentMaxAge {
+			sent[key] = true
+		} else {
+			delete(record, key)
+		}
+	}
+
+	checkBoard(path, notifier, now, sent)
+	checkDigest(path, email, digestAt, now, sent)
+	checkReports(path, reports, now, sent)
+	checkFlow(path, now)
+
+	for key := range sent {
+		if _, ok := record[key]; !ok {
+			record[key] = now
+		}
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(sentPath()), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(sentPath(), data, 0644)
+}
+
+// timerName names the systemd units and the launchd job
+const (
+	timerName = "gotask"
+	launchdID = "com.github.justinmdickey.gotask"
+)
+
+// timerSchedule is when the timer fires: every interval, or daily at a
+// time of day
+type timerSchedule struct {
+	every time.Duration
+	at    time.Duration // time of day, or -1
+}
+
+// runInstallTimer implements "gotask install-timer", which has systemd
+// (Linux) or launchd (macOS) run "gotask daemon -once" on a schedule, so
+// notifications and the digest go out without a daemon running
+func runInstallTimer(args []string) error {
+	flags := flag.NewFlagSet("install-timer", flag.ContinueOnError)
+	every := flags.Duration("every", 15*time.Minute, "how often to check")
+	at := flags.String("at", "", "check once a day at this time instead, e.g. 08:00")
+	path := flags.String("file", "", "board file to check (default ~/.kanban.json)")
+	printOnly := flags.Bool("print", false, "print the files instead of installing them")
+	uninstall := flags.Bool("uninstall", false, "remove the timer")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	schedule := timerSchedule{every: *every, at: -1}
+	if *at != "" {
+		d, err := notify.ParseTimeOfDay(*at)
+		if err != nil {
+			return fmt.Errorf("-at: %w", err)
+		}
+		schedule.at = d
+	} else if *every < time.Minute {
+		return errors.New("-every must be at least a minute")
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	command := []string{exe, "daemon", "-once"}
+	if *path != "" {
+		abs, err := filepath.Abs(*path)
+		if err != nil {
+			return err
+		}
+		command = append(command, "-file", abs)
+	}
+
+	var files [][2]string // paths and contents
+	var activate, deactivate [][]string
+	switch runtime.GOOS {
+	case "linux":
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return err
+		}
+		dir = filepath.Join(dir, "systemd", "user")
+		files = [][2]string{
+			{filepath.Join(dir, timerName+".service"), systemdService(command)},
+			{filepath.Join(dir, timerName+".timer"), systemdTimer(schedule)},
+		}
+		activate = [][]string{
+			{"systemctl", "--user", "daemon-reload"},
+			{"systemctl", "--user", "enable", "--now", timerName + ".timer"},
+		}
+		deactivate = [][]string{{"systemctl", "--user", "disable", "--now", timerName + ".timer"}}
+	case "darwin":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		plist := filepath.Join(home, "Library", "LaunchAgents", launchdID+".plist")
+		files = [][2]string{{plist, launchdPlist(command, schedule)}}
+		activate = [][]string{{"launchctl", "load", "-w", plist}}
+		deactivate = [][]string{{"launchctl", "unload", "-w", plist}}
+	default:
+		return fmt.Errorf("install-timer supports systemd and launchd, not %s; schedule \"%s\" yourself", runtime.GOOS, strings.Join(command, " "))
+	}
+
+	switch {
+	case *printOnly:
+		for _, f := range files {
+			fmt.Printf("# %s\n%s\n", f[0], f[1])
+		}
+		return nil
+	case *uninstall:
+		for _, args := range deactivate {
+			// The timer may not be loaded; removing the files is what counts
+			exec.Command(args[0], args[1:]...).Run()
+		}
+		for _, f := range files {
+			if err := os.Remove(f[0]); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			fmt.Printf("Removed %s\n", f[0])
+		}
+		return nil
+	}
+
+	// Reload an existing launchd job so it picks up the new schedule
+	if runtime.GOOS == "darwin" {
+		for _, args := range deactivate {
+			exec.Command(args[0], args[1:]...).Run()
+		}
+	}
+	for _, f := range files {
+		if err := os.MkdirAll(filepath.Dir(f[0]), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(f[0], []byte(f[1]), 0644); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s\n", f[0])
+	}
+	for _, args := range activate {
+		if out, err := exec.Command(args[0], args[1:]...).CombinedOutput(); err != nil {
+			return fmt.Errorf("%s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+		}
+	}
+	fmt.Printf("Installed; %s\n", schedule)
+	return nil
+}
+
+func (s timerSchedule) String() string {
+	if s.at >= 0 {
+		return fmt.Sprintf("checking daily at %02d:%02d", int(s.at.Hours()), int(s.at.Minutes())%60)
+	}
+	return "checking every " + s.every.String()
+}
+
+// systemdService is the unit the timer starts
+func systemdService(command []string) string {
+	quoted := make([]string, len(command))
+	for i, arg := range command {
+		quoted[i] = systemdQuote(arg)
+	}
+	return fmt.Sprintf(`[Unit]
+Description=gotask notifications and digest
+
+[Service]
+Type=oneshot
+ExecStart=%s
+`, strings.Join(quoted, " "))
+}
+
+// systemdTimer starts the service on the schedule. Persistent catches up
+// on a daily check missed while the machine was off.
+func systemdTimer(s timerSchedule) string {
+	when := fmt.Sprintf("OnBootSec=1min\nOnUnitActiveSec=%ds", int(s.every.Seconds()))
+	if s.at >= 0 {
+		when = fmt.Sprintf("OnCalendar=*-*-* %02d:%02d:00\nPersistent=true", int(s.at.Hours()), int(s.at.Minutes())%60)
+	}
+	return fmt.Sprintf(`[Unit]
+Description=Run gotask notifications and digest on a schedule
+
+[Timer]
+%s
+
+[Install]
+WantedBy=timers.target
+`, when)
+}
+
+// systemdQuote quotes an argument for ExecStart when it needs it. Percent
+// signs are specifiers to systemd, so they are doubled.
+func systemdQuote(s string) string {
+	s = strings.ReplaceAll(s, "%", "%%")
+	if !strings.ContainsAny(s, " \t\"'\\") {
+		return s
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// launchdPlist is the launchd job running the command on the schedule
+func launchdPlist(command []string, s timerSchedule) string {
+	var args strings.Builder
+	for _, arg := range command {
+		args.WriteString("\t\t<string>" + xmlEscape(arg) + "</string>\n")
+	}
+	when := fmt.Sprintf("\t<key>StartInterval</key>\n\t<integer>%d</integer>\n\t<key>RunAtLoad</key>\n\t<true/>\n", int(s.every.Seconds()))
+	if s.at >= 0 {
+		when = fmt.Sprintf("\t<key>StartCalendarInterval</key>\n\t<dict>\n\t\t<key>Hour</key>\n\t\t<integer>%d</integer>\n\t\t<key>Minute</key>\n\t\t<integer>%d</integer>\n\t</dict>\n",
+			int(s.at.Hours()), int(s.at.Minutes())%60)
+	}
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>%s</string>
+	<key>ProgramArguments</key>
+	<array>
+%s	</array>
+%s</dict>
+</plist>
+`, launchdID, args.String(), when)
+}
+
+func xmlEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}